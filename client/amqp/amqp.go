@@ -9,6 +9,7 @@ import (
 
 	"github.com/beatlabs/patron/correlation"
 	"github.com/beatlabs/patron/observability"
+	"github.com/beatlabs/patron/observability/log"
 	patronmetric "github.com/beatlabs/patron/observability/metric"
 	patrontrace "github.com/beatlabs/patron/observability/trace"
 	amqp "github.com/rabbitmq/amqp091-go"
@@ -30,9 +31,10 @@ func init() {
 
 // Publisher defines a RabbitMQ publisher with tracing instrumentation.
 type Publisher struct {
-	cfg        *amqp.Config
-	connection *amqp.Connection
-	channel    *amqp.Channel
+	cfg               *amqp.Config
+	connection        *amqp.Connection
+	channel           *amqp.Channel
+	propagateLogLevel bool
 }
 
 // New constructor.
@@ -79,6 +81,9 @@ func New(url string, oo ...OptionFunc) (*Publisher, error) {
 func (tc *Publisher) Publish(ctx context.Context, exchange, key string, mandatory, immediate bool, msg amqp.Publishing) error {
 	ctx, sp := injectTraceHeaders(ctx, exchange, &msg)
 	defer sp.End()
+	if tc.propagateLogLevel {
+		injectLogLevelHeaders(ctx, &msg)
+	}
 
 	start := time.Now()
 	err := tc.channel.PublishWithContext(ctx, exchange, key, mandatory, immediate, msg)
@@ -108,6 +113,18 @@ func injectTraceHeaders(ctx context.Context, exchange string, msg *amqp.Publishi
 	return ctx, sp
 }
 
+// injectLogLevelHeaders propagates the log level requested in the context, see log.WithLevel.
+func injectLogLevelHeaders(ctx context.Context, msg *amqp.Publishing) {
+	value, signature, ok := log.PropagatedLevel(ctx)
+	if !ok {
+		return
+	}
+	msg.Headers[log.LevelHeader] = value
+	if signature != "" {
+		msg.Headers[log.LevelSignatureHeader] = signature
+	}
+}
+
 // Close the channel and connection.
 func (tc *Publisher) Close() error {
 	return errors.Join(tc.channel.Close(), tc.connection.Close())
@@ -2,10 +2,12 @@ package amqp
 
 import (
 	"context"
+	"log/slog"
 	"testing"
 	"time"
 
 	"github.com/beatlabs/patron/correlation"
+	"github.com/beatlabs/patron/observability/log"
 	patrontrace "github.com/beatlabs/patron/observability/trace"
 	amqp "github.com/rabbitmq/amqp091-go"
 	"github.com/stretchr/testify/assert"
@@ -119,6 +121,30 @@ func TestInjectTraceHeaders_WithCorrelation(t *testing.T) {
 		sp.End()
 	})
 
+	t.Run("does not inject log level into headers", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := log.WithLevel(context.Background(), slog.LevelDebug)
+		msg := amqp.Publishing{}
+
+		_, sp := injectTraceHeaders(ctx, "test-exchange", &msg)
+		sp.End()
+
+		assert.NotContains(t, msg.Headers, log.LevelHeader)
+	})
+
+	t.Run("injects log level into headers", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := log.WithLevel(context.Background(), slog.LevelDebug)
+		msg := amqp.Publishing{Headers: amqp.Table{}}
+
+		injectLogLevelHeaders(ctx, &msg)
+
+		assert.Equal(t, "debug", msg.Headers[log.LevelHeader])
+		assert.NotContains(t, msg.Headers, log.LevelSignatureHeader)
+	})
+
 	t.Run("creates headers table if nil", func(t *testing.T) {
 		t.Parallel()
 
@@ -14,3 +14,13 @@ func WithConfig(cfg amqp.Config) OptionFunc {
 		return nil
 	}
 }
+
+// WithLogLevelPropagation option for propagating the log level requested in the context of the messages published,
+// see log.WithLevel, in the log.LevelHeader and log.LevelSignatureHeader headers. It should be used only with
+// trusted consumers, as the signature can be replayed by them until it expires.
+func WithLogLevelPropagation() OptionFunc {
+	return func(p *Publisher) error {
+		p.propagateLogLevel = true
+		return nil
+	}
+}
@@ -17,3 +17,9 @@ func TestTimeout(t *testing.T) {
 	require.NoError(t, WithConfig(cfg)(&p))
 	assert.Equal(t, cfg, *p.cfg)
 }
+
+func TestWithLogLevelPropagation(t *testing.T) {
+	p := Publisher{}
+	require.NoError(t, WithLogLevelPropagation()(&p))
+	assert.True(t, p.propagateLogLevel)
+}
@@ -2,18 +2,117 @@
 package grpc
 
 import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/beatlabs/patron/correlation"
+	"github.com/beatlabs/patron/observability/log"
 	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
 )
 
-// DialContext creates a client connection to the given target with a context and
-// a tracing and metrics unary interceptor.
+// logger of the client, whose level can be changed at runtime through the "grpc" name along with the component.
+var logger = log.Named("grpc")
+
+// NewClient creates a client connection to the given target with tracing and metrics.
+// The correlation ID of the context is propagated in the correlation.HeaderID metadata of the calls,
+// which are logged at debug level.
 func NewClient(target string, opts ...grpc.DialOption) (*grpc.ClientConn, error) {
-	if len(opts) == 0 {
-		opts = make([]grpc.DialOption, 0)
+	return newClient(target, &config{dialOptions: opts})
+}
+
+// New creates a client connection to the given target with tracing and metrics, as NewClient does,
+// configured with functional options, e.g. for retries, deadlines, circuit breaking and load balancing.
+func New(target string, oo ...OptionFunc) (*grpc.ClientConn, error) {
+	cfg := &config{}
+	for _, o := range oo {
+		if err := o(cfg); err != nil {
+			return nil, err
+		}
+	}
+	return newClient(target, cfg)
+}
+
+func newClient(target string, cfg *config) (*grpc.ClientConn, error) {
+	// the observability interceptors are chained first, so that the interceptors provided see the correlation ID.
+	unaryInterceptors := []grpc.UnaryClientInterceptor{unaryInterceptor}
+	if cfg.timeout > 0 {
+		unaryInterceptors = append(unaryInterceptors, timeoutInterceptor(cfg.timeout))
+	}
+	if cfg.breakerSetting != nil {
+		unaryInterceptors = append(unaryInterceptors, newBreakers(target, *cfg.breakerSetting).unaryInterceptor)
+	}
+
+	opts := append([]grpc.DialOption{
+		grpc.WithChainUnaryInterceptor(unaryInterceptors...),
+		grpc.WithChainStreamInterceptor(streamInterceptor),
+	}, cfg.dialOptions...)
+
+	if cfg.retry != nil || cfg.roundRobin {
+		sc, err := cfg.serviceConfigJSON()
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, grpc.WithDefaultServiceConfig(sc))
 	}
 
 	opts = append(opts, grpc.WithStatsHandler(otelgrpc.NewClientHandler()))
 
 	return grpc.NewClient(target, opts...)
 }
+
+func unaryInterceptor(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn,
+	invoker grpc.UnaryInvoker, opts ...grpc.CallOption,
+) error {
+	ctx = outgoingContext(ctx)
+	start := time.Now()
+	err := invoker(ctx, method, req, reply, cc, opts...)
+	logCall(ctx, method, start, err)
+	return err
+}
+
+func streamInterceptor(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string,
+	streamer grpc.Streamer, opts ...grpc.CallOption,
+) (grpc.ClientStream, error) {
+	ctx = outgoingContext(ctx)
+	start := time.Now()
+	stream, err := streamer(ctx, desc, cc, method, opts...)
+	logCall(ctx, method, start, err)
+	return stream, err
+}
+
+// outgoingContext returns a context with the correlation ID in the outgoing metadata, unless already set.
+func outgoingContext(ctx context.Context) context.Context {
+	if md, ok := metadata.FromOutgoingContext(ctx); ok && len(md.Get(correlation.HeaderID)) > 0 {
+		return ctx
+	}
+	return metadata.AppendToOutgoingContext(ctx, correlation.HeaderID, correlation.IDFromContext(ctx))
+}
+
+func logCall(ctx context.Context, method string, start time.Time, err error) {
+	if !logger.Enabled(ctx, slog.LevelDebug) {
+		return
+	}
+
+	var corID string
+	if md, ok := metadata.FromOutgoingContext(ctx); ok {
+		if values := md.Get(correlation.HeaderID); len(values) > 0 {
+			corID = values[0]
+		}
+	}
+
+	attrs := []slog.Attr{
+		slog.String(correlation.ID, corID),
+		slog.String("method", method),
+		slog.String("code", status.Code(err).String()),
+		slog.Duration("duration", time.Since(start)),
+	}
+	if err != nil {
+		attrs = append(attrs, log.ErrorAttr(err))
+	}
+
+	logger.LogAttrs(ctx, slog.LevelDebug, "request log", attrs...)
+}
@@ -1,12 +1,16 @@
 package grpc
 
 import (
+	"context"
+	"errors"
 	"testing"
 
+	"github.com/beatlabs/patron/correlation"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
 )
 
 const (
@@ -44,3 +48,53 @@ func TestNewClient(t *testing.T) {
 		})
 	}
 }
+
+func TestUnaryInterceptor(t *testing.T) {
+	tests := map[string]struct {
+		ctx        context.Context
+		expectedID string
+	}{
+		"correlation id in context":  {ctx: correlation.ContextWithID(context.Background(), "123"), expectedID: "123"},
+		"correlation id in metadata": {ctx: metadata.AppendToOutgoingContext(context.Background(), correlation.HeaderID, "456"), expectedID: "456"},
+		"no correlation id":          {ctx: context.Background()},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			expectedErr := errors.New("ERROR")
+			var md metadata.MD
+			err := unaryInterceptor(tt.ctx, "/examples.Greeter/SayHello", nil, nil, nil,
+				func(ctx context.Context, _ string, _, _ any, _ *grpc.ClientConn, _ ...grpc.CallOption) error {
+					md, _ = metadata.FromOutgoingContext(ctx)
+					return expectedErr
+				})
+
+			require.ErrorIs(t, err, expectedErr)
+			assertCorrelationID(t, md, tt.expectedID)
+		})
+	}
+}
+
+func TestStreamInterceptor(t *testing.T) {
+	var md metadata.MD
+	ctx := correlation.ContextWithID(context.Background(), "123")
+	stream, err := streamInterceptor(ctx, &grpc.StreamDesc{ServerStreams: true}, nil, "/examples.Greeter/SayHelloStream",
+		func(ctx context.Context, _ *grpc.StreamDesc, _ *grpc.ClientConn, _ string, _ ...grpc.CallOption) (grpc.ClientStream, error) {
+			md, _ = metadata.FromOutgoingContext(ctx)
+			return nil, nil
+		})
+
+	require.NoError(t, err)
+	assert.Nil(t, stream)
+	assertCorrelationID(t, md, "123")
+}
+
+func assertCorrelationID(t *testing.T, md metadata.MD, expectedID string) {
+	t.Helper()
+	values := md.Get(correlation.HeaderID)
+	require.Len(t, values, 1)
+	if expectedID != "" {
+		assert.Equal(t, expectedID, values[0])
+	} else {
+		assert.NotEmpty(t, values[0])
+	}
+}
@@ -0,0 +1,124 @@
+package grpc
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/beatlabs/patron/reliability/circuitbreaker"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+)
+
+const maxRetryAttempts = 5
+
+// OptionFunc configures the client created with New.
+type OptionFunc func(*config) error
+
+type config struct {
+	dialOptions    []grpc.DialOption
+	retry          *RetryPolicy
+	timeout        time.Duration
+	breakerSetting *circuitbreaker.Setting
+	roundRobin     bool
+}
+
+// RetryPolicy retries the calls failing with the given codes, waiting for a random backoff, up to the current
+// backoff, between the attempts. The backoff starts at InitialBackoff and is multiplied by BackoffMultiplier after
+// every attempt, up to MaxBackoff.
+type RetryPolicy struct {
+	// MaxAttempts including the original call, from 2 to 5.
+	MaxAttempts int
+	// InitialBackoff before the first retry.
+	InitialBackoff time.Duration
+	// MaxBackoff between retries.
+	MaxBackoff time.Duration
+	// BackoffMultiplier of the backoff after every attempt, 2 if zero.
+	BackoffMultiplier float64
+	// Codes of the calls retried, e.g. codes.Unavailable.
+	Codes []codes.Code
+}
+
+func (p RetryPolicy) validate() error {
+	var errs []error
+	if p.MaxAttempts < 2 || p.MaxAttempts > maxRetryAttempts {
+		errs = append(errs, fmt.Errorf("retry max attempts should be between 2 and %d", maxRetryAttempts))
+	}
+	if p.InitialBackoff <= 0 {
+		errs = append(errs, errors.New("retry initial backoff must be positive"))
+	}
+	if p.MaxBackoff < p.InitialBackoff {
+		errs = append(errs, errors.New("retry max backoff must not be less than the initial backoff"))
+	}
+	if p.BackoffMultiplier < 0 {
+		errs = append(errs, errors.New("retry backoff multiplier must not be negative"))
+	}
+	if len(p.Codes) == 0 {
+		errs = append(errs, errors.New("no retry codes provided"))
+	}
+	for _, c := range p.Codes {
+		if c == codes.OK {
+			errs = append(errs, errors.New("retry code OK is invalid"))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// WithDialOptions applies grpc.DialOption values to the client, e.g. the transport credentials.
+func WithDialOptions(opts ...grpc.DialOption) OptionFunc {
+	return func(cfg *config) error {
+		if len(opts) == 0 {
+			return errors.New("no grpc dial options provided")
+		}
+		cfg.dialOptions = append(cfg.dialOptions, opts...)
+		return nil
+	}
+}
+
+// WithRetry retries the calls with the retry policy of gRPC. The attempts are bound by the deadline of the call.
+func WithRetry(policy RetryPolicy) OptionFunc {
+	return func(cfg *config) error {
+		if err := policy.validate(); err != nil {
+			return err
+		}
+		if policy.BackoffMultiplier == 0 {
+			policy.BackoffMultiplier = 2
+		}
+		cfg.retry = &policy
+		return nil
+	}
+}
+
+// WithTimeout sets the deadline of the unary calls whose context has no deadline.
+func WithTimeout(timeout time.Duration) OptionFunc {
+	return func(cfg *config) error {
+		if timeout <= 0 {
+			return errors.New("timeout must be positive")
+		}
+		cfg.timeout = timeout
+		return nil
+	}
+}
+
+// WithCircuitBreaker sets up a circuit breaker per method of the target for the unary calls. The calls failing
+// with the codes Unavailable, DeadlineExceeded, ResourceExhausted, Internal and Unknown count as failures.
+// The calls are rejected with the code Unavailable while the circuit is open.
+func WithCircuitBreaker(set circuitbreaker.Setting) OptionFunc {
+	return func(cfg *config) error {
+		// the setting is validated once, the circuit breakers are created per method.
+		if _, err := circuitbreaker.New("grpc", set); err != nil {
+			return fmt.Errorf("failed to set circuit breaker: %w", err)
+		}
+		cfg.breakerSetting = &set
+		return nil
+	}
+}
+
+// WithRoundRobin balances the calls across the addresses of the target with the round_robin policy,
+// e.g. the addresses resolved with DNS for a "dns:///host:port" target.
+func WithRoundRobin() OptionFunc {
+	return func(cfg *config) error {
+		cfg.roundRobin = true
+		return nil
+	}
+}
@@ -0,0 +1,106 @@
+package grpc
+
+import (
+	"testing"
+	"time"
+
+	"github.com/beatlabs/patron/reliability/circuitbreaker"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+func TestWithDialOptions(t *testing.T) {
+	cfg := &config{}
+	require.EqualError(t, WithDialOptions()(cfg), "no grpc dial options provided")
+	require.NoError(t, WithDialOptions(grpc.WithTransportCredentials(insecure.NewCredentials()))(cfg))
+	assert.Len(t, cfg.dialOptions, 1)
+}
+
+func TestWithRetry(t *testing.T) {
+	valid := RetryPolicy{
+		MaxAttempts:    3,
+		InitialBackoff: 100 * time.Millisecond,
+		MaxBackoff:     time.Second,
+		Codes:          []codes.Code{codes.Unavailable},
+	}
+	tests := map[string]struct {
+		policy      func(p RetryPolicy) RetryPolicy
+		expectedErr string
+	}{
+		"success": {policy: func(p RetryPolicy) RetryPolicy { return p }},
+		"too few attempts": {
+			policy:      func(p RetryPolicy) RetryPolicy { p.MaxAttempts = 1; return p },
+			expectedErr: "retry max attempts should be between 2 and 5",
+		},
+		"too many attempts": {
+			policy:      func(p RetryPolicy) RetryPolicy { p.MaxAttempts = 6; return p },
+			expectedErr: "retry max attempts should be between 2 and 5",
+		},
+		"zero initial backoff": {
+			policy:      func(p RetryPolicy) RetryPolicy { p.InitialBackoff = 0; return p },
+			expectedErr: "retry initial backoff must be positive",
+		},
+		"max backoff less than initial": {
+			policy:      func(p RetryPolicy) RetryPolicy { p.MaxBackoff = time.Millisecond; return p },
+			expectedErr: "retry max backoff must not be less than the initial backoff",
+		},
+		"negative multiplier": {
+			policy:      func(p RetryPolicy) RetryPolicy { p.BackoffMultiplier = -1; return p },
+			expectedErr: "retry backoff multiplier must not be negative",
+		},
+		"no codes": {
+			policy:      func(p RetryPolicy) RetryPolicy { p.Codes = nil; return p },
+			expectedErr: "no retry codes provided",
+		},
+		"code OK": {
+			policy:      func(p RetryPolicy) RetryPolicy { p.Codes = []codes.Code{codes.OK}; return p },
+			expectedErr: "retry code OK is invalid",
+		},
+		"all invalid": {
+			policy: func(RetryPolicy) RetryPolicy { return RetryPolicy{} },
+			expectedErr: "retry max attempts should be between 2 and 5\nretry initial backoff must be positive\n" +
+				"no retry codes provided",
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			cfg := &config{}
+			err := WithRetry(tt.policy(valid))(cfg)
+			if tt.expectedErr != "" {
+				require.EqualError(t, err, tt.expectedErr)
+				assert.Nil(t, cfg.retry)
+				return
+			}
+			require.NoError(t, err)
+			assert.InDelta(t, 2.0, cfg.retry.BackoffMultiplier, 0)
+		})
+	}
+}
+
+func TestWithTimeout(t *testing.T) {
+	cfg := &config{}
+	require.EqualError(t, WithTimeout(0)(cfg), "timeout must be positive")
+	require.EqualError(t, WithTimeout(-time.Second)(cfg), "timeout must be positive")
+	require.NoError(t, WithTimeout(time.Second)(cfg))
+	assert.Equal(t, time.Second, cfg.timeout)
+}
+
+func TestWithCircuitBreaker(t *testing.T) {
+	cfg := &config{}
+	err := WithCircuitBreaker(circuitbreaker.Setting{RetrySuccessThreshold: 2, MaxRetryExecutionThreshold: 1})(cfg)
+	require.EqualError(t, err, "failed to set circuit breaker: max retry has to be greater than the retry threshold")
+	assert.Nil(t, cfg.breakerSetting)
+
+	set := circuitbreaker.Setting{FailureThreshold: 1, RetryTimeout: time.Second, RetrySuccessThreshold: 1, MaxRetryExecutionThreshold: 1}
+	require.NoError(t, WithCircuitBreaker(set)(cfg))
+	assert.Equal(t, set, *cfg.breakerSetting)
+}
+
+func TestWithRoundRobin(t *testing.T) {
+	cfg := &config{}
+	require.NoError(t, WithRoundRobin()(cfg))
+	assert.True(t, cfg.roundRobin)
+}
@@ -0,0 +1,142 @@
+package grpc
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/beatlabs/patron/reliability/circuitbreaker"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type serviceConfig struct {
+	LoadBalancingConfig []map[string]struct{} `json:"loadBalancingConfig,omitempty"`
+	MethodConfig        []methodConfig        `json:"methodConfig,omitempty"`
+}
+
+type methodConfig struct {
+	Name        []struct{}  `json:"name"`
+	RetryPolicy retryConfig `json:"retryPolicy"`
+}
+
+type retryConfig struct {
+	MaxAttempts          int          `json:"maxAttempts"`
+	InitialBackoff       string       `json:"initialBackoff"`
+	MaxBackoff           string       `json:"maxBackoff"`
+	BackoffMultiplier    float64      `json:"backoffMultiplier"`
+	RetryableStatusCodes []codes.Code `json:"retryableStatusCodes"`
+}
+
+// serviceConfigJSON returns the default service config of the client with the load balancing and retry policies.
+func (cfg *config) serviceConfigJSON() (string, error) {
+	sc := serviceConfig{}
+	if cfg.roundRobin {
+		sc.LoadBalancingConfig = []map[string]struct{}{{"round_robin": {}}}
+	}
+	if cfg.retry != nil {
+		sc.MethodConfig = []methodConfig{{
+			// an empty name matches all the methods.
+			Name: []struct{}{{}},
+			RetryPolicy: retryConfig{
+				MaxAttempts:          cfg.retry.MaxAttempts,
+				InitialBackoff:       durationJSON(cfg.retry.InitialBackoff),
+				MaxBackoff:           durationJSON(cfg.retry.MaxBackoff),
+				BackoffMultiplier:    cfg.retry.BackoffMultiplier,
+				RetryableStatusCodes: cfg.retry.Codes,
+			},
+		}}
+	}
+
+	b, err := json.Marshal(sc)
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
+
+// durationJSON formats the duration as a JSON protobuf duration, e.g. "0.1s".
+func durationJSON(d time.Duration) string {
+	return strconv.FormatFloat(d.Seconds(), 'f', -1, 64) + "s"
+}
+
+// timeoutInterceptor sets the deadline of the calls whose context has no deadline.
+func timeoutInterceptor(timeout time.Duration) grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker,
+		opts ...grpc.CallOption,
+	) error {
+		if _, ok := ctx.Deadline(); ok {
+			return invoker(ctx, method, req, reply, cc, opts...)
+		}
+		ctx, cancel := context.WithTimeout(ctx, timeout)
+		defer cancel()
+		return invoker(ctx, method, req, reply, cc, opts...)
+	}
+}
+
+// breakers holds a circuit breaker per method of a target.
+type breakers struct {
+	target  string
+	setting circuitbreaker.Setting
+	mu      sync.Mutex
+	byName  map[string]*circuitbreaker.CircuitBreaker
+}
+
+func newBreakers(target string, setting circuitbreaker.Setting) *breakers {
+	return &breakers{target: target, setting: setting, byName: make(map[string]*circuitbreaker.CircuitBreaker)}
+}
+
+func (b *breakers) get(method string) (*circuitbreaker.CircuitBreaker, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if cb, ok := b.byName[method]; ok {
+		return cb, nil
+	}
+	cb, err := circuitbreaker.New(b.target+method, b.setting)
+	if err != nil {
+		return nil, err
+	}
+	b.byName[method] = cb
+	return cb, nil
+}
+
+func (b *breakers) unaryInterceptor(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn,
+	invoker grpc.UnaryInvoker, opts ...grpc.CallOption,
+) error {
+	cb, err := b.get(method)
+	if err != nil {
+		return err
+	}
+
+	var callErr error
+	_, err = cb.Execute(ctx, func() (any, error) {
+		callErr = invoker(ctx, method, req, reply, cc, opts...)
+		if breakerFailure(callErr) {
+			return nil, callErr
+		}
+		return nil, nil
+	})
+	if errors.As(err, new(*circuitbreaker.OpenError)) {
+		return status.Error(codes.Unavailable, err.Error())
+	}
+	if err != nil && callErr == nil {
+		// the context is done before the call.
+		return status.FromContextError(err).Err()
+	}
+	return callErr
+}
+
+// breakerFailure returns true for the errors of a call signaling a failure of the server.
+func breakerFailure(err error) bool {
+	switch status.Code(err) {
+	case codes.Unavailable, codes.DeadlineExceeded, codes.ResourceExhausted, codes.Internal, codes.Unknown:
+		return true
+	default:
+		return false
+	}
+}
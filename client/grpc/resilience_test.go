@@ -0,0 +1,200 @@
+package grpc
+
+import (
+	"context"
+	"net"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/beatlabs/patron/examples"
+	"github.com/beatlabs/patron/reliability/circuitbreaker"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+)
+
+func TestConfig_ServiceConfigJSON(t *testing.T) {
+	retry := &RetryPolicy{
+		MaxAttempts: 3, InitialBackoff: 100 * time.Millisecond, MaxBackoff: 1500 * time.Millisecond,
+		BackoffMultiplier: 2, Codes: []codes.Code{codes.Unavailable, codes.ResourceExhausted},
+	}
+	tests := map[string]struct {
+		cfg      config
+		expected string
+	}{
+		"round robin": {cfg: config{roundRobin: true}, expected: `{"loadBalancingConfig":[{"round_robin":{}}]}`},
+		"retry": {
+			cfg: config{retry: retry},
+			expected: `{"methodConfig":[{"name":[{}],"retryPolicy":{"maxAttempts":3,"initialBackoff":"0.1s",` +
+				`"maxBackoff":"1.5s","backoffMultiplier":2,"retryableStatusCodes":[14,8]}}]}`,
+		},
+		"round robin and retry": {
+			cfg: config{roundRobin: true, retry: retry},
+			expected: `{"loadBalancingConfig":[{"round_robin":{}}],"methodConfig":[{"name":[{}],"retryPolicy":` +
+				`{"maxAttempts":3,"initialBackoff":"0.1s","maxBackoff":"1.5s","backoffMultiplier":2,` +
+				`"retryableStatusCodes":[14,8]}}]}`,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, err := tt.cfg.serviceConfigJSON()
+			require.NoError(t, err)
+			assert.JSONEq(t, tt.expected, got)
+
+			// the service config is validated by the client.
+			conn, err := grpc.NewClient(target, grpc.WithTransportCredentials(insecure.NewCredentials()),
+				grpc.WithDefaultServiceConfig(got))
+			require.NoError(t, err)
+			require.NoError(t, conn.Close())
+		})
+	}
+}
+
+func TestNew(t *testing.T) {
+	conn, err := New("dns:///localhost:50051",
+		WithDialOptions(grpc.WithTransportCredentials(insecure.NewCredentials())),
+		WithRetry(RetryPolicy{MaxAttempts: 2, InitialBackoff: time.Millisecond, MaxBackoff: time.Millisecond, Codes: []codes.Code{codes.Unavailable}}),
+		WithTimeout(time.Second),
+		WithCircuitBreaker(circuitbreaker.Setting{}),
+		WithRoundRobin(),
+	)
+	require.NoError(t, err)
+	require.NoError(t, conn.Close())
+
+	conn, err = New(target, WithTimeout(0))
+	require.EqualError(t, err, "timeout must be positive")
+	assert.Nil(t, conn)
+}
+
+func TestNew_Retry(t *testing.T) {
+	srv := &failingServer{failures: 2, code: codes.Unavailable}
+	addr := serve(t, srv)
+
+	tests := map[string]struct {
+		attempts     int
+		expectedCode codes.Code
+	}{
+		"retried":            {attempts: 3, expectedCode: codes.OK},
+		"not retried enough": {attempts: 2, expectedCode: codes.Unavailable},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			srv.calls.Store(0)
+			conn, err := New(addr, WithDialOptions(grpc.WithTransportCredentials(insecure.NewCredentials())),
+				WithRetry(RetryPolicy{
+					MaxAttempts: tt.attempts, InitialBackoff: time.Millisecond, MaxBackoff: 10 * time.Millisecond,
+					Codes: []codes.Code{codes.Unavailable},
+				}))
+			require.NoError(t, err)
+			defer func() { require.NoError(t, conn.Close()) }()
+
+			rsp, err := examples.NewGreeterClient(conn).SayHello(context.Background(), &examples.HelloRequest{FirstName: "TEST"})
+			assert.Equal(t, tt.expectedCode, status.Code(err))
+			if tt.expectedCode == codes.OK {
+				assert.Equal(t, "Hello TEST", rsp.GetMessage())
+			}
+			assert.Equal(t, int32(tt.attempts), srv.calls.Load())
+		})
+	}
+}
+
+func TestNew_Timeout(t *testing.T) {
+	addr := serve(t, &failingServer{block: true})
+	conn, err := New(addr, WithDialOptions(grpc.WithTransportCredentials(insecure.NewCredentials())),
+		WithTimeout(50*time.Millisecond))
+	require.NoError(t, err)
+	defer func() { require.NoError(t, conn.Close()) }()
+	client := examples.NewGreeterClient(conn)
+
+	_, err = client.SayHello(context.Background(), &examples.HelloRequest{FirstName: "TEST"})
+	assert.Equal(t, codes.DeadlineExceeded, status.Code(err))
+
+	// the deadline of the context is kept.
+	ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	_, err = client.SayHello(ctx, &examples.HelloRequest{FirstName: "TEST"})
+	assert.Equal(t, codes.DeadlineExceeded, status.Code(err))
+	assert.Less(t, time.Since(start), 50*time.Millisecond)
+}
+
+func TestNew_CircuitBreaker(t *testing.T) {
+	set := circuitbreaker.Setting{FailureThreshold: 1, RetryTimeout: time.Minute, RetrySuccessThreshold: 1, MaxRetryExecutionThreshold: 1}
+
+	tests := map[string]struct {
+		code          codes.Code
+		expectedCalls int32
+		expectedErr   string
+	}{
+		"opened by server failures": {
+			code: codes.Unavailable, expectedCalls: 1, expectedErr: "rpc error: code = Unavailable desc = circuit is open",
+		},
+		"not opened by client errors": {
+			code: codes.InvalidArgument, expectedCalls: 3, expectedErr: "rpc error: code = InvalidArgument desc = failure",
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			srv := &failingServer{failures: 100, code: tt.code}
+			addr := serve(t, srv)
+			conn, err := New(addr, WithDialOptions(grpc.WithTransportCredentials(insecure.NewCredentials())),
+				WithCircuitBreaker(set))
+			require.NoError(t, err)
+			defer func() { require.NoError(t, conn.Close()) }()
+			client := examples.NewGreeterClient(conn)
+
+			_, err = client.SayHello(context.Background(), &examples.HelloRequest{FirstName: "TEST"})
+			assert.Equal(t, tt.code, status.Code(err))
+			for range 2 {
+				_, err = client.SayHello(context.Background(), &examples.HelloRequest{FirstName: "TEST"})
+				require.EqualError(t, err, tt.expectedErr)
+			}
+			assert.Equal(t, tt.expectedCalls, srv.calls.Load())
+
+			// the circuit breakers are per method.
+			stream, err := client.SayHelloStream(context.Background(), &examples.HelloRequest{FirstName: "TEST"})
+			require.NoError(t, err)
+			_, err = stream.Recv()
+			assert.Equal(t, tt.code, status.Code(err))
+		})
+	}
+}
+
+// failingServer fails the first calls with the code, or blocks the calls until they are done.
+type failingServer struct {
+	examples.UnimplementedGreeterServer
+	failures int32
+	code     codes.Code
+	block    bool
+	calls    atomic.Int32
+}
+
+func (s *failingServer) SayHello(ctx context.Context, in *examples.HelloRequest) (*examples.HelloReply, error) {
+	if s.block {
+		<-ctx.Done()
+		return nil, ctx.Err()
+	}
+	if s.calls.Add(1) <= s.failures {
+		return nil, status.Error(s.code, "failure")
+	}
+	return &examples.HelloReply{Message: "Hello " + in.GetFirstName()}, nil
+}
+
+func (s *failingServer) SayHelloStream(_ *examples.HelloRequest, _ examples.Greeter_SayHelloStreamServer) error {
+	return status.Error(s.code, "failure")
+}
+
+func serve(t *testing.T, srv examples.GreeterServer) string {
+	t.Helper()
+	lis, err := (&net.ListenConfig{}).Listen(context.Background(), "tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	s := grpc.NewServer()
+	examples.RegisterGreeterServer(s, srv)
+	go func() { _ = s.Serve(lis) }()
+	t.Cleanup(s.Stop)
+	return lis.Addr().String()
+}
@@ -11,6 +11,7 @@ import (
 
 	"github.com/beatlabs/patron/correlation"
 	"github.com/beatlabs/patron/encoding"
+	"github.com/beatlabs/patron/observability/log"
 	"github.com/beatlabs/patron/reliability/circuitbreaker"
 	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
 )
@@ -22,8 +23,9 @@ type Client interface {
 
 // TracedClient defines an HTTP client with tracing integrated.
 type TracedClient struct {
-	cl *http.Client
-	cb *circuitbreaker.CircuitBreaker
+	cl                *http.Client
+	cb                *circuitbreaker.CircuitBreaker
+	propagateLogLevel bool
 }
 
 // New creates a new HTTP client.
@@ -47,8 +49,18 @@ func New(oo ...OptionFunc) (*TracedClient, error) {
 }
 
 // Do execute an HTTP request with integrated tracing and tracing propagation downstream.
+// The log level requested in the context, see log.WithLevel, is propagated downstream as well with
+// WithLogLevelPropagation.
 func (tc *TracedClient) Do(req *http.Request) (*http.Response, error) {
 	req.Header.Set(correlation.HeaderID, correlation.IDFromContext(req.Context()))
+	if tc.propagateLogLevel {
+		if value, signature, ok := log.PropagatedLevel(req.Context()); ok {
+			req.Header.Set(log.LevelHeader, value)
+			if signature != "" {
+				req.Header.Set(log.LevelSignatureHeader, signature)
+			}
+		}
+	}
 
 	rsp, err := tc.do(req)
 	if err != nil {
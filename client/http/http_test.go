@@ -14,7 +14,9 @@ import (
 	"testing"
 	"time"
 
+	"github.com/beatlabs/patron/correlation"
 	"github.com/beatlabs/patron/encoding"
+	"github.com/beatlabs/patron/observability/log"
 	"github.com/beatlabs/patron/reliability/circuitbreaker"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -83,6 +85,45 @@ func TestTracedClient_Do(t *testing.T) {
 	}
 }
 
+func TestTracedClient_Do_LogLevel(t *testing.T) {
+	var level, signature string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		level = r.Header.Get(log.LevelHeader)
+		signature = r.Header.Get(log.LevelSignatureHeader)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer ts.Close()
+
+	secret := []byte("secret")
+	sig := log.SignLevel(secret, "debug", "cor-1", time.Now().Add(time.Minute))
+	override, err := log.NewLevelOverride(log.LevelOverrideConfig{Levels: []string{"debug"}, Secret: secret})
+	require.NoError(t, err)
+	ctx := override.Context(correlation.ContextWithID(context.Background(), "cor-1"), "debug", sig)
+
+	tests := map[string]struct {
+		oo                []OptionFunc
+		expectedLevel     string
+		expectedSignature string
+	}{
+		"not propagated by default": {},
+		"propagated":                {oo: []OptionFunc{WithLogLevelPropagation()}, expectedLevel: "debug", expectedSignature: sig},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			c, err := New(tt.oo...)
+			require.NoError(t, err)
+			req, err := http.NewRequestWithContext(ctx, http.MethodGet, ts.URL, nil)
+			require.NoError(t, err)
+			rsp, err := c.Do(req)
+			require.NoError(t, err)
+			require.NoError(t, rsp.Body.Close())
+
+			assert.Equal(t, tt.expectedLevel, level)
+			assert.Equal(t, tt.expectedSignature, signature)
+		})
+	}
+}
+
 func TestTracedClient_Do_Redirect(t *testing.T) {
 	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		http.Redirect(w, r, "https://google.com", http.StatusSeeOther)
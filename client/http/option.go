@@ -58,3 +58,13 @@ func WithCheckRedirect(cr func(req *http.Request, via []*http.Request) error) Op
 		return nil
 	}
 }
+
+// WithLogLevelPropagation option for propagating the log level requested in the context of the requests, see
+// log.WithLevel, in the log.LevelHeader and log.LevelSignatureHeader headers. It should be used only with trusted
+// hosts, as the signature can be replayed by them until it expires.
+func WithLogLevelPropagation() OptionFunc {
+	return func(tc *TracedClient) error {
+		tc.propagateLogLevel = true
+		return nil
+	}
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/beatlabs/patron/correlation"
 	"github.com/beatlabs/patron/internal/validation"
 	"github.com/beatlabs/patron/observability"
+	"github.com/beatlabs/patron/observability/log"
 	patronmetric "github.com/beatlabs/patron/observability/metric"
 	"github.com/twmb/franz-go/pkg/kgo"
 	"github.com/twmb/franz-go/plugin/kotel"
@@ -41,7 +42,8 @@ func publishCountAdd(ctx context.Context, attrs ...attribute.KeyValue) {
 
 // Producer is a Kafka producer that supports both synchronous and asynchronous message sending.
 type Producer struct {
-	client *kgo.Client
+	client            *kgo.Client
+	propagateLogLevel bool
 }
 
 // New creates a new Kafka producer with the specified brokers and options.
@@ -69,7 +71,7 @@ func New(brokers []string, opts ...OptionFunc) (*Producer, error) {
 		return nil, fmt.Errorf("failed to create producer: %w", err)
 	}
 
-	return &Producer{client: cl}, nil
+	return &Producer{client: cl, propagateLogLevel: cfg.propagateLogLevel}, nil
 }
 
 // Send sends one or more messages synchronously. It returns the produced records
@@ -80,7 +82,7 @@ func (p *Producer) Send(ctx context.Context, records ...*kgo.Record) ([]*kgo.Rec
 	}
 
 	for _, rec := range records {
-		injectCorrelationHeader(ctx, rec)
+		p.injectHeaders(ctx, rec)
 	}
 
 	results := p.client.ProduceSync(ctx, records...)
@@ -107,7 +109,7 @@ func (p *Producer) Send(ctx context.Context, records ...*kgo.Record) ([]*kgo.Rec
 
 // SendAsync sends a message asynchronously. Any producer errors are sent to the provided error channel.
 func (p *Producer) SendAsync(ctx context.Context, rec *kgo.Record, chErr chan<- error) {
-	injectCorrelationHeader(ctx, rec)
+	p.injectHeaders(ctx, rec)
 
 	p.client.Produce(ctx, rec, func(r *kgo.Record, err error) {
 		if err != nil {
@@ -125,6 +127,13 @@ func (p *Producer) Close() {
 	p.client.Close()
 }
 
+func (p *Producer) injectHeaders(ctx context.Context, rec *kgo.Record) {
+	injectCorrelationHeader(ctx, rec)
+	if p.propagateLogLevel {
+		injectLogLevelHeaders(ctx, rec)
+	}
+}
+
 func injectCorrelationHeader(ctx context.Context, rec *kgo.Record) {
 	rec.Headers = append(rec.Headers, kgo.RecordHeader{
 		Key:   correlation.HeaderID,
@@ -132,6 +141,18 @@ func injectCorrelationHeader(ctx context.Context, rec *kgo.Record) {
 	})
 }
 
+// injectLogLevelHeaders propagates the log level requested in the context, see log.WithLevel.
+func injectLogLevelHeaders(ctx context.Context, rec *kgo.Record) {
+	value, signature, ok := log.PropagatedLevel(ctx)
+	if !ok {
+		return
+	}
+	rec.Headers = append(rec.Headers, kgo.RecordHeader{Key: log.LevelHeader, Value: []byte(value)})
+	if signature != "" {
+		rec.Headers = append(rec.Headers, kgo.RecordHeader{Key: log.LevelSignatureHeader, Value: []byte(signature)})
+	}
+}
+
 func topicAttribute(topic string) attribute.KeyValue {
 	return attribute.String("topic", topic)
 }
@@ -2,10 +2,12 @@ package kafka
 
 import (
 	"context"
+	"log/slog"
 	"testing"
 	"time"
 
 	"github.com/beatlabs/patron/correlation"
+	"github.com/beatlabs/patron/observability/log"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"github.com/twmb/franz-go/pkg/kgo"
@@ -161,6 +163,34 @@ func TestInjectCorrelationHeader(t *testing.T) {
 		assert.Equal(t, correlation.HeaderID, rec.Headers[1].Key)
 		assert.Equal(t, "test-456", string(rec.Headers[1].Value))
 	})
+
+}
+
+func TestProducer_InjectHeaders(t *testing.T) {
+	t.Parallel()
+
+	ctx := log.WithLevel(correlation.ContextWithID(context.Background(), "test-789"), slog.LevelDebug)
+
+	t.Run("log level not propagated by default", func(t *testing.T) {
+		t.Parallel()
+
+		rec := &kgo.Record{Topic: "test"}
+		(&Producer{}).injectHeaders(ctx, rec)
+
+		require.Len(t, rec.Headers, 1)
+		assert.Equal(t, correlation.HeaderID, rec.Headers[0].Key)
+	})
+
+	t.Run("log level propagated", func(t *testing.T) {
+		t.Parallel()
+
+		rec := &kgo.Record{Topic: "test"}
+		(&Producer{propagateLogLevel: true}).injectHeaders(ctx, rec)
+
+		require.Len(t, rec.Headers, 2)
+		assert.Equal(t, log.LevelHeader, rec.Headers[1].Key)
+		assert.Equal(t, "debug", string(rec.Headers[1].Value))
+	})
 }
 
 func TestTopicAttribute(t *testing.T) {
@@ -6,7 +6,8 @@ import "github.com/twmb/franz-go/pkg/kgo"
 type OptionFunc func(*config)
 
 type config struct {
-	kgoOpts []kgo.Opt
+	kgoOpts           []kgo.Opt
+	propagateLogLevel bool
 }
 
 // WithKafkaOptions adds franz-go options to the underlying Kafka client.
@@ -15,3 +16,12 @@ func WithKafkaOptions(opts ...kgo.Opt) OptionFunc {
 		cfg.kgoOpts = append(cfg.kgoOpts, opts...)
 	}
 }
+
+// WithLogLevelPropagation propagates the log level requested in the context of the records sent, see log.WithLevel,
+// in the log.LevelHeader and log.LevelSignatureHeader headers. It should be used only with trusted consumers,
+// as the signature can be replayed by them until it expires.
+func WithLogLevelPropagation() OptionFunc {
+	return func(cfg *config) {
+		cfg.propagateLogLevel = true
+	}
+}
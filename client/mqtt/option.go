@@ -0,0 +1,14 @@
+package mqtt
+
+// OptionFunc definition for configuring the publisher in a functional way.
+type OptionFunc func(*Publisher) error
+
+// WithLogLevelPropagation option for propagating the log level requested in the context of the messages published,
+// see log.WithLevel, in the log.LevelHeader and log.LevelSignatureHeader user properties. It should be used only with
+// trusted consumers, as the signature can be replayed by them until it expires.
+func WithLogLevelPropagation() OptionFunc {
+	return func(p *Publisher) error {
+		p.propagateLogLevel = true
+		return nil
+	}
+}
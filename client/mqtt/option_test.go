@@ -0,0 +1,14 @@
+package mqtt
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithLogLevelPropagation(t *testing.T) {
+	p := Publisher{}
+	require.NoError(t, WithLogLevelPropagation()(&p))
+	assert.True(t, p.propagateLogLevel)
+}
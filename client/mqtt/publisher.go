@@ -57,17 +57,27 @@ func DefaultConfig(brokerURLs []*url.URL, clientID string) (autopaho.ClientConfi
 
 // Publisher definition.
 type Publisher struct {
-	cm *autopaho.ConnectionManager
+	cm                *autopaho.ConnectionManager
+	propagateLogLevel bool
 }
 
 // New creates a publisher.
-func New(ctx context.Context, cfg autopaho.ClientConfig) (*Publisher, error) {
+func New(ctx context.Context, cfg autopaho.ClientConfig, oo ...OptionFunc) (*Publisher, error) {
+	pub := &Publisher{}
+	for _, option := range oo {
+		err := option(pub)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	cm, err := autopaho.NewConnection(ctx, cfg)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create connection manager: %w", err)
 	}
+	pub.cm = cm
 
-	return &Publisher{cm: cm}, nil
+	return pub, nil
 }
 
 // Publish provides a instrumented publishing of a message.
@@ -86,6 +96,9 @@ func (p *Publisher) Publish(ctx context.Context, pub *paho.Publish) (*paho.Publi
 	}
 
 	injectObservabilityHeaders(ctx, pub)
+	if p.propagateLogLevel {
+		injectLogLevelHeaders(ctx, pub)
+	}
 
 	rsp, err := p.cm.Publish(ctx, pub)
 	if err != nil {
@@ -111,6 +124,19 @@ func injectObservabilityHeaders(ctx context.Context, pub *paho.Publish) {
 	pub.Properties.User.Add(correlation.HeaderID, correlation.IDFromContext(ctx))
 }
 
+// injectLogLevelHeaders propagates the log level requested in the context, see log.WithLevel.
+func injectLogLevelHeaders(ctx context.Context, pub *paho.Publish) {
+	value, signature, ok := log.PropagatedLevel(ctx)
+	if !ok {
+		return
+	}
+	ensurePublishingProperties(pub)
+	pub.Properties.User.Add(log.LevelHeader, value)
+	if signature != "" {
+		pub.Properties.User.Add(log.LevelSignatureHeader, signature)
+	}
+}
+
 func ensurePublishingProperties(pub *paho.Publish) {
 	if pub.Properties == nil {
 		pub.Properties = &paho.PublishProperties{
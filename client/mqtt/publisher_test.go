@@ -2,11 +2,13 @@ package mqtt
 
 import (
 	"context"
+	"log/slog"
 	"net/url"
 	"testing"
 	"time"
 
 	"github.com/beatlabs/patron/correlation"
+	"github.com/beatlabs/patron/observability/log"
 	"github.com/eclipse/paho.golang/paho"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -150,6 +152,28 @@ func TestInjectObservabilityHeaders(t *testing.T) {
 		corID := pub.Properties.User.Get(correlation.HeaderID)
 		assert.Equal(t, "test-id", corID)
 	})
+
+	t.Run("does not inject log level", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := log.WithLevel(context.Background(), slog.LevelDebug)
+		pub := &paho.Publish{Topic: "test/topic"}
+
+		injectObservabilityHeaders(ctx, pub)
+
+		assert.Empty(t, pub.Properties.User.Get(log.LevelHeader))
+	})
+
+	t.Run("injects log level", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := log.WithLevel(context.Background(), slog.LevelDebug)
+		pub := &paho.Publish{Topic: "test/topic"}
+
+		injectLogLevelHeaders(ctx, pub)
+
+		assert.Equal(t, "debug", pub.Properties.User.Get(log.LevelHeader))
+	})
 }
 
 func TestProducerMessageCarrier_Get(t *testing.T) {
@@ -6,11 +6,15 @@ import (
 	"database/sql"
 	"database/sql/driver"
 	"fmt"
+	"log/slog"
 	"regexp"
 	"time"
 
+	"github.com/beatlabs/patron/correlation"
 	"github.com/beatlabs/patron/observability"
+	"github.com/beatlabs/patron/observability/log"
 	patronmetric "github.com/beatlabs/patron/observability/metric"
+	"github.com/beatlabs/patron/observability/redact"
 	patrontrace "github.com/beatlabs/patron/observability/trace"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/metric"
@@ -27,6 +31,9 @@ var dsnPattern = regexp.MustCompile(
 
 var durationHistogram metric.Int64Histogram
 
+// logger of the client, whose level can be changed at runtime through the "sql" name.
+var logger = log.Named(packageName)
+
 func init() {
 	durationHistogram = patronmetric.Int64Histogram(packageName, "sql.cmd.duration", "SQL command duration.", "ms")
 }
@@ -39,7 +46,7 @@ type connInfo struct {
 
 func (c *connInfo) startSpan(ctx context.Context, opName, stmt string) (context.Context, trace.Span) {
 	return patrontrace.StartSpan(ctx, opName, trace.WithSpanKind(trace.SpanKindClient),
-		trace.WithAttributes(c.userAttr, c.instanceAttr, c.dbNameAttr, attribute.String("db.statement", stmt)))
+		trace.WithAttributes(c.userAttr, c.instanceAttr, c.dbNameAttr, attribute.String("db.statement", redact.Default().Payload(stmt))))
 }
 
 // Conn represents a single database connection.
@@ -545,10 +552,27 @@ func parseDSN(dsn string) DSNInfo {
 	return res
 }
 
+// observeDuration records the duration of a command and logs it at debug level.
 func observeDuration(ctx context.Context, start time.Time, op string, err error) {
-	durationHistogram.Record(ctx, time.Since(start).Milliseconds(),
+	duration := time.Since(start)
+	durationHistogram.Record(ctx, duration.Milliseconds(),
 		metric.WithAttributes(observability.ClientAttribute("sql"), operationAttr(op),
 			observability.StatusAttribute(err)))
+
+	if !logger.Enabled(ctx, slog.LevelDebug) {
+		return
+	}
+
+	attrs := []slog.Attr{
+		slog.String(correlation.ID, correlation.IDFromContext(ctx)),
+		slog.String("op", op),
+		slog.Duration("duration", duration),
+	}
+	if err != nil {
+		attrs = append(attrs, log.ErrorAttr(err))
+	}
+
+	logger.LogAttrs(ctx, slog.LevelDebug, "sql command", attrs...)
 }
 
 func operationAttr(op string) attribute.KeyValue {
@@ -1,6 +1,7 @@
 package sql
 
 import (
+	"bytes"
 	"context"
 	"database/sql"
 	"database/sql/driver"
@@ -8,6 +9,8 @@ import (
 	"testing"
 	"time"
 
+	"github.com/beatlabs/patron/correlation"
+	"github.com/beatlabs/patron/observability/log"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/otel/attribute"
@@ -428,6 +431,25 @@ func TestObserveDuration(_ *testing.T) {
 	observeDuration(ctx, start, op, errors.New("test error"))
 }
 
+func TestObserveDuration_Log(t *testing.T) {
+	out := &bytes.Buffer{}
+	require.NoError(t, log.Setup(&log.Config{IsJSON: true, Level: "info", Output: out}))
+	t.Cleanup(func() { require.NoError(t, log.Setup(&log.Config{Level: "info"})) })
+
+	ctx := correlation.ContextWithID(context.Background(), "cor-1")
+	observeDuration(ctx, time.Now(), "db.Exec", errors.New("test error"))
+	assert.Empty(t, out.String())
+
+	require.NoError(t, log.SetLoggerLevel("sql", "debug", 0))
+	t.Cleanup(func() { log.ResetLoggerLevel("sql") })
+	observeDuration(ctx, time.Now(), "db.Exec", errors.New("test error"))
+	assert.Contains(t, out.String(), `"msg":"sql command"`)
+	assert.Contains(t, out.String(), `"logger":"sql"`)
+	assert.Contains(t, out.String(), `"correlationID":"cor-1"`)
+	assert.Contains(t, out.String(), `"op":"db.Exec"`)
+	assert.Contains(t, out.String(), `"error":"test error"`)
+}
+
 func TestParseDSN_EdgeCases(t *testing.T) {
 	tests := map[string]struct {
 		dsn  string
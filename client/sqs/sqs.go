@@ -1,13 +1,74 @@
 package sqs
 
 import (
+	"context"
+	"maps"
+	"slices"
+
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/sqs"
+	"github.com/aws/aws-sdk-go-v2/service/sqs/types"
+	"github.com/aws/smithy-go/middleware"
+	"github.com/beatlabs/patron/observability/log"
 	"go.opentelemetry.io/contrib/instrumentation/github.com/aws/aws-sdk-go-v2/otelaws"
 )
 
+const logLevelMiddlewareID = "PatronLogLevel"
+
 // NewFromConfig creates a new SQS client from aws.Config with OpenTelemetry instrumentation enabled.
 func NewFromConfig(cfg aws.Config, optFns ...func(*sqs.Options)) *sqs.Client {
 	otelaws.AppendMiddlewares(&cfg.APIOptions)
 	return sqs.NewFromConfig(cfg, optFns...)
 }
+
+// WithLogLevelPropagation propagates the log level requested in the context of the messages sent, see log.WithLevel,
+// in the log.LevelHeader and log.LevelSignatureHeader message attributes. It should be used only with trusted
+// consumers, as the signature can be replayed by them until it expires.
+func WithLogLevelPropagation() func(*sqs.Options) {
+	return func(o *sqs.Options) {
+		o.APIOptions = append(o.APIOptions, addLogLevelMiddleware)
+	}
+}
+
+func addLogLevelMiddleware(stack *middleware.Stack) error {
+	return stack.Initialize.Add(middleware.InitializeMiddlewareFunc(logLevelMiddlewareID, injectLogLevel), middleware.After)
+}
+
+func injectLogLevel(ctx context.Context, in middleware.InitializeInput, next middleware.InitializeHandler,
+) (middleware.InitializeOutput, middleware.Metadata, error) {
+	value, signature, ok := log.PropagatedLevel(ctx)
+	if !ok {
+		return next.HandleInitialize(ctx, in)
+	}
+
+	// the input of the caller is copied, to be left intact.
+	switch params := in.Parameters.(type) {
+	case *sqs.SendMessageInput:
+		input := *params
+		input.MessageAttributes = logLevelAttributes(input.MessageAttributes, value, signature)
+		in.Parameters = &input
+	case *sqs.SendMessageBatchInput:
+		input := *params
+		input.Entries = slices.Clone(input.Entries)
+		for i := range input.Entries {
+			input.Entries[i].MessageAttributes = logLevelAttributes(input.Entries[i].MessageAttributes, value, signature)
+		}
+		in.Parameters = &input
+	}
+
+	return next.HandleInitialize(ctx, in)
+}
+
+// logLevelAttributes returns a copy of the attributes with the log level ones.
+func logLevelAttributes(ma map[string]types.MessageAttributeValue, value, signature string) map[string]types.MessageAttributeValue {
+	ma = maps.Clone(ma)
+	if ma == nil {
+		ma = make(map[string]types.MessageAttributeValue, 2)
+	}
+
+	ma[log.LevelHeader] = types.MessageAttributeValue{DataType: aws.String("String"), StringValue: aws.String(value)}
+	if signature != "" {
+		ma[log.LevelSignatureHeader] = types.MessageAttributeValue{DataType: aws.String("String"), StringValue: aws.String(signature)}
+	}
+	return ma
+}
@@ -2,10 +2,14 @@ package sqs
 
 import (
 	"context"
+	"log/slog"
 	"testing"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/sqs"
+	"github.com/aws/aws-sdk-go-v2/service/sqs/types"
+	"github.com/aws/smithy-go/middleware"
+	"github.com/beatlabs/patron/observability/log"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -183,3 +187,59 @@ func TestNewFromConfig_LocalEmulator(t *testing.T) {
 		assert.Equal(t, localEndpoint, *client.Options().BaseEndpoint)
 	})
 }
+
+func TestWithLogLevelPropagation(t *testing.T) {
+	t.Parallel()
+
+	client := NewFromConfig(aws.Config{Region: "eu-west-1"})
+	apiOptions := len(client.Options().APIOptions)
+
+	client = NewFromConfig(aws.Config{Region: "eu-west-1"}, WithLogLevelPropagation())
+	assert.Len(t, client.Options().APIOptions, apiOptions+1)
+}
+
+func TestInjectLogLevel(t *testing.T) {
+	t.Parallel()
+
+	var got any
+	next := middleware.InitializeHandlerFunc(func(_ context.Context, in middleware.InitializeInput) (middleware.InitializeOutput, middleware.Metadata, error) {
+		got = in.Parameters
+		return middleware.InitializeOutput{}, middleware.Metadata{}, nil
+	})
+	ctx := log.WithLevel(context.Background(), slog.LevelDebug)
+
+	t.Run("message", func(t *testing.T) {
+		input := &sqs.SendMessageInput{MessageAttributes: map[string]types.MessageAttributeValue{
+			"key": {DataType: aws.String("String"), StringValue: aws.String("value")},
+		}}
+		_, _, err := injectLogLevel(ctx, middleware.InitializeInput{Parameters: input}, next)
+		require.NoError(t, err)
+
+		sent, ok := got.(*sqs.SendMessageInput)
+		require.True(t, ok)
+		assert.Len(t, sent.MessageAttributes, 2)
+		assert.Equal(t, "debug", *sent.MessageAttributes[log.LevelHeader].StringValue)
+		// the input of the caller is intact.
+		assert.Len(t, input.MessageAttributes, 1)
+	})
+
+	t.Run("batch", func(t *testing.T) {
+		input := &sqs.SendMessageBatchInput{Entries: []types.SendMessageBatchRequestEntry{{Id: aws.String("1")}, {Id: aws.String("2")}}}
+		_, _, err := injectLogLevel(ctx, middleware.InitializeInput{Parameters: input}, next)
+		require.NoError(t, err)
+
+		sent, ok := got.(*sqs.SendMessageBatchInput)
+		require.True(t, ok)
+		for _, entry := range sent.Entries {
+			assert.Equal(t, "debug", *entry.MessageAttributes[log.LevelHeader].StringValue)
+		}
+		assert.Nil(t, input.Entries[0].MessageAttributes)
+	})
+
+	t.Run("without level", func(t *testing.T) {
+		input := &sqs.SendMessageInput{}
+		_, _, err := injectLogLevel(context.Background(), middleware.InitializeInput{Parameters: input}, next)
+		require.NoError(t, err)
+		assert.Same(t, input, got)
+	})
+}
@@ -0,0 +1,166 @@
+package patron
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+)
+
+// Initializer is an optional interface for components that need to prepare resources before they run.
+// Init is called once, after all the dependencies of the component are ready and before Run.
+type Initializer interface {
+	Init(ctx context.Context) error
+}
+
+// Readier is an optional interface for components that can report when they are able to accept work.
+// Ready should block until the component is ready or the context is done.
+// Components depending on a Readier are started only after Ready returns without an error.
+type Readier interface {
+	Ready(ctx context.Context) error
+}
+
+// Shutdowner is an optional interface for components that need to stop accepting work
+// before their run context is canceled.
+type Shutdowner interface {
+	Shutdown(ctx context.Context) error
+}
+
+// Reloader is an optional interface for components that can apply configuration changes while running.
+// Reload is called when the service receives SIGHUP, after the configuration files are read again.
+type Reloader interface {
+	Reload(ctx context.Context) error
+}
+
+// ComponentOptionFunc configures a ManagedComponent.
+type ComponentOptionFunc func(*ManagedComponent) error
+
+// ManagedComponent wraps a Component with a name and lifecycle configuration used by the Service.
+// Components passed to the Service without a wrapper run with default settings.
+type ManagedComponent struct {
+	name          string
+	component     Component
+	deps          []string
+	restartPolicy *RestartPolicy
+}
+
+// NewManagedComponent creates a named component configurable by functional options.
+func NewManagedComponent(name string, cmp Component, oo ...ComponentOptionFunc) (*ManagedComponent, error) {
+	if name == "" {
+		return nil, errors.New("component name is required")
+	}
+	if cmp == nil {
+		return nil, errors.New("component is nil")
+	}
+
+	mc := &ManagedComponent{
+		name:      name,
+		component: cmp,
+	}
+
+	for _, option := range oo {
+		err := option(mc)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return mc, nil
+}
+
+// DependsOn declares the names of the components that have to be ready before this one is started.
+// The component is shut down before any of its dependencies.
+func DependsOn(names ...string) ComponentOptionFunc {
+	return func(mc *ManagedComponent) error {
+		if len(names) == 0 {
+			return errors.New("dependencies are empty")
+		}
+		for _, name := range names {
+			if name == "" {
+				return errors.New("dependency name is empty")
+			}
+			if name == mc.name {
+				return fmt.Errorf("component %s cannot depend on itself", mc.name)
+			}
+		}
+		mc.deps = append(mc.deps, names...)
+		return nil
+	}
+}
+
+// Name returns the name of the component.
+func (mc *ManagedComponent) Name() string {
+	return mc.name
+}
+
+// Run delegates to the wrapped component.
+func (mc *ManagedComponent) Run(ctx context.Context) error {
+	return mc.component.Run(ctx)
+}
+
+// managedComponents wraps all components and validates names and dependencies.
+// Components without a wrapper get a name derived from their position.
+func managedComponents(components []Component) ([]*ManagedComponent, error) {
+	mcs := make([]*ManagedComponent, 0, len(components))
+	names := make(map[string]struct{}, len(components))
+
+	for i, cmp := range components {
+		mc, ok := cmp.(*ManagedComponent)
+		if !ok {
+			mc = &ManagedComponent{name: fmt.Sprintf("component-%d", i), component: cmp}
+		}
+		if _, ok := names[mc.name]; ok {
+			return nil, fmt.Errorf("component name %s is not unique", mc.name)
+		}
+		names[mc.name] = struct{}{}
+		mcs = append(mcs, mc)
+	}
+
+	for _, mc := range mcs {
+		for _, dep := range mc.deps {
+			if _, ok := names[dep]; !ok {
+				return nil, fmt.Errorf("component %s depends on unknown component %s", mc.name, dep)
+			}
+		}
+	}
+
+	return mcs, nil
+}
+
+// startupOrder sorts the components so that each one comes after its dependencies.
+// The order of independent components is preserved.
+func startupOrder(mcs []*ManagedComponent) ([]*ManagedComponent, error) {
+	ordered := make([]*ManagedComponent, 0, len(mcs))
+	visited := make(map[string]bool, len(mcs))
+	byName := make(map[string]*ManagedComponent, len(mcs))
+	for _, mc := range mcs {
+		byName[mc.name] = mc
+	}
+
+	var visit func(mc *ManagedComponent, path []string) error
+	visit = func(mc *ManagedComponent, path []string) error {
+		if done, ok := visited[mc.name]; ok {
+			if !done {
+				return fmt.Errorf("component dependency cycle detected: %v", append(path, mc.name))
+			}
+			return nil
+		}
+		visited[mc.name] = false
+		for _, dep := range mc.deps {
+			if err := visit(byName[dep], append(slices.Clone(path), mc.name)); err != nil {
+				return err
+			}
+		}
+		visited[mc.name] = true
+		ordered = append(ordered, mc)
+		return nil
+	}
+
+	for _, mc := range mcs {
+		if err := visit(mc, nil); err != nil {
+			return nil, err
+		}
+	}
+
+	return ordered, nil
+}
@@ -8,6 +8,7 @@ import (
 	"log/slog"
 	"net"
 	"net/url"
+	"sync/atomic"
 	"time"
 
 	"github.com/beatlabs/patron/correlation"
@@ -38,6 +39,9 @@ const (
 	fetchedMessageState messageState = "FETCHED"
 )
 
+// logger of the component, whose level can be changed at runtime through the "amqp" name.
+var logger = log.Named("amqp")
+
 // ProcessorFunc definition of an async processor.
 type ProcessorFunc func(context.Context, Batch)
 
@@ -63,12 +67,19 @@ type statsConfig struct {
 
 // Component implementation of an async component.
 type Component struct {
-	queueCfg queueConfig
-	proc     ProcessorFunc
-	batchCfg batchConfig
-	statsCfg statsConfig
-	retryCfg retryConfig
-	cfg      amqp.Config
+	queueCfg   queueConfig
+	proc       ProcessorFunc
+	batchCfg   batchConfig
+	statsCfg   statsConfig
+	retryCfg   retryConfig
+	cfg        amqp.Config
+	logLevel   *log.LevelOverride
+	subscribed atomic.Bool
+}
+
+// IsReady returns true while the component is subscribed to the queue.
+func (c *Component) IsReady() bool {
+	return c.subscribed.Load()
 }
 
 // New creates a new component with support for functional configuration.
@@ -134,7 +145,7 @@ func (c *Component) Run(ctx context.Context) error {
 	for count > 0 {
 		sub, err := c.subscribe()
 		if err != nil {
-			slog.Warn("failed to subscribe to queue, reconnecting", log.ErrorAttr(err),
+			logger.Warn("failed to subscribe to queue, reconnecting", log.ErrorAttr(err),
 				slog.Duration("retry", c.retryCfg.delay))
 			time.Sleep(c.retryCfg.delay)
 			count--
@@ -142,12 +153,14 @@ func (c *Component) Run(ctx context.Context) error {
 		}
 		count = c.retryCfg.count
 
+		c.subscribed.Store(true)
 		err = c.processLoop(ctx, sub)
+		c.subscribed.Store(false)
 		if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
 			closeSubscription(sub)
 			return nil
 		}
-		slog.Warn("process loop failure, reconnecting", log.ErrorAttr(err), slog.Duration("retry", c.retryCfg.delay))
+		logger.Warn("process loop failure, reconnecting", log.ErrorAttr(err), slog.Duration("retry", c.retryCfg.delay))
 		time.Sleep(c.retryCfg.delay)
 		count--
 		closeSubscription(sub)
@@ -158,9 +171,9 @@ func (c *Component) Run(ctx context.Context) error {
 func closeSubscription(sub subscription) {
 	err := sub.close()
 	if err != nil {
-		slog.Error("failed to close amqp channel/connection", log.ErrorAttr(err))
+		logger.Error("failed to close amqp channel/connection", log.ErrorAttr(err))
 	}
-	slog.Debug("amqp subscription closed")
+	logger.Debug("amqp subscription closed")
 }
 
 func (c *Component) processLoop(ctx context.Context, sub subscription) error {
@@ -174,17 +187,17 @@ func (c *Component) processLoop(ctx context.Context, sub subscription) error {
 	for {
 		select {
 		case <-ctx.Done():
-			slog.Info("context cancellation received. exiting...")
+			logger.Info("context cancellation received. exiting...")
 			return ctx.Err()
 		case delivery, ok := <-sub.deliveries:
 			if !ok {
 				return errors.New("subscription channel closed")
 			}
-			slog.Debug("processing message", slog.Uint64("tag", delivery.DeliveryTag))
+			logger.Debug("processing message", slog.Uint64("tag", delivery.DeliveryTag))
 			observeReceivedMessageStats(ctx, c.queueCfg.queue, delivery.Timestamp)
 			c.processBatch(ctx, c.createMessage(ctx, delivery), btc)
 		case <-batchTimeout.C:
-			slog.Debug("batch timeout expired, sending batch")
+			logger.Debug("batch timeout expired, sending batch")
 			c.sendBatch(ctx, btc)
 		case <-tickerStats.C:
 			err := c.stats(ctx, sub)
@@ -196,7 +209,7 @@ func (c *Component) processLoop(ctx context.Context, sub subscription) error {
 }
 
 func logStatsError(err error) {
-	slog.Error("failed to report amqp stats", log.ErrorAttr(err))
+	logger.Error("failed to report amqp stats", log.ErrorAttr(err))
 }
 
 type subscription struct {
@@ -235,7 +248,7 @@ func (c *Component) subscribe() (subscription, error) {
 	sub.channel = ch
 
 	tag := uuid.New().String()
-	slog.Debug("consuming messages", slog.String("tag", tag))
+	logger.Debug("consuming messages", slog.String("tag", tag))
 
 	deliveries, err := ch.Consume(c.queueCfg.queue, tag, false, false, false, false, nil)
 	if err != nil {
@@ -257,7 +270,11 @@ func (c *Component) createMessage(ctx context.Context, delivery amqp.Delivery) *
 		trace.WithSpanKind(trace.SpanKindConsumer))
 
 	ctx = correlation.ContextWithID(ctx, corID)
-	ctx = log.WithContext(ctx, slog.With(slog.String(correlation.ID, corID)))
+	ctx = log.WithContext(ctx, logger.With(slog.String(correlation.ID, corID)))
+	if c.logLevel != nil {
+		carrier := consumerMessageCarrier{msg: &delivery}
+		ctx = c.logLevel.Context(ctx, carrier.Get(log.LevelHeader), carrier.Get(log.LevelSignatureHeader))
+	}
 
 	return &message{
 		ctx:     ctx,
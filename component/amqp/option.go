@@ -4,6 +4,7 @@ import (
 	"errors"
 	"time"
 
+	"github.com/beatlabs/patron/observability/log"
 	amqp "github.com/rabbitmq/amqp091-go"
 )
 
@@ -62,3 +63,16 @@ func WithRequeue(requeue bool) OptionFunc {
 		return nil
 	}
 }
+
+// WithLogLevelOverride processes a message at the log level it requests with the log.LevelHeader header, e.g. debug,
+// if allowed by the configuration.
+func WithLogLevelOverride(cfg log.LevelOverrideConfig) OptionFunc {
+	return func(c *Component) error {
+		override, err := log.NewLevelOverride(cfg)
+		if err != nil {
+			return err
+		}
+		c.logLevel = override
+		return nil
+	}
+}
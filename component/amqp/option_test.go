@@ -4,6 +4,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/beatlabs/patron/observability/log"
 	amqp "github.com/rabbitmq/amqp091-go"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -89,3 +90,10 @@ func TestStatsInterval(t *testing.T) {
 		})
 	}
 }
+
+func TestLogLevelOverride(t *testing.T) {
+	c := &Component{}
+	require.EqualError(t, WithLogLevelOverride(log.LevelOverrideConfig{})(c), "no log levels allowed")
+	require.NoError(t, WithLogLevelOverride(log.LevelOverrideConfig{Levels: []string{"debug"}})(c))
+	assert.NotNil(t, c.logLevel)
+}
@@ -0,0 +1,285 @@
+// Package cron provides a component running jobs on cron expressions or fixed intervals.
+package cron
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"math/rand/v2"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/beatlabs/patron/correlation"
+	"github.com/beatlabs/patron/observability"
+	"github.com/beatlabs/patron/observability/log"
+	patrontrace "github.com/beatlabs/patron/observability/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	defaultShutdownGracePeriod = 5 * time.Second
+
+	cronComponent = "cron"
+)
+
+// JobFunc is the function run on every activation of a job.
+// The context carries a span, a correlation ID and a logger, and is canceled when the run times out
+// or when the shutdown grace period of the component elapses.
+type JobFunc func(ctx context.Context) error
+
+// OverlapPolicy defines what happens when a job is activated while a previous run is still in progress.
+type OverlapPolicy int
+
+const (
+	// SkipOverlap skips the activation. It is the default policy.
+	SkipOverlap OverlapPolicy = iota
+	// DelayOverlap waits for the previous run to finish and then runs once, coalescing the activations missed meanwhile.
+	DelayOverlap
+	// AllowOverlap runs concurrently with the previous runs.
+	AllowOverlap
+)
+
+// Job is a function run on a schedule.
+type Job struct {
+	name     string
+	schedule Schedule
+	fn       JobFunc
+	overlap  OverlapPolicy
+	jitter   time.Duration
+	timeout  time.Duration
+
+	running atomic.Bool
+	delay   chan struct{}
+}
+
+// NewJob creates a job running on a cron expression, see ParseCron for the supported syntax.
+func NewJob(name, expr string, fn JobFunc, oo ...JobOptionFunc) (*Job, error) {
+	schedule, err := ParseCron(expr)
+	if err != nil {
+		return nil, err
+	}
+	return NewScheduledJob(name, schedule, fn, oo...)
+}
+
+// NewIntervalJob creates a job running at a fixed interval.
+func NewIntervalJob(name string, interval time.Duration, fn JobFunc, oo ...JobOptionFunc) (*Job, error) {
+	schedule, err := Every(interval)
+	if err != nil {
+		return nil, err
+	}
+	return NewScheduledJob(name, schedule, fn, oo...)
+}
+
+// NewScheduledJob creates a job running on a custom schedule.
+func NewScheduledJob(name string, schedule Schedule, fn JobFunc, oo ...JobOptionFunc) (*Job, error) {
+	if name == "" {
+		return nil, errors.New("job name is required")
+	}
+	if schedule == nil {
+		return nil, errors.New("schedule is nil")
+	}
+	if fn == nil {
+		return nil, errors.New("job function is nil")
+	}
+
+	job := &Job{
+		name:     name,
+		schedule: schedule,
+		fn:       fn,
+		overlap:  SkipOverlap,
+		delay:    make(chan struct{}, 1),
+	}
+
+	for _, optionFunc := range oo {
+		err := optionFunc(job)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return job, nil
+}
+
+// Component runs jobs on their schedules.
+type Component struct {
+	name                string
+	jobs                []*Job
+	location            *time.Location
+	shutdownGracePeriod time.Duration
+}
+
+// New creates a new cron component with support for functional configuration.
+// Schedules are evaluated in UTC and running jobs get a 5s grace period when the component stops.
+func New(name string, jobs []*Job, oo ...OptionFunc) (*Component, error) {
+	if name == "" {
+		return nil, errors.New("name is required")
+	}
+
+	if len(jobs) == 0 {
+		return nil, errors.New("jobs are empty")
+	}
+
+	names := make(map[string]struct{}, len(jobs))
+	for _, job := range jobs {
+		if job == nil {
+			return nil, errors.New("job is nil")
+		}
+		if _, ok := names[job.name]; ok {
+			return nil, fmt.Errorf("job name %s is not unique", job.name)
+		}
+		names[job.name] = struct{}{}
+	}
+
+	cmp := &Component{
+		name:                name,
+		jobs:                jobs,
+		location:            time.UTC,
+		shutdownGracePeriod: defaultShutdownGracePeriod,
+	}
+
+	for _, optionFunc := range oo {
+		err := optionFunc(cmp)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return cmp, nil
+}
+
+// Run schedules the jobs until the context is canceled, then waits for the running jobs to finish.
+// Jobs still running after the shutdown grace period have their context canceled.
+func (c *Component) Run(ctx context.Context) error {
+	// job runs are detached from the run context, so that they can finish gracefully.
+	jobCtx, cancel := context.WithCancel(context.WithoutCancel(ctx))
+	defer cancel()
+
+	runs := &sync.WaitGroup{}
+	schedulers := &sync.WaitGroup{}
+	for _, job := range c.jobs {
+		schedulers.Add(1)
+		go func() {
+			defer schedulers.Done()
+			c.schedule(ctx, jobCtx, job, runs)
+		}()
+	}
+
+	<-ctx.Done()
+	schedulers.Wait()
+	slog.Info("stopping cron component, waiting for running jobs", slog.String("component", c.name))
+
+	done := make(chan struct{})
+	go func() {
+		runs.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(c.shutdownGracePeriod):
+		slog.Warn("shutdown grace period elapsed, canceling running jobs", slog.String("component", c.name))
+		cancel()
+		<-done
+	}
+	return nil
+}
+
+func (c *Component) schedule(ctx, jobCtx context.Context, job *Job, runs *sync.WaitGroup) {
+	now := time.Now().In(c.location)
+	for {
+		next := job.schedule.Next(now)
+		if next.IsZero() {
+			slog.Warn("job has no more activations", slog.String("component", c.name), slog.String("job", job.name))
+			return
+		}
+
+		wait := time.Until(next)
+		if job.jitter > 0 {
+			wait += rand.N(job.jitter) //nolint:gosec
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+		now = next
+
+		switch job.overlap {
+		case AllowOverlap:
+			c.start(jobCtx, job, runs, nil)
+		case DelayOverlap:
+			select {
+			case job.delay <- struct{}{}:
+			case <-ctx.Done():
+				return
+			}
+			c.start(jobCtx, job, runs, func() { <-job.delay })
+		default:
+			if !job.running.CompareAndSwap(false, true) {
+				jobSkippedInc(ctx, c.name, job.name)
+				slog.Warn("job still running, skipping activation", slog.String("component", c.name), slog.String("job", job.name))
+				continue
+			}
+			c.start(jobCtx, job, runs, func() { job.running.Store(false) })
+		}
+
+		// activations missed while waiting are not run.
+		if current := time.Now().In(c.location); current.After(now) {
+			now = current
+		}
+	}
+}
+
+func (c *Component) start(ctx context.Context, job *Job, runs *sync.WaitGroup, done func()) {
+	runs.Add(1)
+	go func() {
+		defer runs.Done()
+		if done != nil {
+			defer done()
+		}
+		c.run(ctx, job)
+	}()
+}
+
+func (c *Component) run(ctx context.Context, job *Job) {
+	corID := correlation.New()
+	ctx, sp := patrontrace.StartSpan(ctx, patrontrace.ComponentOpName(cronComponent, job.name),
+		trace.WithSpanKind(trace.SpanKindInternal), trace.WithAttributes(observability.ComponentAttribute(c.name)))
+	defer sp.End()
+
+	logger := slog.With(slog.String(correlation.ID, corID), slog.String("job", job.name))
+	ctx = correlation.ContextWithID(ctx, corID)
+	ctx = log.WithContext(ctx, logger)
+
+	if job.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, job.timeout)
+		defer cancel()
+	}
+
+	start := time.Now()
+	err := execute(ctx, job.fn)
+	observeJobRun(ctx, c.name, job.name, time.Since(start), err)
+
+	if err != nil {
+		patrontrace.SetSpanError(sp, "job failed", err)
+		logger.Error("job failed", slog.Duration("duration", time.Since(start)), log.ErrorAttr(err))
+		return
+	}
+	patrontrace.SetSpanSuccess(sp)
+	logger.Debug("job succeeded", slog.Duration("duration", time.Since(start)))
+}
+
+func execute(ctx context.Context, fn JobFunc) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("job panicked: %v", r)
+		}
+	}()
+	return fn(ctx)
+}
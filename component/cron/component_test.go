@@ -0,0 +1,266 @@
+package cron
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/beatlabs/patron/correlation"
+	"github.com/beatlabs/patron/observability/log"
+	patrontrace "github.com/beatlabs/patron/observability/trace"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/goleak"
+)
+
+func TestMain(m *testing.M) {
+	goleak.VerifyTestMain(m,
+		goleak.IgnoreTopFunction("go.opencensus.io/stats/view.(*worker).start"),
+		goleak.IgnoreTopFunction("google.golang.org/grpc/internal/grpcsync.(*CallbackSerializer).run"),
+		goleak.IgnoreTopFunction("go.opentelemetry.io/otel/sdk/metric.(*PeriodicReader).run"),
+		goleak.IgnoreTopFunction("go.opentelemetry.io/otel/sdk/trace.(*batchSpanProcessor).processQueue"),
+	)
+}
+
+func noopJob(context.Context) error { return nil }
+
+func TestNew(t *testing.T) {
+	t.Parallel()
+
+	job := func(name string) *Job {
+		j, err := NewIntervalJob(name, time.Second, noopJob)
+		require.NoError(t, err)
+		return j
+	}
+
+	tests := map[string]struct {
+		name        string
+		jobs        []*Job
+		oo          []OptionFunc
+		expectedErr string
+	}{
+		"success":            {name: "cron", jobs: []*Job{job("a"), job("b")}},
+		"missing name":       {jobs: []*Job{job("a")}, expectedErr: "name is required"},
+		"missing jobs":       {name: "cron", expectedErr: "jobs are empty"},
+		"nil job":            {name: "cron", jobs: []*Job{nil}, expectedErr: "job is nil"},
+		"duplicate job name": {name: "cron", jobs: []*Job{job("a"), job("a")}, expectedErr: "job name a is not unique"},
+		"option failure":     {name: "cron", jobs: []*Job{job("a")}, oo: []OptionFunc{WithLocation(nil)}, expectedErr: "location is nil"},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := New(tt.name, tt.jobs, tt.oo...)
+			if tt.expectedErr != "" {
+				require.EqualError(t, err, tt.expectedErr)
+				assert.Nil(t, got)
+			} else {
+				require.NoError(t, err)
+				assert.NotNil(t, got)
+			}
+		})
+	}
+}
+
+func TestNewJob(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		name        string
+		expr        string
+		fn          JobFunc
+		oo          []JobOptionFunc
+		expectedErr string
+	}{
+		"success":         {name: "job", expr: "*/5 * * * *", fn: noopJob},
+		"missing name":    {expr: "* * * * *", fn: noopJob, expectedErr: "job name is required"},
+		"missing func":    {name: "job", expr: "* * * * *", expectedErr: "job function is nil"},
+		"invalid expr":    {name: "job", expr: "* *", fn: noopJob, expectedErr: `invalid cron expression "* *": expected 5 fields, got 2`},
+		"option failure":  {name: "job", expr: "* * * * *", fn: noopJob, oo: []JobOptionFunc{WithJitter(0)}, expectedErr: "negative or zero jitter provided"},
+		"success options": {name: "job", expr: "@daily", fn: noopJob, oo: []JobOptionFunc{WithJitter(time.Minute), WithTimeout(time.Hour)}},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := NewJob(tt.name, tt.expr, tt.fn, tt.oo...)
+			if tt.expectedErr != "" {
+				require.EqualError(t, err, tt.expectedErr)
+				assert.Nil(t, got)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, SkipOverlap, got.overlap)
+			}
+		})
+	}
+}
+
+func runComponent(t *testing.T, cmp *Component) (context.CancelFunc, <-chan error) {
+	t.Helper()
+	ctx, cancel := context.WithCancel(context.Background())
+	chErr := make(chan error, 1)
+	go func() {
+		chErr <- cmp.Run(ctx)
+	}()
+	return cancel, chErr
+}
+
+func TestComponent_RunContext(t *testing.T) {
+	exp := tracetest.NewInMemoryExporter()
+	tp := patrontrace.Setup("test", nil, exp)
+	t.Cleanup(func() { require.NoError(t, tp.Shutdown(context.Background())) })
+
+	type runInfo struct {
+		corID  string
+		span   trace.SpanContext
+		logger bool
+	}
+	runs := make(chan runInfo, 10)
+
+	job, err := NewIntervalJob("job", 10*time.Millisecond, func(ctx context.Context) error {
+		runs <- runInfo{
+			corID:  correlation.IDFromContext(ctx),
+			span:   trace.SpanContextFromContext(ctx),
+			logger: log.FromContext(ctx) != nil,
+		}
+		return errors.New("job error")
+	})
+	require.NoError(t, err)
+	cmp, err := New("cron", []*Job{job})
+	require.NoError(t, err)
+
+	cancel, chErr := runComponent(t, cmp)
+	first, second := <-runs, <-runs
+	cancel()
+	require.NoError(t, <-chErr)
+
+	assert.True(t, first.span.IsValid())
+	assert.True(t, first.logger)
+	assert.NotEmpty(t, first.corID)
+	assert.NotEqual(t, first.corID, second.corID)
+	assert.NotEqual(t, first.span.TraceID(), second.span.TraceID())
+
+	require.NoError(t, tp.ForceFlush(context.Background()))
+	spans := exp.GetSpans()
+	require.GreaterOrEqual(t, len(spans), 2)
+	assert.Equal(t, "cron job", spans[0].Name)
+	assert.Equal(t, "job failed", spans[0].Status.Description)
+}
+
+func TestComponent_OverlapPolicy(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		policy     OverlapPolicy
+		concurrent bool
+	}{
+		"skip":  {policy: SkipOverlap},
+		"delay": {policy: DelayOverlap},
+		"allow": {policy: AllowOverlap, concurrent: true},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			var running, maxRunning, runs atomic.Int32
+			job, err := NewIntervalJob("job", 5*time.Millisecond, func(context.Context) error {
+				current := running.Add(1)
+				defer running.Add(-1)
+				for {
+					prev := maxRunning.Load()
+					if current <= prev || maxRunning.CompareAndSwap(prev, current) {
+						break
+					}
+				}
+				runs.Add(1)
+				time.Sleep(30 * time.Millisecond)
+				return nil
+			}, WithOverlapPolicy(tt.policy))
+			require.NoError(t, err)
+			cmp, err := New("cron", []*Job{job})
+			require.NoError(t, err)
+
+			cancel, chErr := runComponent(t, cmp)
+			assert.Eventually(t, func() bool { return runs.Load() >= 3 }, time.Second, time.Millisecond)
+			cancel()
+			require.NoError(t, <-chErr)
+
+			assert.Equal(t, int32(0), running.Load())
+			assert.Equal(t, tt.concurrent, maxRunning.Load() > 1)
+		})
+	}
+}
+
+func TestComponent_GracefulStop(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		duration    time.Duration
+		expCanceled bool
+	}{
+		"running job finishes":         {duration: 50 * time.Millisecond},
+		"grace period cancels the job": {duration: time.Hour, expCanceled: true},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			started := make(chan struct{}, 1)
+			var finished, canceled atomic.Bool
+			job, err := NewIntervalJob("job", 5*time.Millisecond, func(ctx context.Context) error {
+				select {
+				case started <- struct{}{}:
+				default:
+				}
+				select {
+				case <-time.After(tt.duration):
+					finished.Store(true)
+				case <-ctx.Done():
+					canceled.Store(true)
+				}
+				return nil
+			})
+			require.NoError(t, err)
+			cmp, err := New("cron", []*Job{job}, WithShutdownGracePeriod(200*time.Millisecond))
+			require.NoError(t, err)
+
+			cancel, chErr := runComponent(t, cmp)
+			<-started
+			cancel()
+			require.NoError(t, <-chErr)
+
+			assert.Equal(t, !tt.expCanceled, finished.Load())
+			assert.Equal(t, tt.expCanceled, canceled.Load())
+		})
+	}
+}
+
+func TestComponent_JobPanicAndTimeout(t *testing.T) {
+	t.Parallel()
+
+	var timedOut atomic.Bool
+	panicking, err := NewIntervalJob("panicking", 5*time.Millisecond, func(context.Context) error {
+		panic("boom")
+	})
+	require.NoError(t, err)
+	slow, err := NewIntervalJob("slow", 5*time.Millisecond, func(ctx context.Context) error {
+		<-ctx.Done()
+		timedOut.Store(errors.Is(ctx.Err(), context.DeadlineExceeded))
+		return ctx.Err()
+	}, WithTimeout(10*time.Millisecond))
+	require.NoError(t, err)
+	cmp, err := New("cron", []*Job{panicking, slow})
+	require.NoError(t, err)
+
+	cancel, chErr := runComponent(t, cmp)
+	assert.Eventually(t, timedOut.Load, time.Second, time.Millisecond)
+	cancel()
+	require.NoError(t, <-chErr)
+
+	err = execute(context.Background(), panicking.fn)
+	require.EqualError(t, err, "job panicked: boom")
+}
@@ -0,0 +1,36 @@
+package cron
+
+import (
+	"context"
+	"time"
+
+	"github.com/beatlabs/patron/observability"
+	patronmetric "github.com/beatlabs/patron/observability/metric"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+const packageName = "cron"
+
+var (
+	jobDurationHistogram metric.Int64Histogram
+	jobSkippedCounter    metric.Int64Counter
+)
+
+func init() {
+	jobDurationHistogram = patronmetric.Int64Histogram(packageName, "cron.job.duration", "Cron job run duration.", "ms")
+	jobSkippedCounter = patronmetric.Int64Counter(packageName, "cron.job.skipped", "Cron job activations skipped due to overlap.", "1")
+}
+
+func observeJobRun(ctx context.Context, component, job string, duration time.Duration, err error) {
+	jobDurationHistogram.Record(ctx, duration.Milliseconds(), metric.WithAttributes(jobAttributes(component, job)...),
+		metric.WithAttributes(observability.StatusAttribute(err)))
+}
+
+func jobSkippedInc(ctx context.Context, component, job string) {
+	jobSkippedCounter.Add(ctx, 1, metric.WithAttributes(jobAttributes(component, job)...))
+}
+
+func jobAttributes(component, job string) []attribute.KeyValue {
+	return []attribute.KeyValue{observability.ComponentAttribute(component), attribute.String("job", job)}
+}
@@ -0,0 +1,69 @@
+package cron
+
+import (
+	"errors"
+	"time"
+)
+
+// OptionFunc definition for configuring the component in a functional way.
+type OptionFunc func(*Component) error
+
+// WithLocation sets the location cron expressions are evaluated in.
+func WithLocation(location *time.Location) OptionFunc {
+	return func(c *Component) error {
+		if location == nil {
+			return errors.New("location is nil")
+		}
+		c.location = location
+		return nil
+	}
+}
+
+// WithShutdownGracePeriod sets how long running jobs are waited for when the component stops,
+// before their context is canceled.
+func WithShutdownGracePeriod(gp time.Duration) OptionFunc {
+	return func(c *Component) error {
+		if gp <= 0 {
+			return errors.New("negative or zero shutdown grace period provided")
+		}
+		c.shutdownGracePeriod = gp
+		return nil
+	}
+}
+
+// JobOptionFunc definition for configuring a job in a functional way.
+type JobOptionFunc func(*Job) error
+
+// WithOverlapPolicy sets what happens when the job is activated while a previous run is still in progress.
+func WithOverlapPolicy(policy OverlapPolicy) JobOptionFunc {
+	return func(j *Job) error {
+		if policy < SkipOverlap || policy > AllowOverlap {
+			return errors.New("invalid overlap policy provided")
+		}
+		j.overlap = policy
+		return nil
+	}
+}
+
+// WithJitter delays each activation by a random duration up to the given maximum,
+// spreading the load of replicas running the same schedule.
+func WithJitter(maxJitter time.Duration) JobOptionFunc {
+	return func(j *Job) error {
+		if maxJitter <= 0 {
+			return errors.New("negative or zero jitter provided")
+		}
+		j.jitter = maxJitter
+		return nil
+	}
+}
+
+// WithTimeout sets the maximum duration of a run, after which its context is canceled.
+func WithTimeout(timeout time.Duration) JobOptionFunc {
+	return func(j *Job) error {
+		if timeout <= 0 {
+			return errors.New("negative or zero timeout provided")
+		}
+		j.timeout = timeout
+		return nil
+	}
+}
@@ -0,0 +1,63 @@
+package cron
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOptions(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		option      OptionFunc
+		expectedErr string
+	}{
+		"location":                   {option: WithLocation(time.Local)},
+		"nil location":               {option: WithLocation(nil), expectedErr: "location is nil"},
+		"shutdown grace period":      {option: WithShutdownGracePeriod(time.Second)},
+		"zero shutdown grace period": {option: WithShutdownGracePeriod(0), expectedErr: "negative or zero shutdown grace period provided"},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			err := tt.option(&Component{})
+			if tt.expectedErr != "" {
+				assert.EqualError(t, err, tt.expectedErr)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestJobOptions(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		option      JobOptionFunc
+		expectedErr string
+	}{
+		"overlap policy":         {option: WithOverlapPolicy(DelayOverlap)},
+		"invalid overlap policy": {option: WithOverlapPolicy(OverlapPolicy(10)), expectedErr: "invalid overlap policy provided"},
+		"jitter":                 {option: WithJitter(time.Second)},
+		"negative jitter":        {option: WithJitter(-time.Second), expectedErr: "negative or zero jitter provided"},
+		"timeout":                {option: WithTimeout(time.Second)},
+		"zero timeout":           {option: WithTimeout(0), expectedErr: "negative or zero timeout provided"},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			err := tt.option(&Job{})
+			if tt.expectedErr != "" {
+				assert.EqualError(t, err, tt.expectedErr)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,232 @@
+package cron
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Schedule returns the activation times of a job.
+type Schedule interface {
+	// Next returns the first activation after the given time, or the zero time if there is none.
+	Next(t time.Time) time.Time
+}
+
+type intervalSchedule struct {
+	interval time.Duration
+}
+
+// Every returns a schedule activating at a fixed interval, starting one interval after the component runs.
+func Every(interval time.Duration) (Schedule, error) {
+	if interval <= 0 {
+		return nil, errors.New("negative or zero interval provided")
+	}
+	return intervalSchedule{interval: interval}, nil
+}
+
+func (s intervalSchedule) Next(t time.Time) time.Time {
+	return t.Add(s.interval)
+}
+
+type field struct {
+	name     string
+	min, max int
+	names    []string
+}
+
+var (
+	minuteField = field{name: "minute", min: 0, max: 59}
+	hourField   = field{name: "hour", min: 0, max: 23}
+	domField    = field{name: "day of month", min: 1, max: 31}
+	monthField  = field{name: "month", min: 1, max: 12, names: []string{
+		"jan", "feb", "mar", "apr", "may", "jun", "jul", "aug", "sep", "oct", "nov", "dec",
+	}}
+	dowField = field{name: "day of week", min: 0, max: 7, names: []string{
+		"sun", "mon", "tue", "wed", "thu", "fri", "sat",
+	}}
+)
+
+var descriptors = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+// cronSchedule holds the allowed values of each field as bit sets.
+type cronSchedule struct {
+	minute, hour, dom, month, dow uint64
+	// domStar and dowStar are true when the day fields are unrestricted, which changes how days are matched.
+	domStar, dowStar bool
+}
+
+// ParseCron parses a standard five field cron expression (minute, hour, day of month, month, day of week)
+// or one of the descriptors @yearly, @annually, @monthly, @weekly, @daily, @midnight, @hourly and @every <duration>.
+// Fields support lists, ranges, steps and the month and day of week names.
+// Activations are computed in the location of the time passed to Next.
+func ParseCron(expr string) (Schedule, error) {
+	expr = strings.TrimSpace(expr)
+	if interval, ok := strings.CutPrefix(expr, "@every "); ok {
+		d, err := time.ParseDuration(strings.TrimSpace(interval))
+		if err != nil {
+			return nil, fmt.Errorf("invalid cron expression %q: %w", expr, err)
+		}
+		return Every(d)
+	}
+	if descriptor, ok := descriptors[strings.ToLower(expr)]; ok {
+		expr = descriptor
+	}
+
+	ff := strings.Fields(expr)
+	if len(ff) != 5 {
+		return nil, fmt.Errorf("invalid cron expression %q: expected 5 fields, got %d", expr, len(ff))
+	}
+
+	s := &cronSchedule{}
+	var errs []error
+	var err error
+	if s.minute, err = minuteField.parse(ff[0]); err != nil {
+		errs = append(errs, err)
+	}
+	if s.hour, err = hourField.parse(ff[1]); err != nil {
+		errs = append(errs, err)
+	}
+	if s.dom, err = domField.parse(ff[2]); err != nil {
+		errs = append(errs, err)
+	}
+	if s.month, err = monthField.parse(ff[3]); err != nil {
+		errs = append(errs, err)
+	}
+	if s.dow, err = dowField.parse(ff[4]); err != nil {
+		errs = append(errs, err)
+	}
+	if len(errs) > 0 {
+		return nil, fmt.Errorf("invalid cron expression %q: %w", expr, errors.Join(errs...))
+	}
+
+	// Sunday is both 0 and 7.
+	if s.dow&(1<<7) != 0 {
+		s.dow |= 1
+	}
+	s.domStar = ff[2] == "*" || ff[2] == "?"
+	s.dowStar = ff[4] == "*" || ff[4] == "?"
+
+	return s, nil
+}
+
+func (f field) parse(expr string) (uint64, error) {
+	var set uint64
+	for part := range strings.SplitSeq(expr, ",") {
+		bitsSet, err := f.parseRange(part)
+		if err != nil {
+			return 0, fmt.Errorf("%s %q: %w", f.name, part, err)
+		}
+		set |= bitsSet
+	}
+	return set, nil
+}
+
+func (f field) parseRange(expr string) (uint64, error) {
+	rangeExpr, stepExpr, hasStep := strings.Cut(expr, "/")
+
+	step := 1
+	if hasStep {
+		var err error
+		step, err = strconv.Atoi(stepExpr)
+		if err != nil || step <= 0 {
+			return 0, errors.New("step has to be a positive number")
+		}
+	}
+
+	start, end := f.min, f.max
+	switch {
+	case rangeExpr == "*" || rangeExpr == "?":
+	case strings.Contains(rangeExpr, "-"):
+		low, high, _ := strings.Cut(rangeExpr, "-")
+		var err error
+		if start, err = f.value(low); err != nil {
+			return 0, err
+		}
+		if end, err = f.value(high); err != nil {
+			return 0, err
+		}
+		if start > end {
+			return 0, errors.New("range start is after its end")
+		}
+	default:
+		var err error
+		if start, err = f.value(rangeExpr); err != nil {
+			return 0, err
+		}
+		// a single value with a step, e.g. 5/15, runs from the value to the end of the field.
+		end = start
+		if hasStep {
+			end = f.max
+		}
+	}
+
+	var set uint64
+	for v := start; v <= end; v += step {
+		set |= 1 << v
+	}
+	return set, nil
+}
+
+func (f field) value(expr string) (int, error) {
+	for i, name := range f.names {
+		if strings.EqualFold(expr, name) {
+			return i + f.min, nil
+		}
+	}
+	v, err := strconv.Atoi(expr)
+	if err != nil {
+		return 0, fmt.Errorf("invalid value %s", expr)
+	}
+	if v < f.min || v > f.max {
+		return 0, fmt.Errorf("value %d out of range [%d, %d]", v, f.min, f.max)
+	}
+	return v, nil
+}
+
+// Next walks forward field by field, from the month down to the minute, skipping whole periods which do not match.
+func (s *cronSchedule) Next(t time.Time) time.Time {
+	loc := t.Location()
+	t = t.Truncate(time.Minute).Add(time.Minute)
+	limit := t.AddDate(5, 0, 0)
+
+	for t.Before(limit) {
+		if s.month&(1<<uint(t.Month())) == 0 {
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, loc)
+			continue
+		}
+		if !s.dayMatches(t) {
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, loc)
+			continue
+		}
+		if s.hour&(1<<uint(t.Hour())) == 0 {
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, loc)
+			continue
+		}
+		if s.minute&(1<<uint(t.Minute())) == 0 {
+			t = t.Truncate(time.Minute).Add(time.Minute)
+			continue
+		}
+		return t
+	}
+	return time.Time{}
+}
+
+// dayMatches follows the cron convention: when both day fields are restricted, a day matching either is a match.
+func (s *cronSchedule) dayMatches(t time.Time) bool {
+	domMatch := s.dom&(1<<uint(t.Day())) != 0
+	dowMatch := s.dow&(1<<uint(t.Weekday())) != 0
+	if s.domStar || s.dowStar {
+		return domMatch && dowMatch
+	}
+	return domMatch || dowMatch
+}
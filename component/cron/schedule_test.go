@@ -0,0 +1,85 @@
+package cron
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseCron_Next(t *testing.T) {
+	t.Parallel()
+
+	// Wednesday.
+	from := time.Date(2026, time.January, 14, 10, 30, 15, 0, time.UTC)
+
+	tests := map[string]struct {
+		expr     string
+		expected time.Time
+	}{
+		"every minute":         {expr: "* * * * *", expected: time.Date(2026, time.January, 14, 10, 31, 0, 0, time.UTC)},
+		"every 15 minutes":     {expr: "*/15 * * * *", expected: time.Date(2026, time.January, 14, 10, 45, 0, 0, time.UTC)},
+		"list of minutes":      {expr: "5,20 * * * *", expected: time.Date(2026, time.January, 14, 11, 5, 0, 0, time.UTC)},
+		"hour range":           {expr: "0 9-17 * * *", expected: time.Date(2026, time.January, 14, 11, 0, 0, 0, time.UTC)},
+		"value with step":      {expr: "10/20 * * * *", expected: time.Date(2026, time.January, 14, 10, 50, 0, 0, time.UTC)},
+		"next day":             {expr: "0 8 * * *", expected: time.Date(2026, time.January, 15, 8, 0, 0, 0, time.UTC)},
+		"day of week name":     {expr: "0 0 * * fri", expected: time.Date(2026, time.January, 16, 0, 0, 0, 0, time.UTC)},
+		"sunday as 7":          {expr: "0 0 * * 7", expected: time.Date(2026, time.January, 18, 0, 0, 0, 0, time.UTC)},
+		"month name":           {expr: "0 0 1 mar *", expected: time.Date(2026, time.March, 1, 0, 0, 0, 0, time.UTC)},
+		"day of month or week": {expr: "0 0 20 * mon", expected: time.Date(2026, time.January, 19, 0, 0, 0, 0, time.UTC)},
+		"leap day":             {expr: "0 0 29 2 *", expected: time.Date(2028, time.February, 29, 0, 0, 0, 0, time.UTC)},
+		"hourly":               {expr: "@hourly", expected: time.Date(2026, time.January, 14, 11, 0, 0, 0, time.UTC)},
+		"monthly":              {expr: "@monthly", expected: time.Date(2026, time.February, 1, 0, 0, 0, 0, time.UTC)},
+		"every duration":       {expr: "@every 90s", expected: from.Add(90 * time.Second)},
+		"impossible date":      {expr: "0 0 30 2 *", expected: time.Time{}},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			schedule, err := ParseCron(tt.expr)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, schedule.Next(from))
+		})
+	}
+}
+
+func TestParseCron_Location(t *testing.T) {
+	t.Parallel()
+
+	loc := time.FixedZone("UTC+2", 2*60*60)
+	schedule, err := ParseCron("0 8 * * *")
+	require.NoError(t, err)
+
+	got := schedule.Next(time.Date(2026, time.January, 14, 7, 0, 0, 0, time.UTC).In(loc))
+	assert.Equal(t, time.Date(2026, time.January, 15, 8, 0, 0, 0, loc), got)
+}
+
+func TestParseCron_Errors(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		expr        string
+		expectedErr string
+	}{
+		"wrong field count": {expr: "* * *", expectedErr: `invalid cron expression "* * *": expected 5 fields, got 3`},
+		"out of range":      {expr: "60 * * * *", expectedErr: `invalid cron expression "60 * * * *": minute "60": value 60 out of range [0, 59]`},
+		"invalid value":     {expr: "* * * foo *", expectedErr: `invalid cron expression "* * * foo *": month "foo": invalid value foo`},
+		"invalid step":      {expr: "*/0 * * * *", expectedErr: `invalid cron expression "*/0 * * * *": minute "*/0": step has to be a positive number`},
+		"reversed range":    {expr: "* 5-1 * * *", expectedErr: `invalid cron expression "* 5-1 * * *": hour "5-1": range start is after its end`},
+		"all errors": {expr: "60 24 * * *", expectedErr: `invalid cron expression "60 24 * * *": minute "60": value 60 out of range [0, 59]` + "\n" +
+			`hour "24": value 24 out of range [0, 23]`},
+		"invalid duration": {expr: "@every 1x", expectedErr: `invalid cron expression "@every 1x": time: unknown unit "x" in duration "1x"`},
+		"zero duration":    {expr: "@every 0s", expectedErr: "negative or zero interval provided"},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := ParseCron(tt.expr)
+			require.EqualError(t, err, tt.expectedErr)
+			assert.Nil(t, got)
+		})
+	}
+}
@@ -6,25 +6,66 @@ import (
 	"fmt"
 	"log/slog"
 	"net"
-
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	patronhttp "github.com/beatlabs/patron/component/http"
+	patronhealth "github.com/beatlabs/patron/health"
+	"github.com/beatlabs/patron/observability/log"
 	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/health"
 	"google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/keepalive"
 	"google.golang.org/grpc/reflection"
 )
 
+const defaultShutdownGracePeriod = 5 * time.Second
+
+// logger of the component, whose level can be changed at runtime through the "grpc" name.
+var logger = log.Named("grpc")
+
 // Component hosts a gRPC server with health and optional reflection.
+// The health service reports the server and its services as serving while the components of the service are
+// ready, as the HTTP ready check does, and their health checks pass, see WithHealthCheck, and as not serving
+// as soon as the component shuts down.
+// The calls get the correlation ID of the correlation.HeaderID metadata, or a new one, and a logger with it
+// in their context, their panics are recovered into codes.Internal errors and they are logged at debug level.
 type Component struct {
-	port             int
-	serverOptions    []grpc.ServerOption
-	enableReflection bool
-	srv              *grpc.Server
+	port                int
+	serverOptions       []grpc.ServerOption
+	enableReflection    bool
+	certFile            string
+	keyFile             string
+	clientCAFile        string
+	certs               *certificates
+	keepaliveParams     *keepalive.ServerParameters
+	keepalivePolicy     *keepalive.EnforcementPolicy
+	maxRecvMessageSize  int
+	maxSendMessageSize  int
+	shutdownGracePeriod time.Duration
+	readiness           *patronhealth.Registry
+	healthChecks        map[string][]patronhealth.Checker
+	healthCheckInterval time.Duration
+	httpHandler         http.Handler
+	httpTimeouts        patronhttp.ServerTimeouts
+	health              *health.Server
+	srv                 *grpc.Server
+	chReady             chan struct{}
+	readyOnce           sync.Once
+	listening           atomic.Bool
 }
 
 // New creates a gRPC Component on the given port with functional options.
 func New(port int, options ...OptionFunc) (*Component, error) {
-	c := new(Component)
+	c := &Component{
+		chReady:             make(chan struct{}),
+		shutdownGracePeriod: defaultShutdownGracePeriod,
+		healthCheckInterval: defaultHealthCheckInterval,
+	}
 	if port <= 0 || port > 65535 {
 		return nil, fmt.Errorf("port is invalid: %d", port)
 	}
@@ -39,11 +80,45 @@ func New(port int, options ...OptionFunc) (*Component, error) {
 		}
 	}
 
-	c.serverOptions = append(c.serverOptions, grpc.StatsHandler(otelgrpc.NewServerHandler()))
-	srv := grpc.NewServer(c.serverOptions...)
+	// the observability interceptors are chained first, to recover the panics of the interceptors provided with
+	// grpc.ChainUnaryInterceptor and grpc.ChainStreamInterceptor. The ones provided with grpc.UnaryInterceptor and
+	// grpc.StreamInterceptor run before any chained interceptor, so their panics are not recovered.
+	serverOptions := append([]grpc.ServerOption{
+		grpc.ChainUnaryInterceptor(unaryInterceptor),
+		grpc.ChainStreamInterceptor(streamInterceptor),
+	}, c.serverOptions...)
 
-	hs := health.NewServer()
-	grpc_health_v1.RegisterHealthServer(srv, hs)
+	if c.certFile != "" {
+		c.certs, err = newCertificates(c.certFile, c.keyFile, c.clientCAFile)
+		if err != nil {
+			return nil, err
+		}
+		if c.httpHandler == nil {
+			serverOptions = append(serverOptions, grpc.Creds(credentials.NewTLS(c.certs.tlsConfig())))
+		}
+	}
+	if c.httpHandler != nil {
+		c.httpTimeouts, err = patronhttp.LoadServerTimeouts()
+		if err != nil {
+			return nil, err
+		}
+	}
+	if c.keepaliveParams != nil {
+		serverOptions = append(serverOptions, grpc.KeepaliveParams(*c.keepaliveParams),
+			grpc.KeepaliveEnforcementPolicy(*c.keepalivePolicy))
+	}
+	if c.maxRecvMessageSize > 0 {
+		serverOptions = append(serverOptions, grpc.MaxRecvMsgSize(c.maxRecvMessageSize))
+	}
+	if c.maxSendMessageSize > 0 {
+		serverOptions = append(serverOptions, grpc.MaxSendMsgSize(c.maxSendMessageSize))
+	}
+
+	serverOptions = append(serverOptions, grpc.StatsHandler(otelgrpc.NewServerHandler()))
+	srv := grpc.NewServer(serverOptions...)
+
+	c.health = newHealthServer()
+	grpc_health_v1.RegisterHealthServer(srv, c.health)
 
 	if c.enableReflection {
 		reflection.Register(srv)
@@ -67,17 +142,99 @@ func (c *Component) Run(ctx context.Context) error {
 		return fmt.Errorf("failed to listen: %w", err)
 	}
 
+	serve := func() error { return c.srv.Serve(lis) }
+	stop := c.stop
+	if c.httpHandler != nil {
+		httpSrv := c.createHTTPServer()
+		serve = func() error { return c.serveHTTPServer(httpSrv, lis) }
+		stop = func() { c.stopHTTPServer(httpSrv) }
+	}
+
 	stopCtx, stopCancel := context.WithCancel(context.Background())
-	defer stopCancel()
+	chStopped := make(chan struct{})
 
 	go func() {
+		defer close(chStopped)
 		select {
 		case <-ctx.Done():
-			c.srv.GracefulStop()
+			c.listening.Store(false)
+			stop()
 		case <-stopCtx.Done():
 		}
 	}()
 
-	slog.Debug("gRPC component listening", slog.Int("port", c.port))
-	return c.srv.Serve(lis)
+	logger.Debug("gRPC component listening", slog.Int("port", c.port), slog.Bool("tls", c.certs != nil),
+		slog.Bool("http", c.httpHandler != nil))
+	c.listening.Store(true)
+	defer c.listening.Store(false)
+	c.readyOnce.Do(func() { close(c.chReady) })
+
+	c.updateHealth()
+	healthCtx, healthCancel := context.WithCancel(ctx)
+	chHealthStopped := make(chan struct{})
+	go func() {
+		defer close(chHealthStopped)
+		c.watchHealth(healthCtx)
+	}()
+
+	err = serve()
+
+	// the calls in flight are waited for, up to the shutdown grace period.
+	stopCancel()
+	<-chStopped
+	healthCancel()
+	<-chHealthStopped
+	return err
+}
+
+// stop stops the server gracefully, closing the connections left after the shutdown grace period.
+func (c *Component) stop() {
+	logger.Info("shutting down gRPC component")
+	// the clients are told to drain the connections before they are closed.
+	c.health.Shutdown()
+
+	chDone := make(chan struct{})
+	go func() {
+		c.srv.GracefulStop()
+		close(chDone)
+	}()
+
+	timer := time.NewTimer(c.shutdownGracePeriod)
+	defer timer.Stop()
+
+	select {
+	case <-chDone:
+	case <-timer.C:
+		logger.Warn("gRPC component shutdown grace period exceeded, stopping", slog.Duration("grace_period", c.shutdownGracePeriod))
+		c.srv.Stop()
+		<-chDone
+	}
+}
+
+// Reload reads the TLS certificate files again, if any, applying them to the new connections.
+// The previous certificates are kept if any of the files fails to load.
+func (c *Component) Reload(_ context.Context) error {
+	if c.certs == nil {
+		return nil
+	}
+	if err := c.certs.load(); err != nil {
+		return err
+	}
+	logger.Info("gRPC component certificates reloaded", slog.Int("port", c.port))
+	return nil
+}
+
+// IsReady returns true while the gRPC server is serving.
+func (c *Component) IsReady() bool {
+	return c.listening.Load()
+}
+
+// Ready blocks until the gRPC server is listening or the context is done.
+func (c *Component) Ready(ctx context.Context) error {
+	select {
+	case <-c.chReady:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
 }
@@ -244,6 +244,60 @@ func TestComponent_Run_Stream(t *testing.T) {
 	<-chDone
 }
 
+func TestComponent_Run_ShutdownGracePeriod(t *testing.T) {
+	cmp, err := New(60000, WithShutdownGracePeriod(100*time.Millisecond))
+	require.NoError(t, err)
+	srv := &blockingServer{chStarted: make(chan struct{})}
+	examples.RegisterGreeterServer(cmp.Server(), srv)
+	ctx, cnl := context.WithCancel(context.Background())
+	chDone := make(chan error)
+	go func() {
+		chDone <- cmp.Run(ctx)
+	}()
+
+	conn, err := grpc.NewClient("localhost:60000", grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	defer func() { require.NoError(t, conn.Close()) }()
+	stream, err := examples.NewGreeterClient(conn).SayHelloStream(context.Background(), &examples.HelloRequest{FirstName: "TEST"})
+	require.NoError(t, err)
+	<-srv.chStarted
+
+	cnl()
+	select {
+	case err := <-chDone:
+		require.NoError(t, err)
+	case <-time.After(5 * time.Second):
+		require.Fail(t, "the stream in flight blocked the shutdown")
+	}
+	_, err = stream.Recv()
+	require.Error(t, err)
+}
+
+func TestComponent_Run_RecoversInterceptorPanics(t *testing.T) {
+	panicking := func(context.Context, any, *grpc.UnaryServerInfo, grpc.UnaryHandler) (any, error) {
+		panic("PANIC")
+	}
+	cmp, err := New(60000, WithServerOptions(grpc.ChainUnaryInterceptor(panicking)))
+	require.NoError(t, err)
+	examples.RegisterGreeterServer(cmp.Server(), &server{})
+	ctx, cnl := context.WithCancel(context.Background())
+	chDone := make(chan error)
+	go func() {
+		chDone <- cmp.Run(ctx)
+	}()
+
+	conn, err := grpc.NewClient("localhost:60000", grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	r, err := examples.NewGreeterClient(conn).SayHello(context.Background(), &examples.HelloRequest{FirstName: "TEST"},
+		grpc.WaitForReady(true))
+	require.EqualError(t, err, "rpc error: code = Internal desc = Internal")
+	assert.Nil(t, r)
+
+	require.NoError(t, conn.Close())
+	cnl()
+	require.NoError(t, <-chDone)
+}
+
 type server struct {
 	examples.UnimplementedGreeterServer
 }
@@ -262,3 +316,26 @@ func (s *server) SayHelloStream(req *examples.HelloRequest, srv examples.Greeter
 
 	return srv.Send(&examples.HelloReply{Message: "Hello " + req.GetFirstName()})
 }
+
+// slowServer replies after a delay.
+type slowServer struct {
+	examples.UnimplementedGreeterServer
+	delay time.Duration
+}
+
+func (s *slowServer) SayHello(_ context.Context, in *examples.HelloRequest) (*examples.HelloReply, error) {
+	time.Sleep(s.delay)
+	return &examples.HelloReply{Message: "Hello " + in.GetFirstName()}, nil
+}
+
+// blockingServer streams until the stream is closed.
+type blockingServer struct {
+	examples.UnimplementedGreeterServer
+	chStarted chan struct{}
+}
+
+func (s *blockingServer) SayHelloStream(_ *examples.HelloRequest, srv examples.Greeter_SayHelloStreamServer) error {
+	close(s.chStarted)
+	<-srv.Context().Done()
+	return srv.Context().Err()
+}
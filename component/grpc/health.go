@@ -0,0 +1,76 @@
+package grpc
+
+import (
+	"context"
+	"time"
+
+	patronhealth "github.com/beatlabs/patron/health"
+	"google.golang.org/grpc/health"
+	"google.golang.org/grpc/health/grpc_health_v1"
+)
+
+const defaultHealthCheckInterval = time.Second
+
+// watchHealth updates the health status of the server and of its services every health check interval,
+// until the context is done.
+func (c *Component) watchHealth(ctx context.Context) {
+	ticker := time.NewTicker(c.healthCheckInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			c.updateHealth()
+		}
+	}
+}
+
+// updateHealth sets the health status of the server, the "" service, and of its services. The server is serving
+// when the components of the service are ready, see WithReadiness, and its checks pass. A service is serving
+// when the server is serving and the checks of the service pass.
+func (c *Component) updateHealth() {
+	serving := c.readinessRegistry().Ready() && c.healthChecksPass("")
+	c.health.SetServingStatus("", servingStatus(serving))
+
+	for name := range c.srv.GetServiceInfo() {
+		c.health.SetServingStatus(name, servingStatus(serving && c.healthChecksPass(name)))
+	}
+	for name := range c.healthChecks {
+		if name != "" {
+			c.health.SetServingStatus(name, servingStatus(serving && c.healthChecksPass(name)))
+		}
+	}
+}
+
+// readinessRegistry returns the registry set with WithReadiness, or the default one.
+func (c *Component) readinessRegistry() *patronhealth.Registry {
+	if c.readiness != nil {
+		return c.readiness
+	}
+	return patronhealth.Default()
+}
+
+func (c *Component) healthChecksPass(service string) bool {
+	for _, check := range c.healthChecks[service] {
+		if !check.IsReady() {
+			return false
+		}
+	}
+	return true
+}
+
+func servingStatus(serving bool) grpc_health_v1.HealthCheckResponse_ServingStatus {
+	if serving {
+		return grpc_health_v1.HealthCheckResponse_SERVING
+	}
+	return grpc_health_v1.HealthCheckResponse_NOT_SERVING
+}
+
+// newHealthServer returns a health server reporting the server as not serving until it is updated.
+func newHealthServer() *health.Server {
+	hs := health.NewServer()
+	hs.SetServingStatus("", grpc_health_v1.HealthCheckResponse_NOT_SERVING)
+	return hs
+}
@@ -0,0 +1,111 @@
+package grpc
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/beatlabs/patron/examples"
+	"github.com/beatlabs/patron/health"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health/grpc_health_v1"
+)
+
+const greeterService = "examples.Greeter"
+
+func TestComponent_Health(t *testing.T) {
+	registry := health.NewRegistry()
+	dependency := &testChecker{}
+	registry.Register("dependency", dependency)
+	registry.SetStarted("dependency", true)
+
+	greeter := &testChecker{}
+	greeter.ready.Store(true)
+	cmp, err := New(60000, WithReadiness(registry), WithHealthCheck(greeterService, greeter),
+		WithShutdownGracePeriod(100*time.Millisecond))
+	require.NoError(t, err)
+	cmp.healthCheckInterval = 10 * time.Millisecond
+	examples.RegisterGreeterServer(cmp.Server(), &server{})
+	ctx, cnl := context.WithCancel(context.Background())
+	chDone := make(chan error)
+	go func() {
+		chDone <- cmp.Run(ctx)
+	}()
+
+	conn, err := grpc.NewClient("localhost:60000", grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	defer func() { require.NoError(t, conn.Close()) }()
+	client := grpc_health_v1.NewHealthClient(conn)
+	require.NoError(t, cmp.Ready(ctx))
+
+	assertStatus := func(service string, expected grpc_health_v1.HealthCheckResponse_ServingStatus) {
+		t.Helper()
+		assert.EventuallyWithT(t, func(c *assert.CollectT) {
+			rsp, err := client.Check(context.Background(), &grpc_health_v1.HealthCheckRequest{Service: service})
+			require.NoError(c, err)
+			assert.Equal(c, expected, rsp.GetStatus())
+		}, time.Second, 10*time.Millisecond, service)
+	}
+
+	// a dependency is not ready.
+	assertStatus("", grpc_health_v1.HealthCheckResponse_NOT_SERVING)
+	assertStatus(greeterService, grpc_health_v1.HealthCheckResponse_NOT_SERVING)
+
+	dependency.ready.Store(true)
+	assertStatus("", grpc_health_v1.HealthCheckResponse_SERVING)
+	assertStatus(greeterService, grpc_health_v1.HealthCheckResponse_SERVING)
+
+	// the check of the service fails.
+	greeter.ready.Store(false)
+	assertStatus("", grpc_health_v1.HealthCheckResponse_SERVING)
+	assertStatus(greeterService, grpc_health_v1.HealthCheckResponse_NOT_SERVING)
+	greeter.ready.Store(true)
+	assertStatus(greeterService, grpc_health_v1.HealthCheckResponse_SERVING)
+
+	// not serving as soon as the component shuts down.
+	watch, err := client.Watch(context.Background(), &grpc_health_v1.HealthCheckRequest{Service: greeterService})
+	require.NoError(t, err)
+	rsp, err := watch.Recv()
+	require.NoError(t, err)
+	assert.Equal(t, grpc_health_v1.HealthCheckResponse_SERVING, rsp.GetStatus())
+
+	cnl()
+	rsp, err = watch.Recv()
+	require.NoError(t, err)
+	assert.Equal(t, grpc_health_v1.HealthCheckResponse_NOT_SERVING, rsp.GetStatus())
+	require.NoError(t, <-chDone)
+}
+
+func TestWithHealthCheck(t *testing.T) {
+	comp := new(Component)
+	require.EqualError(t, WithHealthCheck(greeterService, nil)(comp), "health check is nil")
+
+	check := &testChecker{}
+	require.NoError(t, WithHealthCheck(greeterService, check)(comp))
+	require.NoError(t, WithHealthCheck("", check)(comp))
+	assert.Len(t, comp.healthChecks[greeterService], 1)
+	assert.Len(t, comp.healthChecks[""], 1)
+}
+
+func TestWithReadiness(t *testing.T) {
+	comp := new(Component)
+	assert.Same(t, health.Default(), comp.readinessRegistry())
+
+	require.EqualError(t, WithReadiness(nil)(comp), "readiness registry is nil")
+
+	registry := health.NewRegistry()
+	require.NoError(t, WithReadiness(registry)(comp))
+	assert.Same(t, registry, comp.readinessRegistry())
+}
+
+type testChecker struct {
+	ready atomic.Bool
+}
+
+func (c *testChecker) IsReady() bool {
+	return c.ready.Load()
+}
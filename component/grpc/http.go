@@ -0,0 +1,78 @@
+package grpc
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"log/slog"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// createHTTPServer returns the HTTP server serving the gRPC calls, HTTP/2 requests with the application/grpc
+// content type, with the gRPC server and the other requests with the HTTP handler.
+// The server has no read or write timeouts, since they would apply to the gRPC streams as well; the ones of the
+// HTTP component are applied to the requests of the handler instead, see serveHTTP.
+func (c *Component) createHTTPServer() *http.Server {
+	srv := &http.Server{
+		Handler:           http.HandlerFunc(c.serveHTTP),
+		ReadHeaderTimeout: c.httpTimeouts.Read,
+		IdleTimeout:       c.httpTimeouts.Idle,
+		Protocols:         &http.Protocols{},
+	}
+	srv.Protocols.SetHTTP1(true)
+	if c.certs != nil {
+		srv.Protocols.SetHTTP2(true)
+		srv.TLSConfig = c.certs.tlsConfig("h2", "http/1.1")
+	} else {
+		// gRPC requires HTTP/2, which is negotiated with the prior knowledge of the clients without TLS.
+		srv.Protocols.SetUnencryptedHTTP2(true)
+	}
+	return srv
+}
+
+// serveHTTP dispatches every request on its own, so that a connection can carry both gRPC calls and HTTP requests.
+func (c *Component) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.ProtoMajor == 2 && strings.HasPrefix(r.Header.Get("Content-Type"), "application/grpc") {
+		c.srv.ServeHTTP(w, r)
+		return
+	}
+
+	// the deadlines bound the request only, not the connection it is served on.
+	rc := http.NewResponseController(w)
+	now := time.Now()
+	_ = rc.SetReadDeadline(now.Add(c.httpTimeouts.Read))
+	_ = rc.SetWriteDeadline(now.Add(c.httpTimeouts.Write))
+	c.httpHandler.ServeHTTP(w, r)
+}
+
+func (c *Component) serveHTTPServer(srv *http.Server, lis net.Listener) error {
+	if srv.TLSConfig != nil {
+		lis = tls.NewListener(lis, srv.TLSConfig)
+	}
+	if err := srv.Serve(lis); !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
+
+// stopHTTPServer shuts the HTTP server down, closing the connections left after the shutdown grace period.
+// The gRPC server is stopped afterwards, canceling the gRPC calls left, since it cannot drain the calls served
+// through the HTTP server.
+func (c *Component) stopHTTPServer(srv *http.Server) {
+	logger.Info("shutting down gRPC component")
+	// the clients are told to drain the connections before they are closed.
+	c.health.Shutdown()
+
+	ctx, cancel := context.WithTimeout(context.Background(), c.shutdownGracePeriod)
+	defer cancel()
+
+	if err := srv.Shutdown(ctx); err != nil {
+		logger.Warn("gRPC component shutdown grace period exceeded, stopping",
+			slog.Duration("grace_period", c.shutdownGracePeriod))
+		_ = srv.Close()
+	}
+	c.srv.Stop()
+}
@@ -0,0 +1,220 @@
+package grpc
+
+import (
+	"context"
+	"crypto/tls"
+	"io"
+	"net/http"
+	"net/http/httptrace"
+	"testing"
+	"time"
+
+	"github.com/beatlabs/patron/examples"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/peer"
+)
+
+func TestComponent_HTTPHandler(t *testing.T) {
+	dir := t.TempDir()
+	ca := newTestCA(t)
+	certFile, keyFile := ca.writeCert(t, dir, "server", false)
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.WriteString(w, r.Proto)
+		if r.TLS != nil {
+			_, _ = io.WriteString(w, " TLS")
+		}
+	})
+
+	tests := map[string]struct {
+		options       []OptionFunc
+		creds         credentials.TransportCredentials
+		scheme        string
+		http2         bool
+		expectedProto string
+	}{
+		"cleartext http/1.1": {
+			creds: insecure.NewCredentials(), scheme: "http", expectedProto: "HTTP/1.1",
+		},
+		"tls http/1.1": {
+			options: []OptionFunc{WithTLS(certFile, keyFile)},
+			creds:   credentials.NewTLS(&tls.Config{MinVersion: tls.VersionTLS12, RootCAs: ca.pool()}),
+			scheme:  "https", expectedProto: "HTTP/1.1 TLS",
+		},
+		"tls http/2": {
+			options: []OptionFunc{WithTLS(certFile, keyFile)},
+			creds:   credentials.NewTLS(&tls.Config{MinVersion: tls.VersionTLS12, RootCAs: ca.pool()}),
+			scheme:  "https", http2: true, expectedProto: "HTTP/2.0 TLS",
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			cmp, err := New(60002, append(tt.options, WithHTTPHandler(handler))...)
+			require.NoError(t, err)
+			examples.RegisterGreeterServer(cmp.Server(), &server{})
+			ctx, cnl := context.WithCancel(context.Background())
+			chDone := make(chan error)
+			go func() {
+				chDone <- cmp.Run(ctx)
+			}()
+			require.NoError(t, cmp.Ready(ctx))
+
+			conn, err := grpc.NewClient("localhost:60002", grpc.WithTransportCredentials(tt.creds))
+			require.NoError(t, err)
+			r, err := examples.NewGreeterClient(conn).SayHello(ctx, &examples.HelloRequest{FirstName: "TEST"})
+			require.NoError(t, err)
+			assert.Equal(t, "Hello TEST", r.GetMessage())
+			require.NoError(t, conn.Close())
+
+			transport := &http.Transport{
+				TLSClientConfig:   &tls.Config{MinVersion: tls.VersionTLS12, RootCAs: ca.pool()},
+				ForceAttemptHTTP2: tt.http2,
+			}
+			defer transport.CloseIdleConnections()
+			// the second request reuses the connection of the first one.
+			for i := range 2 {
+				reused := false
+				trace := &httptrace.ClientTrace{GotConn: func(info httptrace.GotConnInfo) { reused = info.Reused }}
+				req, err := http.NewRequestWithContext(httptrace.WithClientTrace(ctx, trace), http.MethodGet,
+					tt.scheme+"://localhost:60002/", nil)
+				require.NoError(t, err)
+				rsp, err := (&http.Client{Transport: transport}).Do(req)
+				require.NoError(t, err)
+				body, err := io.ReadAll(rsp.Body)
+				require.NoError(t, err)
+				require.NoError(t, rsp.Body.Close())
+				assert.Equal(t, http.StatusOK, rsp.StatusCode)
+				assert.Equal(t, tt.expectedProto, string(body))
+				assert.Equal(t, i > 0, reused)
+			}
+
+			cnl()
+			require.NoError(t, <-chDone)
+		})
+	}
+}
+
+func TestComponent_HTTPHandler_MTLSPeer(t *testing.T) {
+	dir := t.TempDir()
+	ca := newTestCA(t)
+	certFile, keyFile := ca.writeCert(t, dir, "server", false)
+	clientCertFile, clientKeyFile := ca.writeCert(t, dir, "client", true)
+	caFile := ca.writeCA(t, dir)
+
+	chPeer := make(chan *peer.Peer, 1)
+	peerInterceptor := func(ctx context.Context, req any, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		p, _ := peer.FromContext(ctx)
+		chPeer <- p
+		return handler(ctx, req)
+	}
+
+	cmp, err := New(60002, WithMTLS(certFile, keyFile, caFile), WithHTTPHandler(http.NotFoundHandler()),
+		WithServerOptions(grpc.ChainUnaryInterceptor(peerInterceptor)))
+	require.NoError(t, err)
+	examples.RegisterGreeterServer(cmp.Server(), &server{})
+	ctx, cnl := context.WithCancel(context.Background())
+	chDone := make(chan error)
+	go func() {
+		chDone <- cmp.Run(ctx)
+	}()
+	require.NoError(t, cmp.Ready(ctx))
+
+	cert, err := tls.LoadX509KeyPair(clientCertFile, clientKeyFile)
+	require.NoError(t, err)
+	tlsCfg := &tls.Config{MinVersion: tls.VersionTLS12, RootCAs: ca.pool(), Certificates: []tls.Certificate{cert}}
+	conn, err := grpc.NewClient("localhost:60002", grpc.WithTransportCredentials(credentials.NewTLS(tlsCfg)))
+	require.NoError(t, err)
+	_, err = examples.NewGreeterClient(conn).SayHello(ctx, &examples.HelloRequest{FirstName: "TEST"})
+	require.NoError(t, err)
+	require.NoError(t, conn.Close())
+
+	p := <-chPeer
+	require.NotNil(t, p)
+	info, ok := p.AuthInfo.(credentials.TLSInfo)
+	require.True(t, ok)
+	assert.Equal(t, credentials.PrivacyAndIntegrity, info.SecurityLevel)
+	require.Len(t, info.State.PeerCertificates, 1)
+	assert.Equal(t, "client", info.State.PeerCertificates[0].Subject.CommonName)
+
+	cnl()
+	require.NoError(t, <-chDone)
+}
+
+func TestComponent_HTTPHandler_ShutdownGracePeriod(t *testing.T) {
+	cmp, err := New(60002, WithHTTPHandler(http.NotFoundHandler()), WithShutdownGracePeriod(100*time.Millisecond))
+	require.NoError(t, err)
+	srv := &blockingServer{chStarted: make(chan struct{})}
+	examples.RegisterGreeterServer(cmp.Server(), srv)
+	ctx, cnl := context.WithCancel(context.Background())
+	chDone := make(chan error)
+	go func() {
+		chDone <- cmp.Run(ctx)
+	}()
+	require.NoError(t, cmp.Ready(ctx))
+
+	conn, err := grpc.NewClient("localhost:60002", grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	defer func() { require.NoError(t, conn.Close()) }()
+	stream, err := examples.NewGreeterClient(conn).SayHelloStream(context.Background(), &examples.HelloRequest{FirstName: "TEST"})
+	require.NoError(t, err)
+	<-srv.chStarted
+
+	cnl()
+	select {
+	case err := <-chDone:
+		require.NoError(t, err)
+	case <-time.After(5 * time.Second):
+		require.Fail(t, "the stream in flight blocked the shutdown")
+	}
+	_, err = stream.Recv()
+	require.Error(t, err)
+}
+
+func TestComponent_HTTPHandler_Timeouts(t *testing.T) {
+	t.Setenv("PATRON_HTTP_WRITE_TIMEOUT", "100ms")
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		time.Sleep(300 * time.Millisecond)
+		_, _ = io.WriteString(w, "late")
+	})
+	cmp, err := New(60002, WithHTTPHandler(handler))
+	require.NoError(t, err)
+	examples.RegisterGreeterServer(cmp.Server(), &slowServer{delay: 300 * time.Millisecond})
+	ctx, cnl := context.WithCancel(context.Background())
+	chDone := make(chan error)
+	go func() {
+		chDone <- cmp.Run(ctx)
+	}()
+	require.NoError(t, cmp.Ready(ctx))
+
+	// the write timeout of the HTTP component bounds the HTTP requests only.
+	conn, err := grpc.NewClient("localhost:60002", grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	r, err := examples.NewGreeterClient(conn).SayHello(ctx, &examples.HelloRequest{FirstName: "TEST"})
+	require.NoError(t, err)
+	assert.Equal(t, "Hello TEST", r.GetMessage())
+	require.NoError(t, conn.Close())
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, "http://localhost:60002/", nil)
+	require.NoError(t, err)
+	rsp, err := http.DefaultClient.Do(req)
+	if err == nil {
+		_, err = io.ReadAll(rsp.Body)
+		require.NoError(t, rsp.Body.Close())
+	}
+	require.Error(t, err)
+
+	cnl()
+	require.NoError(t, <-chDone)
+}
+
+func TestWithHTTPHandler(t *testing.T) {
+	comp := new(Component)
+	require.EqualError(t, WithHTTPHandler(nil)(comp), "http handler is nil")
+	require.NoError(t, WithHTTPHandler(http.NotFoundHandler())(comp))
+	assert.NotNil(t, comp.httpHandler)
+}
@@ -0,0 +1,110 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"runtime/debug"
+	"time"
+
+	"github.com/beatlabs/patron/correlation"
+	"github.com/beatlabs/patron/observability/log"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// unaryInterceptor sets up the observability of the unary calls, see observabilityContext, recovers their panics
+// and logs them.
+func unaryInterceptor(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler,
+) (rsp any, err error) {
+	ctx = observabilityContext(ctx)
+	start := time.Now()
+
+	defer func() {
+		if r := recover(); r != nil {
+			err = recoverError(ctx, r)
+		}
+		logCall(ctx, info.FullMethod, start, err)
+	}()
+
+	return handler(ctx, req)
+}
+
+// streamInterceptor sets up the observability of the streaming calls, see observabilityContext, recovers their
+// panics and logs them.
+func streamInterceptor(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler,
+) (err error) {
+	ctx := observabilityContext(ss.Context())
+	start := time.Now()
+
+	defer func() {
+		if r := recover(); r != nil {
+			err = recoverError(ctx, r)
+		}
+		logCall(ctx, info.FullMethod, start, err)
+	}()
+
+	return handler(srv, &serverStream{ServerStream: ss, ctx: ctx})
+}
+
+// observabilityContext returns a context with the correlation ID of the incoming metadata, or a new one if missing,
+// and the logger of the component with the correlation ID.
+func observabilityContext(ctx context.Context) context.Context {
+	var corID string
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if values := md.Get(correlation.HeaderID); len(values) > 0 {
+			corID = values[0]
+		}
+	}
+	if corID == "" {
+		corID = correlation.New()
+	}
+
+	ctx = correlation.ContextWithID(ctx, corID)
+	return log.WithContext(ctx, logger.With(slog.String(correlation.ID, corID)))
+}
+
+func recoverError(ctx context.Context, r any) error {
+	var err error
+	switch x := r.(type) {
+	case string:
+		err = errors.New(x)
+	case error:
+		err = x
+	default:
+		err = fmt.Errorf("unknown panic: %v", x)
+	}
+	log.FromContext(ctx).Error("recovering from a failure", log.ErrorAttr(err), slog.String("stack", string(debug.Stack())))
+	return status.Error(codes.Internal, codes.Internal.String())
+}
+
+func logCall(ctx context.Context, method string, start time.Time, err error) {
+	logger := log.FromContext(ctx)
+	if !logger.Enabled(ctx, slog.LevelDebug) {
+		return
+	}
+
+	attrs := []slog.Attr{
+		slog.String("method", method),
+		slog.String("code", status.Code(err).String()),
+		slog.Duration("duration", time.Since(start)),
+	}
+	if err != nil {
+		attrs = append(attrs, log.ErrorAttr(err))
+	}
+
+	logger.LogAttrs(ctx, slog.LevelDebug, "request log", attrs...)
+}
+
+// serverStream replaces the context of a grpc.ServerStream.
+type serverStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *serverStream) Context() context.Context {
+	return s.ctx
+}
@@ -0,0 +1,136 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/beatlabs/patron/correlation"
+	"github.com/beatlabs/patron/observability/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func TestUnaryInterceptor(t *testing.T) {
+	t.Parallel()
+	info := &grpc.UnaryServerInfo{FullMethod: "/examples.Greeter/SayHello"}
+
+	tests := map[string]struct {
+		md          metadata.MD
+		handlerErr  error
+		panicValue  any
+		expectedID  string
+		expectedErr string
+	}{
+		"correlation id":         {md: metadata.Pairs(correlation.HeaderID, "123"), expectedID: "123"},
+		"missing correlation id": {md: metadata.MD{}},
+		"no metadata":            {},
+		"error": {
+			md: metadata.Pairs(correlation.HeaderID, "123"), handlerErr: errors.New("ERROR"), expectedID: "123",
+			expectedErr: "ERROR",
+		},
+		"panic string": {panicValue: "PANIC", expectedErr: "rpc error: code = Internal desc = Internal"},
+		"panic error":  {panicValue: errors.New("PANIC"), expectedErr: "rpc error: code = Internal desc = Internal"},
+		"panic other":  {panicValue: 1, expectedErr: "rpc error: code = Internal desc = Internal"},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			ctx := context.Background()
+			if tt.md != nil {
+				ctx = metadata.NewIncomingContext(ctx, tt.md)
+			}
+
+			var handlerCtx context.Context
+			rsp, err := unaryInterceptor(ctx, "request", info, func(ctx context.Context, req any) (any, error) {
+				handlerCtx = ctx
+				if tt.panicValue != nil {
+					panic(tt.panicValue)
+				}
+				if tt.handlerErr != nil {
+					return nil, tt.handlerErr
+				}
+				return req, nil
+			})
+
+			assertHandlerContext(t, handlerCtx, tt.expectedID)
+			if tt.expectedErr != "" {
+				require.EqualError(t, err, tt.expectedErr)
+				assert.Nil(t, rsp)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, "request", rsp)
+			}
+			if tt.panicValue != nil {
+				assert.Equal(t, codes.Internal, status.Code(err))
+			}
+		})
+	}
+}
+
+func TestStreamInterceptor(t *testing.T) {
+	t.Parallel()
+	info := &grpc.StreamServerInfo{FullMethod: "/examples.Greeter/SayHelloStream", IsServerStream: true}
+
+	tests := map[string]struct {
+		md          metadata.MD
+		handlerErr  error
+		panicValue  any
+		expectedID  string
+		expectedErr string
+	}{
+		"correlation id":         {md: metadata.Pairs(correlation.HeaderID, "123"), expectedID: "123"},
+		"missing correlation id": {md: metadata.MD{}},
+		"error":                  {handlerErr: errors.New("ERROR"), expectedErr: "ERROR"},
+		"panic":                  {panicValue: "PANIC", expectedErr: "rpc error: code = Internal desc = Internal"},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			ctx := context.Background()
+			if tt.md != nil {
+				ctx = metadata.NewIncomingContext(ctx, tt.md)
+			}
+
+			var handlerCtx context.Context
+			err := streamInterceptor(nil, &testServerStream{ctx: ctx}, info, func(_ any, stream grpc.ServerStream) error {
+				handlerCtx = stream.Context()
+				if tt.panicValue != nil {
+					panic(tt.panicValue)
+				}
+				return tt.handlerErr
+			})
+
+			assertHandlerContext(t, handlerCtx, tt.expectedID)
+			if tt.expectedErr != "" {
+				require.EqualError(t, err, tt.expectedErr)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func assertHandlerContext(t *testing.T, ctx context.Context, expectedID string) {
+	t.Helper()
+	require.NotNil(t, ctx)
+	id := correlation.IDFromContext(ctx)
+	if expectedID != "" {
+		assert.Equal(t, expectedID, id)
+	}
+	assert.Equal(t, id, correlation.IDFromContext(ctx), "the correlation id should be set in the context")
+	assert.NotSame(t, log.FromContext(context.Background()), log.FromContext(ctx))
+}
+
+type testServerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *testServerStream) Context() context.Context {
+	return s.ctx
+}
@@ -2,8 +2,12 @@ package grpc
 
 import (
 	"errors"
+	"net/http"
+	"time"
 
+	"github.com/beatlabs/patron/health"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/keepalive"
 )
 
 // OptionFunc configures the gRPC Component.
@@ -28,3 +32,126 @@ func WithReflection() OptionFunc {
 		return nil
 	}
 }
+
+// WithTLS serves TLS with the given certificate and key files, read again when the component is reloaded.
+func WithTLS(cert, key string) OptionFunc {
+	return func(component *Component) error {
+		if cert == "" || key == "" {
+			return errors.New("cert file or key file was empty")
+		}
+
+		component.certFile = cert
+		component.keyFile = key
+		return nil
+	}
+}
+
+// WithMTLS serves mutual TLS with the given certificate and key files, requiring client certificates
+// signed by the CAs of the client CA file. The files are read again when the component is reloaded.
+// With WithHTTPHandler, the HTTP clients need client certificates as well, see WithHTTPHandler.
+func WithMTLS(cert, key, clientCA string) OptionFunc {
+	return func(component *Component) error {
+		if cert == "" || key == "" || clientCA == "" {
+			return errors.New("cert file, key file or client CA file was empty")
+		}
+
+		component.certFile = cert
+		component.keyFile = key
+		component.clientCAFile = clientCA
+		return nil
+	}
+}
+
+// WithKeepalive sets the keepalive parameters of the server and the policy enforced on the keepalive pings
+// of the clients.
+func WithKeepalive(params keepalive.ServerParameters, policy keepalive.EnforcementPolicy) OptionFunc {
+	return func(component *Component) error {
+		if params.MaxConnectionIdle < 0 || params.MaxConnectionAge < 0 || params.MaxConnectionAgeGrace < 0 ||
+			params.Time < 0 || params.Timeout < 0 || policy.MinTime < 0 {
+			return errors.New("negative keepalive duration provided")
+		}
+
+		component.keepaliveParams = &params
+		component.keepalivePolicy = &policy
+		return nil
+	}
+}
+
+// WithMaxRecvMessageSize sets the maximum size in bytes of the messages received, 4MB by default.
+func WithMaxRecvMessageSize(size int) OptionFunc {
+	return func(component *Component) error {
+		if size <= 0 {
+			return errors.New("negative or zero max receive message size provided")
+		}
+		component.maxRecvMessageSize = size
+		return nil
+	}
+}
+
+// WithMaxSendMessageSize sets the maximum size in bytes of the messages sent, unlimited by default.
+func WithMaxSendMessageSize(size int) OptionFunc {
+	return func(component *Component) error {
+		if size <= 0 {
+			return errors.New("negative or zero max send message size provided")
+		}
+		component.maxSendMessageSize = size
+		return nil
+	}
+}
+
+// WithShutdownGracePeriod sets how long the calls in flight are waited for on shutdown before the server is
+// stopped, closing them, 5s by default.
+func WithShutdownGracePeriod(gp time.Duration) OptionFunc {
+	return func(component *Component) error {
+		if gp <= 0*time.Second {
+			return errors.New("negative or zero shutdown grace period timeout provided")
+		}
+		component.shutdownGracePeriod = gp
+		return nil
+	}
+}
+
+// WithHealthCheck adds a check to the health status of the given service, or of the server and all of its
+// services for an empty service name. The checks are evaluated every second, along with the readiness of
+// the components of the service.
+func WithHealthCheck(service string, check health.Checker) OptionFunc {
+	return func(component *Component) error {
+		if check == nil {
+			return errors.New("health check is nil")
+		}
+		if component.healthChecks == nil {
+			component.healthChecks = make(map[string][]health.Checker)
+		}
+		component.healthChecks[service] = append(component.healthChecks[service], check)
+		return nil
+	}
+}
+
+// WithReadiness reports the server as serving while the components registered in the given registry are ready,
+// e.g. the one of patron.Service.Readiness, instead of the ones of the default registry, see health.Default.
+func WithReadiness(registry *health.Registry) OptionFunc {
+	return func(component *Component) error {
+		if registry == nil {
+			return errors.New("readiness registry is nil")
+		}
+		component.readiness = registry
+		return nil
+	}
+}
+
+// WithHTTPHandler serves the handler, e.g. an HTTP router, on the port of the component along with the gRPC
+// services. Every request is dispatched on its own: the HTTP/2 requests with the application/grpc content type
+// are served by the gRPC server and the rest by the handler, with the read and write timeouts of the HTTP
+// component, see http.LoadServerTimeouts.
+// The gRPC calls are served with grpc.Server.ServeHTTP, which does not support some server options, e.g. keepalive.
+// With WithMTLS, the client certificates are required from the HTTP clients too, e.g. from the probes of the
+// orchestrator, which should be served on another port otherwise.
+func WithHTTPHandler(handler http.Handler) OptionFunc {
+	return func(component *Component) error {
+		if handler == nil {
+			return errors.New("http handler is nil")
+		}
+		component.httpHandler = handler
+		return nil
+	}
+}
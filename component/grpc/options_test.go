@@ -6,7 +6,9 @@ import (
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/keepalive"
 )
 
 func TestGRPCOptions(t *testing.T) {
@@ -53,3 +55,119 @@ func TestReflection(t *testing.T) {
 		})
 	}
 }
+
+func TestTLSOptions(t *testing.T) {
+	tests := map[string]struct {
+		option      OptionFunc
+		expectedCA  string
+		expectedErr string
+	}{
+		"tls":                {option: WithTLS("cert.pem", "key.pem")},
+		"tls missing cert":   {option: WithTLS("", "key.pem"), expectedErr: "cert file or key file was empty"},
+		"tls missing key":    {option: WithTLS("cert.pem", ""), expectedErr: "cert file or key file was empty"},
+		"mtls":               {option: WithMTLS("cert.pem", "key.pem", "ca.pem"), expectedCA: "ca.pem"},
+		"mtls missing ca":    {option: WithMTLS("cert.pem", "key.pem", ""), expectedErr: "cert file, key file or client CA file was empty"},
+		"mtls missing cert":  {option: WithMTLS("", "key.pem", "ca.pem"), expectedErr: "cert file, key file or client CA file was empty"},
+		"mtls missing files": {option: WithMTLS("", "", ""), expectedErr: "cert file, key file or client CA file was empty"},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			comp := new(Component)
+			err := tt.option(comp)
+			if tt.expectedErr != "" {
+				require.EqualError(t, err, tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, "cert.pem", comp.certFile)
+			assert.Equal(t, "key.pem", comp.keyFile)
+			assert.Equal(t, tt.expectedCA, comp.clientCAFile)
+		})
+	}
+}
+
+func TestWithKeepalive(t *testing.T) {
+	tests := map[string]struct {
+		params      keepalive.ServerParameters
+		policy      keepalive.EnforcementPolicy
+		expectedErr string
+	}{
+		"success": {
+			params: keepalive.ServerParameters{MaxConnectionIdle: time.Minute, Time: time.Minute, Timeout: time.Second},
+			policy: keepalive.EnforcementPolicy{MinTime: 10 * time.Second, PermitWithoutStream: true},
+		},
+		"negative parameter": {
+			params:      keepalive.ServerParameters{Time: -time.Second},
+			expectedErr: "negative keepalive duration provided",
+		},
+		"negative policy": {
+			policy:      keepalive.EnforcementPolicy{MinTime: -time.Second},
+			expectedErr: "negative keepalive duration provided",
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			comp := new(Component)
+			err := WithKeepalive(tt.params, tt.policy)(comp)
+			if tt.expectedErr != "" {
+				require.EqualError(t, err, tt.expectedErr)
+				assert.Nil(t, comp.keepaliveParams)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.params, *comp.keepaliveParams)
+			assert.Equal(t, tt.policy, *comp.keepalivePolicy)
+		})
+	}
+}
+
+func TestWithMessageSizes(t *testing.T) {
+	tests := map[string]struct {
+		option       OptionFunc
+		expectedRecv int
+		expectedSend int
+		expectedErr  string
+	}{
+		"receive":          {option: WithMaxRecvMessageSize(1024), expectedRecv: 1024},
+		"zero receive":     {option: WithMaxRecvMessageSize(0), expectedErr: "negative or zero max receive message size provided"},
+		"negative send":    {option: WithMaxSendMessageSize(-1), expectedErr: "negative or zero max send message size provided"},
+		"send":             {option: WithMaxSendMessageSize(2048), expectedSend: 2048},
+		"negative receive": {option: WithMaxRecvMessageSize(-1), expectedErr: "negative or zero max receive message size provided"},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			comp := new(Component)
+			err := tt.option(comp)
+			if tt.expectedErr != "" {
+				require.EqualError(t, err, tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedRecv, comp.maxRecvMessageSize)
+			assert.Equal(t, tt.expectedSend, comp.maxSendMessageSize)
+		})
+	}
+}
+
+func TestWithShutdownGracePeriod(t *testing.T) {
+	tests := map[string]struct {
+		gp          time.Duration
+		expectedErr string
+	}{
+		"success":  {gp: time.Second},
+		"zero":     {gp: 0, expectedErr: "negative or zero shutdown grace period timeout provided"},
+		"negative": {gp: -time.Second, expectedErr: "negative or zero shutdown grace period timeout provided"},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			comp := new(Component)
+			err := WithShutdownGracePeriod(tt.gp)(comp)
+			if tt.expectedErr != "" {
+				require.EqualError(t, err, tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.gp, comp.shutdownGracePeriod)
+		})
+	}
+}
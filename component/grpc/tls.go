@@ -0,0 +1,82 @@
+package grpc
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"os"
+	"sync/atomic"
+)
+
+// certificates of the server, and the client CAs for mTLS, loaded from files and reloaded on Reload.
+// The reloaded certificates apply to the new connections.
+type certificates struct {
+	certFile     string
+	keyFile      string
+	clientCAFile string
+	cert         atomic.Pointer[tls.Certificate]
+	clientCAs    atomic.Pointer[x509.CertPool]
+}
+
+func newCertificates(certFile, keyFile, clientCAFile string) (*certificates, error) {
+	c := &certificates{certFile: certFile, keyFile: keyFile, clientCAFile: clientCAFile}
+	if err := c.load(); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// load reads the files, keeping the previous certificates if any of them fails.
+func (c *certificates) load() error {
+	cert, err := tls.LoadX509KeyPair(c.certFile, c.keyFile)
+	if err != nil {
+		return fmt.Errorf("failed to load certificate: %w", err)
+	}
+
+	var pool *x509.CertPool
+	if c.clientCAFile != "" {
+		pem, err := os.ReadFile(c.clientCAFile)
+		if err != nil {
+			return fmt.Errorf("failed to read client CA: %w", err)
+		}
+		pool = x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return errors.New("failed to parse client CA: no certificates found")
+		}
+	}
+
+	c.cert.Store(&cert)
+	c.clientCAs.Store(pool)
+	return nil
+}
+
+// tlsConfig returns the TLS configuration serving the given application protocols, only HTTP/2 by default.
+func (c *certificates) tlsConfig(protos ...string) *tls.Config {
+	if len(protos) == 0 {
+		protos = []string{"h2"}
+	}
+	cfg := c.connConfig(protos)
+	if c.clientCAFile != "" {
+		// the client CAs are resolved per connection, to apply the reloaded ones.
+		cfg.GetConfigForClient = func(*tls.ClientHelloInfo) (*tls.Config, error) {
+			return c.connConfig(protos), nil
+		}
+	}
+	return cfg
+}
+
+func (c *certificates) connConfig(protos []string) *tls.Config {
+	cfg := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		NextProtos: protos,
+		GetCertificate: func(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+			return c.cert.Load(), nil
+		},
+	}
+	if c.clientCAFile != "" {
+		cfg.ClientAuth = tls.RequireAndVerifyClientCert
+		cfg.ClientCAs = c.clientCAs.Load()
+	}
+	return cfg
+}
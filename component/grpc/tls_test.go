@@ -0,0 +1,216 @@
+package grpc
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/beatlabs/patron/examples"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+)
+
+func TestComponent_TLS(t *testing.T) {
+	dir := t.TempDir()
+	ca := newTestCA(t)
+	certFile, keyFile := ca.writeCert(t, dir, "server", false)
+	clientCertFile, clientKeyFile := ca.writeCert(t, dir, "client", true)
+	caFile := ca.writeCA(t, dir)
+
+	tests := map[string]struct {
+		option      OptionFunc
+		clientCert  bool
+		expectedErr bool
+	}{
+		"tls":                        {option: WithTLS(certFile, keyFile)},
+		"mtls":                       {option: WithMTLS(certFile, keyFile, caFile), clientCert: true},
+		"mtls without a client cert": {option: WithMTLS(certFile, keyFile, caFile), expectedErr: true},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			cmp, err := New(60001, tt.option)
+			require.NoError(t, err)
+			examples.RegisterGreeterServer(cmp.Server(), &server{})
+			ctx, cnl := context.WithCancel(context.Background())
+			chDone := make(chan struct{})
+			go func() {
+				assert.NoError(t, cmp.Run(ctx))
+				close(chDone)
+			}()
+			require.NoError(t, cmp.Ready(ctx))
+
+			tlsCfg := &tls.Config{MinVersion: tls.VersionTLS12, RootCAs: ca.pool()}
+			if tt.clientCert {
+				cert, err := tls.LoadX509KeyPair(clientCertFile, clientKeyFile)
+				require.NoError(t, err)
+				tlsCfg.Certificates = []tls.Certificate{cert}
+			}
+			conn, err := grpc.NewClient("localhost:60001", grpc.WithTransportCredentials(credentials.NewTLS(tlsCfg)))
+			require.NoError(t, err)
+
+			r, err := examples.NewGreeterClient(conn).SayHello(ctx, &examples.HelloRequest{FirstName: "TEST"})
+			if tt.expectedErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, "Hello TEST", r.GetMessage())
+			}
+
+			require.NoError(t, conn.Close())
+			cnl()
+			<-chDone
+		})
+	}
+}
+
+func TestNew_TLSInvalidFiles(t *testing.T) {
+	dir := t.TempDir()
+	ca := newTestCA(t)
+	certFile, keyFile := ca.writeCert(t, dir, "server", false)
+	invalidFile := filepath.Join(dir, "invalid.pem")
+	require.NoError(t, os.WriteFile(invalidFile, []byte("invalid"), 0o600))
+
+	tests := map[string]struct {
+		option      OptionFunc
+		expectedErr string
+	}{
+		"missing cert":    {option: WithTLS(filepath.Join(dir, "missing.pem"), keyFile), expectedErr: "failed to load certificate"},
+		"invalid key":     {option: WithTLS(certFile, invalidFile), expectedErr: "failed to load certificate"},
+		"missing ca":      {option: WithMTLS(certFile, keyFile, filepath.Join(dir, "missing.pem")), expectedErr: "failed to read client CA"},
+		"invalid ca":      {option: WithMTLS(certFile, keyFile, invalidFile), expectedErr: "failed to parse client CA: no certificates found"},
+		"valid cert only": {option: WithTLS(certFile, keyFile)},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			cmp, err := New(60001, tt.option)
+			if tt.expectedErr != "" {
+				require.ErrorContains(t, err, tt.expectedErr)
+				assert.Nil(t, cmp)
+			} else {
+				require.NoError(t, err)
+				assert.NotNil(t, cmp.certs.cert.Load())
+			}
+		})
+	}
+}
+
+func TestComponent_Reload(t *testing.T) {
+	dir := t.TempDir()
+	ca := newTestCA(t)
+	certFile, keyFile := ca.writeCert(t, dir, "server", false)
+	caFile := ca.writeCA(t, dir)
+
+	cmp, err := New(60001, WithMTLS(certFile, keyFile, caFile))
+	require.NoError(t, err)
+	cert := cmp.certs.cert.Load()
+	pool := cmp.certs.clientCAs.Load()
+	tlsCfg := cmp.certs.tlsConfig()
+
+	// new CA and certificate.
+	ca = newTestCA(t)
+	ca.writeCert(t, dir, "server", false)
+	ca.writeCA(t, dir)
+	require.NoError(t, cmp.Reload(context.Background()))
+
+	reloaded := cmp.certs.cert.Load()
+	assert.NotSame(t, cert, reloaded)
+	assert.NotSame(t, pool, cmp.certs.clientCAs.Load())
+	got, err := tlsCfg.GetCertificate(nil)
+	require.NoError(t, err)
+	assert.Same(t, reloaded, got)
+	connCfg, err := tlsCfg.GetConfigForClient(nil)
+	require.NoError(t, err)
+	assert.Same(t, cmp.certs.clientCAs.Load(), connCfg.ClientCAs)
+	assert.Equal(t, tls.RequireAndVerifyClientCert, connCfg.ClientAuth)
+
+	// the previous certificates are kept on failure.
+	require.NoError(t, os.WriteFile(keyFile, []byte("invalid"), 0o600))
+	require.ErrorContains(t, cmp.Reload(context.Background()), "failed to load certificate")
+	assert.Same(t, reloaded, cmp.certs.cert.Load())
+
+	// nothing to reload without TLS.
+	cmp, err = New(60001)
+	require.NoError(t, err)
+	require.NoError(t, cmp.Reload(context.Background()))
+}
+
+type testCA struct {
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+	der  []byte
+}
+
+func newTestCA(t *testing.T) *testCA {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	tmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(time.Now().UnixNano()),
+		Subject:               pkix.Name{CommonName: "test CA"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+	return &testCA{cert: cert, key: key, der: der}
+}
+
+func (ca *testCA) pool() *x509.CertPool {
+	pool := x509.NewCertPool()
+	pool.AddCert(ca.cert)
+	return pool
+}
+
+func (ca *testCA) writeCA(t *testing.T, dir string) string {
+	t.Helper()
+	file := filepath.Join(dir, "ca.pem")
+	require.NoError(t, os.WriteFile(file, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: ca.der}), 0o600))
+	return file
+}
+
+func (ca *testCA) writeCert(t *testing.T, dir, name string, client bool) (string, string) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: name},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		DNSNames:     []string{"localhost"},
+		IPAddresses:  []net.IP{net.IPv4(127, 0, 0, 1)},
+	}
+	if client {
+		tmpl.ExtKeyUsage = []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth}
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, ca.cert, &key.PublicKey, ca.key)
+	require.NoError(t, err)
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+
+	certFile := filepath.Join(dir, name+".pem")
+	keyFile := filepath.Join(dir, name+".key")
+	require.NoError(t, os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0o600))
+	require.NoError(t, os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0o600))
+	return certFile, keyFile
+}
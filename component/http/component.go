@@ -6,11 +6,13 @@ import (
 	"errors"
 	"fmt"
 	"log/slog"
+	"net"
 	"net/http"
-	"os"
-	"strconv"
 	"sync"
+	"sync/atomic"
 	"time"
+
+	"github.com/beatlabs/patron/config"
 )
 
 const (
@@ -20,52 +22,54 @@ const (
 	defaultIdleTimeout         = 240 * time.Second
 	defaultHandlerTimeout      = 59 * time.Second // should be smaller than write timeout
 	defaultShutdownGracePeriod = 5 * time.Second
+	defaultName                = "http"
 )
 
-func port() (int, error) {
-	port, ok := os.LookupEnv("PATRON_HTTP_DEFAULT_PORT")
-	if !ok {
-		slog.Debug("using default port", slog.Int("port", defaultPort))
-		return defaultPort, nil
-	}
-	portVal, err := strconv.ParseInt(port, 10, 32)
-	if err != nil {
-		return 0, fmt.Errorf("env var for HTTP default port is not valid: %w", err)
-	}
-	slog.Debug("using port", slog.Int64("port", portVal))
-	return int(portVal), nil
+// settings of the component loaded from the "http" configuration section and the environment.
+type settings struct {
+	Port         int           `yaml:"port" env:"PATRON_HTTP_DEFAULT_PORT" validate:"min=1,max=65535"`
+	ReadTimeout  time.Duration `yaml:"read_timeout" env:"PATRON_HTTP_READ_TIMEOUT" validate:"min=1ns"`
+	WriteTimeout time.Duration `yaml:"write_timeout" env:"PATRON_HTTP_WRITE_TIMEOUT" validate:"min=1ns"`
 }
 
-func readTimeout() (time.Duration, error) {
-	httpTimeout, ok := os.LookupEnv("PATRON_HTTP_READ_TIMEOUT")
-	if !ok {
-		slog.Debug("using default read timeout", slog.Duration("timeout", defaultReadTimeout))
-		return defaultReadTimeout, nil
+func loadSettings() (settings, error) {
+	s := settings{
+		Port:         defaultPort,
+		ReadTimeout:  defaultReadTimeout,
+		WriteTimeout: defaultWriteTimeout,
 	}
-	timeout, err := time.ParseDuration(httpTimeout)
-	if err != nil {
-		return 0, fmt.Errorf("env var for HTTP read timeout is not valid: %w", err)
+	if err := config.Parse("http", &s); err != nil {
+		return settings{}, err
 	}
-	slog.Debug("using read timeout", slog.Duration("timeout", timeout))
-	return timeout, nil
+	slog.Debug("using HTTP settings", slog.Int("port", s.Port), slog.Duration("read_timeout", s.ReadTimeout),
+		slog.Duration("write_timeout", s.WriteTimeout))
+	return s, nil
 }
 
-func writeTimeout() (time.Duration, error) {
-	httpTimeout, ok := os.LookupEnv("PATRON_HTTP_WRITE_TIMEOUT")
-	if !ok {
-		slog.Debug("using default write timeout", slog.Duration("timeout", defaultWriteTimeout))
-		return defaultWriteTimeout, nil
-	}
-	timeout, err := time.ParseDuration(httpTimeout)
+// ServerTimeouts are the timeouts the HTTP servers are run with.
+type ServerTimeouts struct {
+	// Read is the time to read a request, including its body.
+	Read time.Duration
+	// Write is the time to write the response of a request.
+	Write time.Duration
+	// Idle is the time a connection waits for its next request.
+	Idle time.Duration
+}
+
+// LoadServerTimeouts returns the timeouts of the component, whose read and write timeouts are loaded from the
+// "http" configuration section and the environment, PATRON_HTTP_READ_TIMEOUT and PATRON_HTTP_WRITE_TIMEOUT,
+// so that the HTTP handlers served by other components, e.g. on the port of the gRPC component, share them.
+func LoadServerTimeouts() (ServerTimeouts, error) {
+	s, err := loadSettings()
 	if err != nil {
-		return 0, fmt.Errorf("env var for HTTP write timeout is not valid: %w", err)
+		return ServerTimeouts{}, err
 	}
-	slog.Debug("using write timeout", slog.Duration("timeout", timeout))
-	return timeout, nil
+	return ServerTimeouts{Read: s.ReadTimeout, Write: s.WriteTimeout, Idle: defaultIdleTimeout}, nil
 }
 
 // Component implements an HTTP server with sane defaults and graceful shutdown.
 type Component struct {
+	name                string
 	port                int
 	readTimeout         time.Duration
 	writeTimeout        time.Duration
@@ -75,6 +79,9 @@ type Component struct {
 	mu                  sync.Mutex
 	certFile            string
 	keyFile             string
+	chReady             chan struct{}
+	ready               bool
+	listening           atomic.Bool
 }
 
 // New creates an HTTP Component configurable by functional options.
@@ -83,25 +90,16 @@ func New(handler http.Handler, oo ...OptionFunc) (*Component, error) {
 		return nil, errors.New("handler is nil")
 	}
 
-	port, err := port()
-	if err != nil {
-		return nil, err
-	}
-
-	readTimeout, err := readTimeout()
-	if err != nil {
-		return nil, err
-	}
-
-	writeTimeout, err := writeTimeout()
+	s, err := loadSettings()
 	if err != nil {
 		return nil, err
 	}
 
 	cmp := &Component{
-		port:                port,
-		readTimeout:         readTimeout,
-		writeTimeout:        writeTimeout,
+		name:                defaultName,
+		port:                s.Port,
+		readTimeout:         s.ReadTimeout,
+		writeTimeout:        s.WriteTimeout,
 		shutdownGracePeriod: defaultShutdownGracePeriod,
 		handlerTimeout:      defaultHandlerTimeout,
 		handler:             handler,
@@ -114,20 +112,37 @@ func New(handler http.Handler, oo ...OptionFunc) (*Component, error) {
 		}
 	}
 
+	// the settings are registered once the options are applied, to expose the ones the component runs with.
+	err = config.Register(cmp.name, settings{Port: cmp.port, ReadTimeout: cmp.readTimeout, WriteTimeout: cmp.writeTimeout})
+	if err != nil {
+		return nil, err
+	}
+
 	return cmp, nil
 }
 
 // Run starts the HTTP server and blocks until the context is canceled or the server fails.
 func (c *Component) Run(ctx context.Context) error {
+	listenCfg := &net.ListenConfig{}
+	lis, err := listenCfg.Listen(ctx, "tcp", fmt.Sprintf(":%d", c.port))
+	if err != nil {
+		return fmt.Errorf("failed to listen: %w", err)
+	}
+
 	c.mu.Lock()
 	chFail := make(chan error, 1)
 	srv := c.createHTTPServer()
-	go c.listenAndServe(srv, chFail)
+	go c.serve(srv, lis, chFail)
+	c.listening.Store(true)
+	c.markReady()
 	c.mu.Unlock()
+	defer c.listening.Store(false)
+	defer c.resetReady()
 
 	select {
 	case <-ctx.Done():
 		slog.Info("shutting down HTTP component")
+		c.listening.Store(false)
 		ctx, cancel := context.WithTimeout(context.Background(), c.shutdownGracePeriod)
 		defer cancel()
 		return srv.Shutdown(ctx)
@@ -136,6 +151,49 @@ func (c *Component) Run(ctx context.Context) error {
 	}
 }
 
+// Ready blocks until the HTTP server is listening or the context is done.
+func (c *Component) Ready(ctx context.Context) error {
+	c.mu.Lock()
+	chReady := c.readyChannel()
+	c.mu.Unlock()
+
+	select {
+	case <-chReady:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// IsReady returns true while the HTTP server is listening.
+func (c *Component) IsReady() bool {
+	return c.listening.Load()
+}
+
+func (c *Component) readyChannel() chan struct{} {
+	if c.chReady == nil {
+		c.chReady = make(chan struct{})
+	}
+	return c.chReady
+}
+
+func (c *Component) markReady() {
+	if c.ready {
+		return
+	}
+	close(c.readyChannel())
+	c.ready = true
+}
+
+// resetReady recreates the ready channel once Run returns, so that Ready waits for the listener of the next Run,
+// e.g. when the component is restarted.
+func (c *Component) resetReady() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.chReady = make(chan struct{})
+	c.ready = false
+}
+
 func (c *Component) createHTTPServer() *http.Server {
 	return &http.Server{
 		Addr:         fmt.Sprintf(":%d", c.port),
@@ -146,13 +204,13 @@ func (c *Component) createHTTPServer() *http.Server {
 	}
 }
 
-func (c *Component) listenAndServe(srv *http.Server, ch chan<- error) {
+func (c *Component) serve(srv *http.Server, lis net.Listener, ch chan<- error) {
 	if c.certFile != "" && c.keyFile != "" {
 		slog.Debug("HTTPS component listening", slog.Int("port", c.port))
-		ch <- srv.ListenAndServeTLS(c.certFile, c.keyFile)
+		ch <- srv.ServeTLS(lis, c.certFile, c.keyFile)
 		return
 	}
 
 	slog.Debug("HTTP component listening", slog.Int("port", c.port))
-	ch <- srv.ListenAndServe()
+	ch <- srv.Serve(lis)
 }
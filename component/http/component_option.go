@@ -65,6 +65,17 @@ func WithShutdownGracePeriod(gp time.Duration) OptionFunc {
 	}
 }
 
+// WithName sets the name of the component, under which its effective settings are registered, "http" by default.
+func WithName(name string) OptionFunc {
+	return func(cmp *Component) error {
+		if name == "" {
+			return errors.New("name is empty")
+		}
+		cmp.name = name
+		return nil
+	}
+}
+
 // WithPort overrides the listening port.
 func WithPort(port int) OptionFunc {
 	return func(cmp *Component) error {
@@ -178,3 +178,28 @@ func TestPort(t *testing.T) {
 		})
 	}
 }
+
+func TestName(t *testing.T) {
+	t.Parallel()
+	tests := map[string]struct {
+		name        string
+		expectedErr string
+	}{
+		"success":      {name: "api"},
+		"missing name": {name: "", expectedErr: "name is empty"},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			cmp := &Component{}
+			err := WithName(tt.name)(cmp)
+
+			if tt.expectedErr != "" {
+				require.EqualError(t, err, tt.expectedErr)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tt.name, cmp.name)
+			}
+		})
+	}
+}
@@ -19,7 +19,7 @@ func TestMain(m *testing.M) {
 		goleak.IgnoreTopFunction("google.golang.org/grpc/internal/grpcsync.(*CallbackSerializer).run"),
 		goleak.IgnoreTopFunction("go.opentelemetry.io/otel/sdk/metric.(*PeriodicReader).run"),
 		goleak.IgnoreTopFunction("go.opentelemetry.io/otel/sdk/trace.(*batchSpanProcessor).processQueue"),
-		goleak.IgnoreTopFunction("github.com/beatlabs/patron/component/http.(*Component).listenAndServe"),
+		goleak.IgnoreTopFunction("github.com/beatlabs/patron/component/http.(*Component).serve"),
 	)
 }
 
@@ -46,6 +46,7 @@ func TestNew(t *testing.T) {
 		"success": {
 			args: args{handler: hnd},
 			expected: &Component{
+				name:                defaultName,
 				port:                defaultPort,
 				readTimeout:         defaultReadTimeout,
 				writeTimeout:        defaultWriteTimeout,
@@ -62,6 +63,7 @@ func TestNew(t *testing.T) {
 				writeTimeout: "11s",
 			},
 			expected: &Component{
+				name:                defaultName,
 				port:                8080,
 				readTimeout:         10 * time.Second,
 				writeTimeout:        11 * time.Second,
@@ -75,21 +77,21 @@ func TestNew(t *testing.T) {
 				handler: hnd,
 				port:    "aaa",
 			},
-			expectedErr: `env var for HTTP default port is not valid: strconv.ParseInt: parsing "aaa": invalid syntax`,
+			expectedErr: `http.port: env var PATRON_HTTP_DEFAULT_PORT is not valid: strconv.ParseInt: parsing "aaa": invalid syntax`,
 		},
 		"failure, read timeout env vars": {
 			args: args{
 				handler:     hnd,
 				readTimeout: "aaa",
 			},
-			expectedErr: `env var for HTTP read timeout is not valid: time: invalid duration "aaa"`,
+			expectedErr: `http.read_timeout: env var PATRON_HTTP_READ_TIMEOUT is not valid: time: invalid duration "aaa"`,
 		},
 		"failure, write timeout env vars": {
 			args: args{
 				handler:      hnd,
 				writeTimeout: "aaa",
 			},
-			expectedErr: `env var for HTTP write timeout is not valid: time: invalid duration "aaa"`,
+			expectedErr: `http.write_timeout: env var PATRON_HTTP_WRITE_TIMEOUT is not valid: time: invalid duration "aaa"`,
 		},
 		"missing handler": {
 			args:        args{handler: nil},
@@ -126,6 +128,45 @@ func TestNew(t *testing.T) {
 	}
 }
 
+func TestLoadServerTimeouts(t *testing.T) {
+	tests := map[string]struct {
+		readTimeout  string
+		writeTimeout string
+		expected     ServerTimeouts
+		expectedErr  string
+	}{
+		"defaults": {
+			expected: ServerTimeouts{Read: defaultReadTimeout, Write: defaultWriteTimeout, Idle: defaultIdleTimeout},
+		},
+		"environment": {
+			readTimeout: "10s", writeTimeout: "20s",
+			expected: ServerTimeouts{Read: 10 * time.Second, Write: 20 * time.Second, Idle: defaultIdleTimeout},
+		},
+		"invalid": {
+			readTimeout: "-10s",
+			expectedErr: "http.read_timeout: must be at least 1ns",
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			if tt.readTimeout != "" {
+				t.Setenv("PATRON_HTTP_READ_TIMEOUT", tt.readTimeout)
+			}
+			if tt.writeTimeout != "" {
+				t.Setenv("PATRON_HTTP_WRITE_TIMEOUT", tt.writeTimeout)
+			}
+
+			got, err := LoadServerTimeouts()
+			if tt.expectedErr != "" {
+				require.EqualError(t, err, tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, got)
+		})
+	}
+}
+
 func TestComponent_ListenAndServe_DefaultRoutes_Shutdown(t *testing.T) {
 	listenCfg := &net.ListenConfig{}
 	listener, err := listenCfg.Listen(context.Background(), "tcp", ":0") //nolint:gosec
@@ -151,4 +192,58 @@ func TestComponent_ListenAndServe_DefaultRoutes_Shutdown(t *testing.T) {
 	require.NoError(t, rsp.Body.Close())
 	cnl()
 	assert.True(t, <-done)
+
+}
+
+func TestComponent_Ready(t *testing.T) {
+	listenCfg := &net.ListenConfig{}
+	listener, err := listenCfg.Listen(context.Background(), "tcp", ":0") //nolint:gosec
+	require.NoError(t, err)
+	port, ok := listener.Addr().(*net.TCPAddr)
+	assert.True(t, ok)
+	require.NoError(t, listener.Close())
+
+	cmp, err := New(&stubHandler{}, WithPort(port.Port))
+	require.NoError(t, err)
+
+	notReadyCtx, notReadyCnl := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer notReadyCnl()
+	require.ErrorIs(t, cmp.Ready(notReadyCtx), context.DeadlineExceeded)
+
+	done := make(chan bool)
+	ctx, cnl := context.WithCancel(context.Background())
+	go func() {
+		assert.NoError(t, cmp.Run(ctx))
+		done <- true
+	}()
+
+	readyCtx, readyCnl := context.WithTimeout(context.Background(), time.Second)
+	defer readyCnl()
+	require.NoError(t, cmp.Ready(readyCtx))
+
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, fmt.Sprintf("http://localhost:%d/", port.Port), nil)
+	require.NoError(t, err)
+	rsp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rsp.StatusCode)
+	require.NoError(t, rsp.Body.Close())
+	cnl()
+	assert.True(t, <-done)
+
+	// a restarted component is ready once it listens again.
+	notReadyCtx, notReadyCnl = context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer notReadyCnl()
+	require.ErrorIs(t, cmp.Ready(notReadyCtx), context.DeadlineExceeded)
+	assert.False(t, cmp.IsReady())
+
+	ctx, cnl = context.WithCancel(context.Background())
+	go func() {
+		assert.NoError(t, cmp.Run(ctx))
+		done <- true
+	}()
+	readyCtx, readyCnl = context.WithTimeout(context.Background(), time.Second)
+	defer readyCnl()
+	require.NoError(t, cmp.Ready(readyCtx))
+	cnl()
+	assert.True(t, <-done)
 }
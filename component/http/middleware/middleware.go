@@ -21,6 +21,7 @@ import (
 	"github.com/beatlabs/patron/correlation"
 	"github.com/beatlabs/patron/encoding"
 	"github.com/beatlabs/patron/observability/log"
+	"github.com/beatlabs/patron/observability/redact"
 	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
 	"golang.org/x/time/rate"
 )
@@ -34,6 +35,10 @@ const (
 	appNameHeader    = "X-App-Name"
 )
 
+// logger of the middlewares, whose level can be changed at runtime through the "http.router" name along with the
+// router. It is the logger of the requests as well, see NewInjectObservability.
+var logger = log.Named("http.router")
+
 type responseWriter struct {
 	status              int
 	statusHeaderWritten bool
@@ -100,7 +105,7 @@ func NewRecovery() Func {
 					default:
 						err = errors.New("unknown panic")
 					}
-					slog.Error("recovering from a failure", log.ErrorAttr(err), slog.String("stack", string(debug.Stack())))
+					logger.Error("recovering from a failure", log.ErrorAttr(err), slog.String("stack", string(debug.Stack())))
 					http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
 				}
 			}()
@@ -160,26 +165,47 @@ func NewLoggingTracing(path string, statusCodeLogger StatusCodeLoggerHandler) (F
 
 			otelhttp.NewMiddleware(path)(next).ServeHTTP(lw, r)
 			logRequestResponse(corID, lw, r)
-			if log.Enabled(slog.LevelError) && statusCodeLogger.shouldLog(lw.status) {
-				log.FromContext(r.Context()).Error("failed route execution", slog.String("path", path),
-					slog.Int("status", lw.status), slog.String("payload", lw.responsePayload.String()))
+			if statusCodeLogger.shouldLog(lw.status) {
+				logger.ErrorContext(r.Context(), "failed route execution", slog.String(correlation.ID, corID), slog.String("path", path),
+					slog.Int("status", lw.status), slog.String("payload", redact.Default().Payload(lw.responsePayload.String())))
 			}
 		})
 	}, nil
 }
 
-// NewInjectObservability injects a correlation ID unless one is already present.
+// NewInjectObservability injects a correlation ID unless one is already present, and the logger of the request
+// with the correlation ID.
 func NewInjectObservability() Func {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			corID := getOrSetCorrelationID(r.Header)
 			ctx := correlation.ContextWithID(r.Context(), corID)
-			ctx = log.WithContext(ctx, slog.With(slog.String(correlation.ID, corID)))
+			ctx = log.WithContext(ctx, logger.With(slog.String(correlation.ID, corID)))
 			next.ServeHTTP(w, r.WithContext(ctx))
 		})
 	}
 }
 
+// NewLogLevelOverride creates a Func that sets the log level requested by the request with the log.LevelHeader,
+// if allowed by the override, in the context of the request. See log.LevelOverride.
+func NewLogLevelOverride(override *log.LevelOverride) (Func, error) {
+	if override == nil {
+		return nil, errors.New("log level override cannot be nil")
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			value := r.Header.Get(log.LevelHeader)
+			if value == "" {
+				next.ServeHTTP(w, r)
+				return
+			}
+			ctx := override.Context(r.Context(), value, r.Header.Get(log.LevelSignatureHeader))
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}, nil
+}
+
 func getOrSetCorrelationID(h http.Header) string {
 	cor, ok := h[correlation.HeaderID]
 	if !ok {
@@ -209,7 +235,7 @@ func NewRateLimiting(limiter *rate.Limiter) (Func, error) {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			if !limiter.Allow() {
-				slog.Debug("limiting requests...")
+				logger.Debug("limiting requests...")
 				http.Error(w, "Requests greater than limit", http.StatusTooManyRequests)
 				return
 			}
@@ -319,7 +345,7 @@ func NewCompression(deflateLevel int, ignoreRoutes ...string) (Func, error) {
 			hdr := r.Header.Get(encoding.AcceptEncodingHeader)
 			selectedEncoding, err := parseAcceptEncoding(hdr)
 			if err != nil {
-				slog.Debug("encoding is not supported in compression middleware, "+
+				logger.Debug("encoding is not supported in compression middleware, "+
 					"and client doesn't accept anything else", slog.String("header", hdr))
 				http.Error(w, http.StatusText(http.StatusNotAcceptable), http.StatusNotAcceptable)
 				return
@@ -332,9 +358,9 @@ func NewCompression(deflateLevel int, ignoreRoutes ...string) (Func, error) {
 				if err != nil {
 					msgErr := "error in deferred call to Close() method on compression middleware"
 					if isErrConnectionReset(err) {
-						slog.Info(msgErr, slog.String("header", hdr), log.ErrorAttr(err))
+						logger.Info(msgErr, slog.String("header", hdr), log.ErrorAttr(err))
 					} else {
-						slog.Error(msgErr, slog.String("header", hdr), log.ErrorAttr(err))
+						logger.Error(msgErr, slog.String("header", hdr), log.ErrorAttr(err))
 					}
 				}
 			}(dw)
@@ -459,7 +485,7 @@ func NewCaching(rc *cache.RouteCache) (Func, error) {
 			}
 			err := cache.Handler(w, r, rc, next)
 			if err != nil {
-				slog.Error("error encountered in the caching middleware", log.ErrorAttr(err))
+				logger.Error("error encountered in the caching middleware", log.ErrorAttr(err))
 				return
 			}
 		})
@@ -475,7 +501,7 @@ func Chain(f http.Handler, mm ...Func) http.Handler {
 }
 
 func logRequestResponse(corID string, w *responseWriter, r *http.Request) {
-	if !log.Enabled(slog.LevelDebug) {
+	if !logger.Enabled(r.Context(), slog.LevelDebug) {
 		return
 	}
 
@@ -491,9 +517,10 @@ func logRequestResponse(corID string, w *responseWriter, r *http.Request) {
 		slog.Int("status", w.Status()),
 		slog.String("remote-address", remoteAddr),
 		slog.String("proto", r.Proto),
+		slog.Any("headers", redact.Default().Headers(r.Header)),
 	}
 
-	log.FromContext(r.Context()).LogAttrs(r.Context(), slog.LevelDebug, "request log", attrs...)
+	logger.LogAttrs(r.Context(), slog.LevelDebug, "request log", attrs...)
 }
 
 // stripQueryString returns a path without the query string.
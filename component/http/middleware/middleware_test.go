@@ -1,15 +1,19 @@
 package middleware
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
+	"time"
 
 	httpcache "github.com/beatlabs/patron/component/http/cache"
 	"github.com/beatlabs/patron/correlation"
+	"github.com/beatlabs/patron/observability/log"
 	"github.com/beatlabs/patron/observability/trace"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -169,6 +173,86 @@ func TestNewLoggingTracing(t *testing.T) {
 	}
 }
 
+func TestNewLoggingTracing_Redaction(t *testing.T) {
+	out := &bytes.Buffer{}
+	require.NoError(t, log.Setup(&log.Config{IsJSON: true, Level: "debug", Output: out}))
+	t.Cleanup(func() { require.NoError(t, log.Setup(&log.Config{Level: "info"})) })
+
+	statusCodeLogger, err := NewStatusCodeLoggerHandler("500")
+	require.NoError(t, err)
+	loggingTracingMiddleware, err := NewLoggingTracing("/index", statusCodeLogger)
+	require.NoError(t, err)
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+		_, err := w.Write([]byte("invalid header Bearer s3cr3t"))
+		assert.NoError(t, err)
+	})
+
+	r, err := http.NewRequestWithContext(context.Background(), http.MethodGet, "/index", nil)
+	require.NoError(t, err)
+	r.Header.Set("Authorization", "Basic s3cr3t")
+	r.Header.Set("Accept", "application/json")
+
+	Chain(handler, loggingTracingMiddleware).ServeHTTP(httptest.NewRecorder(), r)
+
+	assert.NotContains(t, out.String(), "s3cr3t")
+	assert.Contains(t, out.String(), `"payload":"invalid header [REDACTED]"`)
+	assert.Contains(t, out.String(), `"Authorization":["[REDACTED]"]`)
+	assert.Contains(t, out.String(), `"Accept":["application/json"]`)
+}
+
+func TestNewLogLevelOverride(t *testing.T) {
+	_, err := NewLogLevelOverride(nil)
+	require.EqualError(t, err, "log level override cannot be nil")
+
+	out := &bytes.Buffer{}
+	require.NoError(t, log.Setup(&log.Config{IsJSON: true, Level: "info", Output: out}))
+	t.Cleanup(func() { require.NoError(t, log.Setup(&log.Config{Level: "info"})) })
+
+	override, err := log.NewLevelOverride(log.LevelOverrideConfig{Levels: []string{"debug"}, Secret: []byte("secret")})
+	require.NoError(t, err)
+	logLevelMiddleware, err := NewLogLevelOverride(override)
+	require.NoError(t, err)
+	loggingTracingMiddleware, err := NewLoggingTracing("/index", StatusCodeLoggerHandler{})
+	require.NoError(t, err)
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		log.FromContext(r.Context()).Debug("handler debug")
+		w.WriteHeader(http.StatusOK)
+	})
+
+	tests := map[string]struct {
+		signature string
+		expected  bool
+	}{
+		"signed":     {signature: log.SignLevel([]byte("secret"), "debug", "cor-1", time.Now().Add(time.Minute)), expected: true},
+		"not signed": {},
+		"expired":    {signature: log.SignLevel([]byte("secret"), "debug", "cor-1", time.Now().Add(-time.Minute))},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			out.Reset()
+			r, err := http.NewRequestWithContext(context.Background(), http.MethodGet, "/index", nil)
+			require.NoError(t, err)
+			r.Header.Set(correlation.HeaderID, "cor-1")
+			r.Header.Set(log.LevelHeader, "debug")
+			r.Header.Set(log.LevelSignatureHeader, tt.signature)
+
+			Chain(handler, NewInjectObservability(), logLevelMiddleware, loggingTracingMiddleware).ServeHTTP(httptest.NewRecorder(), r)
+
+			if tt.expected {
+				assert.Contains(t, out.String(), `"msg":"handler debug"`)
+				assert.Contains(t, out.String(), `"msg":"request log"`)
+				// both the request and the handler log through the logger of the router.
+				assert.Equal(t, 2, strings.Count(out.String(), `"logger":"http.router"`))
+			} else {
+				assert.Empty(t, out.String())
+			}
+		})
+	}
+}
+
 // TestSpanLogError tests whether an HTTP handler with a tracing middleware adds a log event in case of we return an error.
 func TestSpanLogError(t *testing.T) {
 	// Setup tracing
@@ -1,13 +1,19 @@
 package http
 
 import (
+	"encoding/json"
 	"expvar"
 	"fmt"
 	"net/http"
 	"net/http/pprof"
+	"runtime"
+	"runtime/debug"
+	"time"
 
 	patronhttp "github.com/beatlabs/patron/component/http/middleware"
+	"github.com/beatlabs/patron/config"
 	"github.com/beatlabs/patron/observability/log"
+	patronmetric "github.com/beatlabs/patron/observability/metric"
 )
 
 func ProfilingRoutes(enableExpVar bool, middlewares ...patronhttp.Func) []*Route {
@@ -52,24 +58,180 @@ func expVars(w http.ResponseWriter, _ *http.Request) {
 	_, _ = fmt.Fprintf(w, "\n}\n")
 }
 
-// LoggingRoutes returns a routes relates to logs.
+// LogLevels describes the level of the logs and of the named loggers.
+type LogLevels struct {
+	Level   string        `json:"level"`
+	Loggers []LoggerLevel `json:"loggers"`
+}
+
+// LoggerLevel describes the level of a named logger. The level set for all the loggers has an empty name.
+type LoggerLevel struct {
+	Name      string     `json:"name"`
+	Level     string     `json:"level"`
+	Set       bool       `json:"set,omitempty"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+}
+
+// LoggingRoutes returns the routes related to logs:
+//   - GET /debug/log lists the level of the logs and of the named loggers.
+//   - POST /debug/log/{level} sets the level of the logs. With a ttl query parameter, e.g. ?ttl=10m,
+//     the level applies to all the loggers until the TTL elapses.
+//   - POST /debug/log/{logger}/{level} sets the level of a named logger, e.g. kafka, optionally with a ttl query parameter.
+//   - DELETE /debug/log/{logger} removes the level set for a named logger.
 func LoggingRoutes(middlewares ...patronhttp.Func) []*Route {
-	handler := func(w http.ResponseWriter, r *http.Request) {
-		lvl := r.PathValue("level")
-		if lvl == "" {
-			http.Error(w, "missing log level", http.StatusBadRequest)
-			return
+	routeFunc := func(path string, handler http.HandlerFunc) *Route {
+		route, _ := NewRoute(path, handler)
+		route.middlewares = append(route.middlewares, middlewares...)
+		return route
+	}
+
+	return []*Route{
+		routeFunc("GET /debug/log", logLevels),
+		routeFunc("POST /debug/log/{level}", setLogLevel),
+		routeFunc("POST /debug/log/{logger}/{level}", setLoggerLevel),
+		routeFunc("DELETE /debug/log/{logger}", resetLoggerLevel),
+	}
+}
+
+func logLevels(w http.ResponseWriter, _ *http.Request) {
+	lvl, ll := log.Levels()
+
+	levels := LogLevels{Level: lvl.String(), Loggers: make([]LoggerLevel, 0, len(ll))}
+	for _, l := range ll {
+		logger := LoggerLevel{Name: l.Name, Level: l.Level.String(), Set: l.Set}
+		if !l.ExpiresAt.IsZero() {
+			logger.ExpiresAt = &l.ExpiresAt
 		}
+		levels.Loggers = append(levels.Loggers, logger)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(levels)
+}
+
+func setLogLevel(w http.ResponseWriter, r *http.Request) {
+	lvl := r.PathValue("level")
+	if lvl == "" {
+		http.Error(w, "missing log level", http.StatusBadRequest)
+		return
+	}
+
+	ttl, err := levelTTL(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if ttl > 0 {
+		err = log.SetLoggerLevel("", lvl, ttl)
+	} else {
+		err = log.SetLevel(lvl)
+		if err == nil {
+			// a level set with a TTL no longer applies.
+			log.ResetLoggerLevel("")
+		}
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+func setLoggerLevel(w http.ResponseWriter, r *http.Request) {
+	ttl, err := levelTTL(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	err = log.SetLoggerLevel(r.PathValue("logger"), r.PathValue("level"), ttl)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+func resetLoggerLevel(w http.ResponseWriter, r *http.Request) {
+	log.ResetLoggerLevel(r.PathValue("logger"))
+	w.WriteHeader(http.StatusOK)
+}
+
+func levelTTL(r *http.Request) (time.Duration, error) {
+	val := r.URL.Query().Get("ttl")
+	if val == "" {
+		return 0, nil
+	}
+
+	ttl, err := time.ParseDuration(val)
+	if err != nil {
+		return 0, fmt.Errorf("invalid ttl %q: %w", val, err)
+	}
+	if ttl <= 0 {
+		return 0, fmt.Errorf("invalid ttl %q: must be positive", val)
+	}
+	return ttl, nil
+}
+
+// BuildInfo describes the service and the binary it runs from.
+type BuildInfo struct {
+	Name      string `json:"name"`
+	Version   string `json:"version"`
+	GoVersion string `json:"go_version"`
+	Module    string `json:"module,omitempty"`
+	Revision  string `json:"revision,omitempty"`
+	BuildTime string `json:"build_time,omitempty"`
+	Modified  bool   `json:"modified,omitempty"`
+}
 
-		err := log.SetLevel(lvl)
-		if err != nil {
-			http.Error(w, err.Error(), http.StatusBadRequest)
-			return
+// BuildInfoRoutes returns a route exposing the name and version of the service and the build info of the binary.
+func BuildInfoRoutes(name, version string, middlewares ...patronhttp.Func) []*Route {
+	info := BuildInfo{
+		Name:      name,
+		Version:   version,
+		GoVersion: runtime.Version(),
+	}
+
+	if bi, ok := debug.ReadBuildInfo(); ok {
+		info.Module = bi.Main.Path
+		for _, setting := range bi.Settings {
+			switch setting.Key {
+			case "vcs.revision":
+				info.Revision = setting.Value
+			case "vcs.time":
+				info.BuildTime = setting.Value
+			case "vcs.modified":
+				info.Modified = setting.Value == "true"
+			}
 		}
-		w.WriteHeader(http.StatusOK)
 	}
 
-	route, _ := NewRoute("POST /debug/log/{level}", handler)
+	handler := func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(info)
+	}
+
+	route, _ := NewRoute("GET /debug/info", handler)
+	route.middlewares = append(route.middlewares, middlewares...)
+	return []*Route{route}
+}
+
+// ConfigRoutes returns a route exposing the effective configuration loaded through the config package, with secrets redacted.
+func ConfigRoutes(middlewares ...patronhttp.Func) []*Route {
+	handler := func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(config.Effective())
+	}
+
+	route, _ := NewRoute("GET /debug/config", handler)
+	route.middlewares = append(route.middlewares, middlewares...)
+	return []*Route{route}
+}
+
+// MetricsRoutes returns a route exposing the metrics to Prometheus, when exported with the Prometheus exporter.
+func MetricsRoutes(middlewares ...patronhttp.Func) []*Route {
+	route, _ := NewRoute("GET /metrics", patronmetric.PrometheusHandler().ServeHTTP)
 	route.middlewares = append(route.middlewares, middlewares...)
 	return []*Route{route}
 }
@@ -2,10 +2,13 @@ package http
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"runtime"
 	"testing"
+	"time"
 
 	patronhttp "github.com/beatlabs/patron/component/http/middleware"
 	"github.com/beatlabs/patron/observability/log"
@@ -120,6 +123,94 @@ func TestLoggingRoutes(t *testing.T) {
 		assert.Equal(t, http.StatusNotFound, resp.StatusCode)
 		require.NoError(t, resp.Body.Close())
 	})
+
+	t.Run("change logger level with ttl", func(t *testing.T) {
+		t.Cleanup(func() { log.ResetLoggerLevel("kafka") })
+
+		req, err := http.NewRequestWithContext(context.Background(), http.MethodPost, server.URL+"/debug/log/kafka/warn?ttl=10m", nil)
+		require.NoError(t, err)
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		require.NoError(t, resp.Body.Close())
+
+		// the logger of the middlewares is listed along with the one the level is set for.
+		levels := getLogLevels(t, server.URL)
+		assert.Equal(t, "DEBUG", levels.Level)
+		require.Len(t, levels.Loggers, 2)
+		assert.Equal(t, "http.router", levels.Loggers[0].Name)
+		assert.Equal(t, "DEBUG", levels.Loggers[0].Level)
+		assert.False(t, levels.Loggers[0].Set)
+		assert.Equal(t, "kafka", levels.Loggers[1].Name)
+		assert.Equal(t, "WARN", levels.Loggers[1].Level)
+		assert.True(t, levels.Loggers[1].Set)
+		require.NotNil(t, levels.Loggers[1].ExpiresAt)
+		assert.WithinDuration(t, time.Now().Add(10*time.Minute), *levels.Loggers[1].ExpiresAt, time.Minute)
+
+		req, err = http.NewRequestWithContext(context.Background(), http.MethodDelete, server.URL+"/debug/log/kafka", nil)
+		require.NoError(t, err)
+		resp, err = http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		require.NoError(t, resp.Body.Close())
+
+		levels = getLogLevels(t, server.URL)
+		require.Len(t, levels.Loggers, 1)
+		assert.Equal(t, "http.router", levels.Loggers[0].Name)
+	})
+
+	t.Run("change log level with ttl", func(t *testing.T) {
+		t.Cleanup(func() { log.ResetLoggerLevel("") })
+
+		req, err := http.NewRequestWithContext(context.Background(), http.MethodPost, server.URL+"/debug/log/error?ttl=1m", nil)
+		require.NoError(t, err)
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		require.NoError(t, resp.Body.Close())
+
+		levels := getLogLevels(t, server.URL)
+		assert.Equal(t, "DEBUG", levels.Level)
+		require.Len(t, levels.Loggers, 2)
+		assert.Empty(t, levels.Loggers[0].Name)
+		assert.Equal(t, "ERROR", levels.Loggers[0].Level)
+	})
+
+	t.Run("wrong ttl", func(t *testing.T) {
+		for _, ttl := range []string{"xxx", "-1m", "0s"} {
+			req, err := http.NewRequestWithContext(context.Background(), http.MethodPost, server.URL+"/debug/log/kafka/debug?ttl="+ttl, nil)
+			require.NoError(t, err)
+			resp, err := http.DefaultClient.Do(req)
+			require.NoError(t, err)
+			assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+			require.NoError(t, resp.Body.Close())
+		}
+	})
+
+	t.Run("wrong logger level", func(t *testing.T) {
+		req, err := http.NewRequestWithContext(context.Background(), http.MethodPost, server.URL+"/debug/log/kafka/xxx", nil)
+		require.NoError(t, err)
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+		require.NoError(t, resp.Body.Close())
+	})
+}
+
+func getLogLevels(t *testing.T, url string) LogLevels {
+	t.Helper()
+
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, url+"/debug/log", nil)
+	require.NoError(t, err)
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer func() { require.NoError(t, resp.Body.Close()) }()
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "application/json", resp.Header.Get("Content-Type"))
+	var levels LogLevels
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&levels))
+	return levels
 }
 
 func TestProfilingRoutesWithMiddleware(t *testing.T) {
@@ -165,3 +256,66 @@ func TestLoggingRoutesWithMiddleware(t *testing.T) {
 	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
 	require.NoError(t, resp.Body.Close())
 }
+
+func TestBuildInfoRoutes(t *testing.T) {
+	mux := http.NewServeMux()
+	for _, route := range BuildInfoRoutes("name", "1.0.0") {
+		mux.Handle(route.path, patronhttp.Chain(route.handler, route.middlewares...))
+	}
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, server.URL+"/debug/info", nil)
+	require.NoError(t, err)
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer func() { require.NoError(t, resp.Body.Close()) }()
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "application/json", resp.Header.Get("Content-Type"))
+	var info BuildInfo
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&info))
+	assert.Equal(t, "name", info.Name)
+	assert.Equal(t, "1.0.0", info.Version)
+	assert.Equal(t, runtime.Version(), info.GoVersion)
+}
+
+func TestConfigRoutes(t *testing.T) {
+	t.Setenv("PATRON_HTTP_DEFAULT_PORT", "50123")
+	_, err := New(&stubHandler{})
+	require.NoError(t, err)
+	_, err = New(&stubHandler{}, WithName("admin"), WithPort(50124))
+	require.NoError(t, err)
+
+	mux := http.NewServeMux()
+	for _, route := range ConfigRoutes() {
+		mux.Handle(route.path, patronhttp.Chain(route.handler, route.middlewares...))
+	}
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, server.URL+"/debug/config", nil)
+	require.NoError(t, err)
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer func() { require.NoError(t, resp.Body.Close()) }()
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "application/json", resp.Header.Get("Content-Type"))
+	var effective map[string]map[string]any
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&effective))
+	assert.InDelta(t, 50123, effective["http"]["port"], 0)
+	assert.Equal(t, "30s", effective["http"]["read_timeout"])
+	assert.InDelta(t, 50124, effective["admin"]["port"], 0)
+}
+
+func TestMetricsRoutes(t *testing.T) {
+	routes := MetricsRoutes()
+	require.Len(t, routes, 1)
+	assert.Equal(t, "GET /metrics", routes[0].path)
+
+	// the metrics are not exported with the Prometheus exporter.
+	rec := httptest.NewRecorder()
+	routes[0].handler(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
@@ -4,17 +4,27 @@ import (
 	"fmt"
 	"log/slog"
 	"net/http"
-	"os"
 
 	patronhttp "github.com/beatlabs/patron/component/http"
 	"github.com/beatlabs/patron/component/http/middleware"
+	"github.com/beatlabs/patron/config"
+	"github.com/beatlabs/patron/health"
+	"github.com/beatlabs/patron/observability/log"
 )
 
 const defaultDeflateLevel = 6
 
+// logger of the router, whose level can be changed at runtime through the "http.router" name.
+var logger = log.Named("http.router")
+
 // OptionFunc definition to allow functional configuration of the router.
 type OptionFunc func(*Config) error
 
+// settings of the router loaded from the "http.router" configuration section and the environment.
+type settings struct {
+	StatusErrorLogging string `yaml:"status_error_logging" env:"PATRON_HTTP_STATUS_ERROR_LOGGING"`
+}
+
 // Config definition.
 type Config struct {
 	aliveCheckFunc           patronhttp.LivenessCheckFunc
@@ -26,6 +36,10 @@ type Config struct {
 	enableProfilingExpVar    bool
 	profilingMiddlewares     []middleware.Func
 	appNameVersionMiddleware middleware.Func
+	logLevelMiddleware       middleware.Func
+	// prometheus metrics
+	enablePrometheusMetrics      bool
+	prometheusMetricsMiddlewares []middleware.Func
 }
 
 // New creates an http router with functional options.
@@ -49,6 +63,9 @@ func New(oo ...OptionFunc) (*http.ServeMux, error) {
 	if cfg.enableProfiling {
 		stdRoutes = append(stdRoutes, patronhttp.ProfilingRoutes(cfg.enableProfilingExpVar, cfg.profilingMiddlewares...)...)
 	}
+	if cfg.enablePrometheusMetrics {
+		stdRoutes = append(stdRoutes, patronhttp.MetricsRoutes(cfg.prometheusMetricsMiddlewares...)...)
+	}
 
 	route, err := patronhttp.LivenessCheckRoute(cfg.aliveCheckFunc)
 	if err != nil {
@@ -56,7 +73,7 @@ func New(oo ...OptionFunc) (*http.ServeMux, error) {
 	}
 	stdRoutes = append(stdRoutes, route)
 
-	route, err = patronhttp.ReadyCheckRoute(cfg.readyCheckFunc)
+	route, err = patronhttp.ReadyCheckRoute(aggregateReadyCheck(cfg.readyCheckFunc))
 	if err != nil {
 		return nil, err
 	}
@@ -73,18 +90,25 @@ func New(oo ...OptionFunc) (*http.ServeMux, error) {
 		middlewares = append(middlewares, route.Middlewares()...)
 		handler := middleware.Chain(route.Handler(), middlewares...)
 		mux.Handle(route.Path(), handler)
-		slog.Debug("added route", slog.Any("route", route))
+		logger.Debug("added route", slog.Any("route", route))
+	}
+
+	st := settings{}
+	if err := config.Load("http.router", &st); err != nil {
+		return nil, err
 	}
 
 	// parse a list of HTTP numeric status codes that must be logged
-	statusCodeLoggerCfg, _ := os.LookupEnv("PATRON_HTTP_STATUS_ERROR_LOGGING")
-	statusCodeLogger, err := middleware.NewStatusCodeLoggerHandler(statusCodeLoggerCfg)
+	statusCodeLogger, err := middleware.NewStatusCodeLoggerHandler(st.StatusErrorLogging)
 	if err != nil {
-		return nil, fmt.Errorf("failed to parse status codes %s: %w", statusCodeLoggerCfg, err)
+		return nil, fmt.Errorf("failed to parse status codes %s: %w", st.StatusErrorLogging, err)
 	}
 
 	// add to the default middlewares the observability we need per route.
 	stdMiddlewares = append(stdMiddlewares, middleware.NewInjectObservability())
+	if cfg.logLevelMiddleware != nil {
+		stdMiddlewares = append(stdMiddlewares, cfg.logLevelMiddleware)
+	}
 
 	for _, route := range cfg.routes {
 		var middlewares []middleware.Func
@@ -107,8 +131,19 @@ func New(oo ...OptionFunc) (*http.ServeMux, error) {
 		// chain all middlewares to the handler
 		handler := middleware.Chain(route.Handler(), middlewares...)
 		mux.Handle(route.Path(), handler)
-		slog.Debug("added route with middlewares", slog.Any("route", route), slog.Int("middlewares", len(middlewares)))
+		logger.Debug("added route with middlewares", slog.Any("route", route), slog.Int("middlewares", len(middlewares)))
 	}
 
 	return mux, nil
 }
+
+// aggregateReadyCheck reports ready only when the components of the service and the provided check are ready.
+// The registry is resolved on every check, since the router may be created before the service.
+func aggregateReadyCheck(rcf patronhttp.ReadyCheckFunc) patronhttp.ReadyCheckFunc {
+	return func() patronhttp.ReadyStatus {
+		if !health.Default().Ready() {
+			return patronhttp.NotReady
+		}
+		return rcf()
+	}
+}
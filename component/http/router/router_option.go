@@ -5,6 +5,7 @@ import (
 
 	patronhttp "github.com/beatlabs/patron/component/http"
 	"github.com/beatlabs/patron/component/http/middleware"
+	"github.com/beatlabs/patron/observability/log"
 )
 
 // WithRoutes option for providing routes to the router.
@@ -30,6 +31,7 @@ func WithAliveCheck(acf patronhttp.LivenessCheckFunc) OptionFunc {
 }
 
 // WithReadyCheck option for the router.
+// The check is combined with the readiness of the components run by the service.
 func WithReadyCheck(rcf patronhttp.ReadyCheckFunc) OptionFunc {
 	return func(cfg *Config) error {
 		if rcf == nil {
@@ -93,6 +95,16 @@ func WithExpVarProfiling() OptionFunc {
 	}
 }
 
+// WithPrometheusMetrics enables the GET /metrics route, serving the metrics to Prometheus
+// when the service exports them with the Prometheus exporter.
+func WithPrometheusMetrics(mm ...middleware.Func) OptionFunc {
+	return func(cfg *Config) error {
+		cfg.enablePrometheusMetrics = true
+		cfg.prometheusMetricsMiddlewares = append(cfg.prometheusMetricsMiddlewares, mm...)
+		return nil
+	}
+}
+
 // WithAppNameHeaders option for adding name and version header to the response.
 func WithAppNameHeaders(name, version string) (OptionFunc, error) {
 	appNameVersionMiddleware, err := middleware.NewAppNameVersion(name, version)
@@ -104,3 +116,16 @@ func WithAppNameHeaders(name, version string) (OptionFunc, error) {
 		return nil
 	}, nil
 }
+
+// WithLogLevelOverride option for logging a request at the level it requests with the log.LevelHeader, e.g. debug,
+// if allowed by the configuration.
+func WithLogLevelOverride(cfg log.LevelOverrideConfig) OptionFunc {
+	return func(c *Config) error {
+		override, err := log.NewLevelOverride(cfg)
+		if err != nil {
+			return err
+		}
+		c.logLevelMiddleware, err = middleware.NewLogLevelOverride(override)
+		return err
+	}
+}
@@ -6,6 +6,7 @@ import (
 
 	patronhttp "github.com/beatlabs/patron/component/http"
 	"github.com/beatlabs/patron/component/http/middleware"
+	"github.com/beatlabs/patron/observability/log"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -163,6 +164,16 @@ func TestDisableProfiling(t *testing.T) {
 	assert.True(t, cfg.enableProfilingExpVar)
 }
 
+func TestWithPrometheusMetrics(t *testing.T) {
+	t.Parallel()
+	mw := func(next http.Handler) http.Handler { return next }
+	cfg := &Config{}
+	err := WithPrometheusMetrics(mw)(cfg)
+	require.NoError(t, err)
+	assert.True(t, cfg.enablePrometheusMetrics)
+	assert.Len(t, cfg.prometheusMetricsMiddlewares, 1)
+}
+
 func TestEnableAppNameHeaders(t *testing.T) {
 	type args struct {
 		name    string
@@ -194,3 +205,27 @@ func TestEnableAppNameHeaders(t *testing.T) {
 		})
 	}
 }
+
+func TestWithLogLevelOverride(t *testing.T) {
+	t.Parallel()
+	tests := map[string]struct {
+		cfg         log.LevelOverrideConfig
+		expectedErr string
+	}{
+		"success":   {cfg: log.LevelOverrideConfig{Levels: []string{"debug"}}},
+		"no levels": {cfg: log.LevelOverrideConfig{}, expectedErr: "no log levels allowed"},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			cfg := &Config{}
+			err := WithLogLevelOverride(tt.cfg)(cfg)
+			if tt.expectedErr != "" {
+				require.EqualError(t, err, tt.expectedErr)
+			} else {
+				require.NoError(t, err)
+				assert.NotNil(t, cfg.logLevelMiddleware)
+			}
+		})
+	}
+}
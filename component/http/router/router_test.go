@@ -7,6 +7,7 @@ import (
 	"testing"
 
 	patronhttp "github.com/beatlabs/patron/component/http"
+	"github.com/beatlabs/patron/health"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -162,3 +163,39 @@ func TestProfilingMiddlewaresOption(t *testing.T) {
 	err = WithProfilingMiddlewares()(cfg)
 	assert.EqualError(t, err, "middlewares are empty")
 }
+
+func TestReadyCheckAggregatesRegistry(t *testing.T) {
+	original := health.Default()
+	t.Cleanup(func() { health.SetDefault(original) })
+	registry := health.NewRegistry()
+	health.SetDefault(registry)
+
+	customReady := patronhttp.Ready
+	router, err := New(WithReadyCheck(func() patronhttp.ReadyStatus { return customReady }))
+	require.NoError(t, err)
+
+	srv := httptest.NewServer(router)
+	defer srv.Close()
+
+	assertReadyStatus := func(t *testing.T, expected int) {
+		req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, srv.URL+"/ready", nil)
+		require.NoError(t, err)
+		rsp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		assert.Equal(t, expected, rsp.StatusCode)
+		require.NoError(t, rsp.Body.Close())
+	}
+
+	registry.Register("kafka", nil)
+	assertReadyStatus(t, http.StatusServiceUnavailable)
+
+	registry.SetStarted("kafka", true)
+	assertReadyStatus(t, http.StatusOK)
+
+	customReady = patronhttp.NotReady
+	assertReadyStatus(t, http.StatusServiceUnavailable)
+
+	customReady = patronhttp.Ready
+	registry.Shutdown()
+	assertReadyStatus(t, http.StatusServiceUnavailable)
+}
@@ -7,11 +7,14 @@ import (
 	"fmt"
 	"log/slog"
 	"sync"
+	"sync/atomic"
 	"time"
 
+	"github.com/beatlabs/patron/config"
 	"github.com/beatlabs/patron/correlation"
 	"github.com/beatlabs/patron/internal/validation"
 	"github.com/beatlabs/patron/observability/log"
+	"github.com/beatlabs/patron/observability/redact"
 	patrontrace "github.com/beatlabs/patron/observability/trace"
 	"github.com/google/uuid"
 	"github.com/twmb/franz-go/pkg/kgo"
@@ -36,6 +39,9 @@ const (
 // Each record's own Context (rec.Context) carries per-record trace/log/correlation context.
 type ProcessorFunc func(ctx context.Context, records []*kgo.Record) error
 
+// logger of the component, whose level can be changed at runtime through the "kafka" name.
+var logger = log.Named("kafka")
+
 type recordSpan struct {
 	record *kgo.Record
 	span   trace.Span
@@ -80,6 +86,11 @@ func New(name, group string, brokers, topics []string, proc ProcessorFunc, opts
 		return nil, errors.Join(errs...)
 	}
 
+	st := settings{BatchSize: defaultBatchSize}
+	if err := config.Load(configName(name), &st); err != nil {
+		return nil, err
+	}
+
 	cmp := &Component{
 		name:         name,
 		group:        group,
@@ -88,7 +99,7 @@ func New(name, group string, brokers, topics []string, proc ProcessorFunc, opts
 		proc:         proc,
 		retries:      defaultRetries,
 		retryWait:    defaultRetryWait,
-		batchSize:    defaultBatchSize,
+		batchSize:    st.BatchSize,
 		batchTimeout: defaultBatchTimeout,
 		failStrategy: defaultFailureStrategy,
 		opts:         opts,
@@ -104,6 +115,15 @@ func New(name, group string, brokers, topics []string, proc ProcessorFunc, opts
 	return cmp, nil
 }
 
+// settings of the component loaded from the "kafka.<name>" configuration section.
+type settings struct {
+	BatchSize uint `yaml:"batch_size" validate:"min=1"`
+}
+
+func configName(name string) string {
+	return "kafka." + name
+}
+
 // Component is a kafka consumer implementation that processes messages in batch.
 type Component struct {
 	name                      string
@@ -114,12 +134,49 @@ type Component struct {
 	proc                      ProcessorFunc
 	failStrategy              FailStrategy
 	batchSize                 uint
+	batchSizeSet              bool
 	batchTimeout              time.Duration
 	batchMessageDeduplication bool
 	retries                   uint32
 	retryWait                 time.Duration
 	manualCommit              bool
 	sessionCallback           func() error
+	logLevel                  *log.LevelOverride
+	handler                   atomic.Pointer[consumerHandler]
+	mu                        sync.Mutex
+}
+
+// Reload applies the batch size of the "kafka.<name>" configuration section to the running consumer,
+// unless it is set with WithBatchSize, which takes precedence as in New.
+func (c *Component) Reload(_ context.Context) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.batchSizeSet {
+		return nil
+	}
+
+	st := settings{BatchSize: c.batchSize}
+	if err := config.Load(configName(c.name), &st); err != nil {
+		return err
+	}
+
+	if st.BatchSize != c.batchSize {
+		logger.Info("kafka batch size reloaded", slog.String("component", c.name),
+			slog.Uint64("from", uint64(c.batchSize)), slog.Uint64("to", uint64(st.BatchSize)))
+	}
+	c.batchSize = st.BatchSize
+	if handler := c.handler.Load(); handler != nil {
+		handler.setBatchSize(st.BatchSize)
+	}
+	return nil
+}
+
+// IsReady returns true once the consumer joined the group and was assigned its partitions, until the partitions
+// are lost or a poll returns fetch errors.
+func (c *Component) IsReady() bool {
+	handler := c.handler.Load()
+	return handler != nil && handler.connected.Load()
 }
 
 // Run starts the consumer processing loop to process messages from Kafka.
@@ -131,6 +188,7 @@ func (c *Component) Run(ctx context.Context) error {
 
 func (c *Component) processing(ctx context.Context) error {
 	var componentError error
+	defer c.handler.Store(nil)
 
 	retries := c.retries
 	for i := uint32(0); i <= retries; i++ {
@@ -138,15 +196,19 @@ func (c *Component) processing(ctx context.Context) error {
 		meter := kotel.NewMeter(kotel.MeterProvider(otel.GetMeterProvider()))
 		kotelService := kotel.NewKotel(kotel.WithTracer(tracer), kotel.WithMeter(meter))
 
+		c.mu.Lock()
 		handler := newConsumerHandler(ctx, c.name, c.group, tracer, c.proc, c.failStrategy, c.batchSize,
 			c.batchTimeout, c.manualCommit, c.batchMessageDeduplication)
+		handler.logLevel = c.logLevel
+		c.handler.Store(handler)
+		c.mu.Unlock()
 
 		opts := []kgo.Opt{
 			kgo.SeedBrokers(c.brokers...),
 			kgo.ConsumerGroup(c.group),
 			kgo.ConsumeTopics(c.topics...),
 			kgo.WithHooks(kotelService.Hooks()...),
-			kgo.WithLogger(kslog.New(slog.Default())),
+			kgo.WithLogger(kslog.New(logger)),
 		}
 
 		if c.manualCommit {
@@ -155,31 +217,40 @@ func (c *Component) processing(ctx context.Context) error {
 			opts = append(opts, kgo.AutoCommitMarks())
 		}
 
-		if c.sessionCallback != nil {
-			opts = append(opts, kgo.OnPartitionsAssigned(func(context.Context, *kgo.Client, map[string][]int32) {
+		opts = append(opts,
+			kgo.OnPartitionsAssigned(func(context.Context, *kgo.Client, map[string][]int32) {
+				// the consumer is ready once it joined the group, even if there are no records to fetch.
+				handler.connected.Store(true)
+				if c.sessionCallback == nil {
+					return
+				}
 				err := c.sessionCallback()
 				if err != nil {
-					slog.Error("error executing session callback", log.ErrorAttr(err))
+					logger.Error("error executing session callback", log.ErrorAttr(err))
 					handler.setErr(err)
 				}
-			}))
-		}
+			}),
+			kgo.OnPartitionsLost(func(context.Context, *kgo.Client, map[string][]int32) {
+				handler.connected.Store(false)
+			}),
+		)
 
 		opts = append(opts, c.opts...)
 
 		cl, err := kgo.NewClient(opts...)
 		componentError = err
 		if err != nil {
-			slog.Error("error creating kafka consumer client", log.ErrorAttr(err))
+			logger.Error("error creating kafka consumer client", log.ErrorAttr(err))
 		}
 
 		if cl != nil {
-			slog.Debug("consuming messages", slog.Any("topics", c.topics), slog.String("group", c.group))
+			logger.Debug("consuming messages", slog.Any("topics", c.topics), slog.String("group", c.group))
 
 			err = handler.consume(ctx, cl)
+			handler.connected.Store(false)
 			componentError = err
 			if err != nil {
-				slog.Error("failure from kafka consumer", log.ErrorAttr(err))
+				logger.Error("failure from kafka consumer", log.ErrorAttr(err))
 			}
 
 			if handler.getErr() != nil && componentError == nil {
@@ -199,7 +270,7 @@ func (c *Component) processing(ctx context.Context) error {
 		}
 
 		if shouldRetry {
-			slog.Error("failed run", slog.Uint64("current", uint64(i)), slog.Uint64("retries", uint64(c.retries)),
+			logger.Error("failed run", slog.Uint64("current", uint64(i)), slog.Uint64("retries", uint64(c.retries)),
 				slog.Duration("wait", c.retryWait), log.ErrorAttr(componentError))
 			select {
 			case <-time.After(c.retryWait):
@@ -255,6 +326,9 @@ type consumerHandler struct {
 	// committing manually after every batch
 	manualCommit bool
 
+	// log level requested by the records
+	logLevel *log.LevelOverride
+
 	// lock to protect buffer operation
 	mu     sync.RWMutex
 	recBuf []*kgo.Record
@@ -264,6 +338,9 @@ type consumerHandler struct {
 
 	// whether the handler has processed any messages
 	processedMessages bool
+
+	// whether the consumer joined the group and the last poll completed without fetch errors
+	connected atomic.Bool
 }
 
 func newConsumerHandler(ctx context.Context, name, group string, kotelTracer *kotel.Tracer,
@@ -292,7 +369,7 @@ func (c *consumerHandler) consume(ctx context.Context, cl *kgo.Client) error {
 	for {
 		if ctx.Err() != nil {
 			if !errors.Is(ctx.Err(), context.Canceled) {
-				slog.Info("closing consumer", log.ErrorAttr(ctx.Err()))
+				logger.Info("closing consumer", log.ErrorAttr(ctx.Err()))
 			}
 
 			if err := c.flush(cl); err != nil {
@@ -307,16 +384,23 @@ func (c *consumerHandler) consume(ctx context.Context, cl *kgo.Client) error {
 			return nil
 		}
 
+		fetchFailed := false
 		for _, fetchErr := range fetches.Errors() {
 			if !errors.Is(fetchErr.Err, context.Canceled) {
-				slog.Error("fetch error", slog.String("topic", fetchErr.Topic),
+				fetchFailed = true
+				logger.Error("fetch error", slog.String("topic", fetchErr.Topic),
 					slog.Int("partition", int(fetchErr.Partition)), log.ErrorAttr(fetchErr.Err))
 			}
 		}
+		if fetchFailed {
+			c.connected.Store(false)
+		} else if len(fetches) > 0 {
+			c.connected.Store(true)
+		}
 
 		fetches.EachPartition(func(ftp kgo.FetchTopicPartition) {
 			for _, rec := range ftp.Records {
-				slog.Debug("message claimed", slog.String("value", string(rec.Value)),
+				logger.Debug("message claimed", slog.String("value", redact.Default().Payload(string(rec.Value))),
 					slog.Time("timestamp", rec.Timestamp), slog.String("topic", rec.Topic))
 				topicPartitionOffsetDiffGaugeSet(c.ctx, c.group, rec.Topic, rec.Partition, ftp.HighWatermark, rec.Offset)
 				messageStatusCountInc(c.ctx, messageReceived, c.group, rec.Topic)
@@ -422,7 +506,7 @@ func (c *consumerHandler) executeFailureStrategy(recordSpans []recordSpan, err e
 			rs.span.End()
 			messageStatusCountInc(rs.record.Context, messageErrored, c.group, rs.record.Topic)
 		}
-		slog.Error("could not process message(s)")
+		logger.Error("could not process message(s)")
 		c.setErr(err)
 		return err
 	case SkipStrategy:
@@ -432,9 +516,9 @@ func (c *consumerHandler) executeFailureStrategy(recordSpans []recordSpan, err e
 			messageStatusCountInc(rs.record.Context, messageErrored, c.group, rs.record.Topic)
 			messageStatusCountInc(rs.record.Context, messageSkipped, c.group, rs.record.Topic)
 		}
-		slog.Error("could not process message(s) so skipping with error", log.ErrorAttr(err))
+		logger.Error("could not process message(s) so skipping with error", log.ErrorAttr(err))
 	default:
-		slog.Error("unknown failure strategy executed")
+		logger.Error("unknown failure strategy executed")
 		return fmt.Errorf("unknown failure strategy: %v", c.failStrategy)
 	}
 
@@ -450,7 +534,10 @@ func (c *consumerHandler) getContextWithCorrelation(rec *kgo.Record) (context.Co
 	ctx, sp := c.kotelTracer.WithProcessSpan(rec)
 
 	ctx = correlation.ContextWithID(ctx, corID)
-	ctx = log.WithContext(ctx, slog.With(slog.String(correlation.ID, corID)))
+	ctx = log.WithContext(ctx, logger.With(slog.String(correlation.ID, corID)))
+	if c.logLevel != nil {
+		ctx = c.logLevel.Context(ctx, getHeader(rec.Headers, log.LevelHeader), getHeader(rec.Headers, log.LevelSignatureHeader))
+	}
 	return ctx, sp
 }
 
@@ -460,6 +547,12 @@ func (c *consumerHandler) allowRebalance(cl *kgo.Client) {
 	}
 }
 
+func (c *consumerHandler) setBatchSize(size uint) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.batchSize = size
+}
+
 func (c *consumerHandler) setErr(err error) {
 	c.mu.Lock()
 	defer c.mu.Unlock()
@@ -484,10 +577,19 @@ func getCorrelationID(hh []kgo.RecordHeader) string {
 		}
 	}
 
-	slog.Debug("correlation header not found, creating new correlation UUID")
+	logger.Debug("correlation header not found, creating new correlation UUID")
 	return uuid.New().String()
 }
 
+func getHeader(hh []kgo.RecordHeader, key string) string {
+	for _, h := range hh {
+		if h.Key == key {
+			return string(h.Value)
+		}
+	}
+	return ""
+}
+
 // deduplicateRecords takes a slice of records and de-duplicates based on the Key of those records.
 // This function assumes that records are ordered from old to new, and relies on Kafka ordering guarantees within
 // partitions. This is the default behaviour from Kafka unless the Producer altered the partition hashing behaviour in
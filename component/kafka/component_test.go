@@ -5,12 +5,16 @@ package kafka
 import (
 	"context"
 	"errors"
+	"log/slog"
 	"os"
+	"path/filepath"
 	"sync"
 	"testing"
 	"time"
 
+	"github.com/beatlabs/patron/config"
 	"github.com/beatlabs/patron/correlation"
+	"github.com/beatlabs/patron/observability/log"
 	patrontrace "github.com/beatlabs/patron/observability/trace"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
@@ -147,6 +151,44 @@ func TestNew(t *testing.T) {
 	}
 }
 
+func TestComponent_Reload(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(path, []byte("kafka:\n  reload:\n    batch_size: 10\n"), 0o600))
+
+	tests := map[string]struct {
+		options           []OptionFunc
+		expectedBatchSize uint
+	}{
+		"configured batch size":     {expectedBatchSize: 10},
+		"batch size set explicitly": {options: []OptionFunc{WithBatchSize(5)}, expectedBatchSize: 5},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			require.NoError(t, config.SetFiles())
+
+			proc := mockProcessor{}
+			cmp, err := New("reload", "grp", []string{"localhost:9092"}, []string{"topic"}, proc.Process, nil, tt.options...)
+			require.NoError(t, err)
+			initial := cmp.batchSize
+
+			handler := newConsumerHandler(context.Background(), "reload", "grp", nil, proc.Process, ExitStrategy, initial,
+				time.Second, false, false)
+			defer handler.ticker.Stop()
+			cmp.handler.Store(handler)
+
+			require.NoError(t, cmp.Reload(context.Background()))
+			assert.Equal(t, initial, cmp.batchSize)
+
+			require.NoError(t, config.SetFiles(path))
+			t.Cleanup(func() { require.NoError(t, config.SetFiles()) })
+
+			require.NoError(t, cmp.Reload(context.Background()))
+			assert.Equal(t, tt.expectedBatchSize, cmp.batchSize)
+			assert.Equal(t, tt.expectedBatchSize, handler.batchSize)
+		})
+	}
+}
+
 type mockProcessor struct {
 	errReturn bool
 	mux       sync.Mutex
@@ -483,6 +525,29 @@ func Test_getCorrelationID(t *testing.T) {
 	})
 }
 
+func TestConsumerHandler_getContextWithCorrelation_LogLevel(t *testing.T) {
+	override, err := log.NewLevelOverride(log.LevelOverrideConfig{Levels: []string{"debug"}})
+	require.NoError(t, err)
+
+	handler := newConsumerHandler(context.Background(), "test", "grp", kotel.NewTracer(), nil, ExitStrategy, 1,
+		time.Millisecond, false, false)
+	defer handler.ticker.Stop()
+
+	rec := &kgo.Record{Topic: "test", Context: context.Background(), Headers: []kgo.RecordHeader{{Key: log.LevelHeader, Value: []byte("debug")}}}
+
+	ctx, sp := handler.getContextWithCorrelation(rec)
+	sp.End()
+	_, ok := log.LevelFromContext(ctx)
+	assert.False(t, ok)
+
+	handler.logLevel = override
+	ctx, sp = handler.getContextWithCorrelation(rec)
+	sp.End()
+	lvl, ok := log.LevelFromContext(ctx)
+	assert.True(t, ok)
+	assert.Equal(t, slog.LevelDebug, lvl)
+}
+
 func Test_deduplicateRecords(t *testing.T) {
 	original := []*kgo.Record{
 		{Key: []byte("k1"), Value: []byte("v1.1")},
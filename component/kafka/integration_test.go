@@ -59,6 +59,7 @@ const (
 	successTopic2        = "successTopic2"
 	failAllRetriesTopic2 = "failAllRetriesTopic2"
 	failAndRetryTopic2   = "failAndRetryTopic2"
+	emptyTopic           = "emptyTopic"
 	broker               = "127.0.0.1:9092"
 )
 
@@ -315,6 +316,29 @@ func TestKafkaComponent_FailOnceAndRetry(t *testing.T) {
 	}
 }
 
+func TestKafkaComponent_ReadyOnEmptyTopic(t *testing.T) {
+	require.NoError(t, createTopics(broker, emptyTopic))
+
+	processorFunc := func(_ context.Context, _ []*kgo.Record) error {
+		return nil
+	}
+	component := newComponent(t, emptyTopic, uniqueGroup(emptyTopic), 0, 1, processorFunc)
+	assert.False(t, component.IsReady())
+
+	ctx, cnl := context.WithCancel(context.Background())
+	chDone := make(chan error)
+	go func() {
+		chDone <- component.Run(ctx)
+	}()
+
+	// no records are fetched, the consumer is ready once it joined the group.
+	assert.Eventually(t, component.IsReady, 30*time.Second, 100*time.Millisecond)
+
+	cnl()
+	require.NoError(t, <-chDone)
+	assert.False(t, component.IsReady())
+}
+
 func TestGroupConsume_CheckTopicFailsDueToNonExistingTopic(t *testing.T) {
 	// Test parameters
 	processorFunc := func(_ context.Context, _ []*kgo.Record) error {
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/beatlabs/patron/observability/log"
 	"github.com/twmb/franz-go/pkg/kgo"
 	"github.com/twmb/franz-go/pkg/kmsg"
 )
@@ -103,6 +104,7 @@ func WithBatchSize(size uint) OptionFunc {
 			return errors.New("zero batch size provided")
 		}
 		c.batchSize = size
+		c.batchSizeSet = true
 		return nil
 	}
 }
@@ -150,3 +152,16 @@ func WithNewSessionCallback(sessionCallback func() error) OptionFunc {
 		return nil
 	}
 }
+
+// WithLogLevelOverride processes a record at the log level it requests with the log.LevelHeader header, e.g. debug,
+// if allowed by the configuration.
+func WithLogLevelOverride(cfg log.LevelOverrideConfig) OptionFunc {
+	return func(c *Component) error {
+		override, err := log.NewLevelOverride(cfg)
+		if err != nil {
+			return err
+		}
+		c.logLevel = override
+		return nil
+	}
+}
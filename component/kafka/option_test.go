@@ -4,6 +4,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/beatlabs/patron/observability/log"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -196,3 +197,10 @@ func TestManualCommit(t *testing.T) {
 	require.NoError(t, err)
 	assert.True(t, c.manualCommit)
 }
+
+func TestWithLogLevelOverride(t *testing.T) {
+	c := &Component{}
+	require.EqualError(t, WithLogLevelOverride(log.LevelOverrideConfig{})(c), "no log levels allowed")
+	require.NoError(t, WithLogLevelOverride(log.LevelOverrideConfig{Levels: []string{"debug"}})(c))
+	assert.NotNil(t, c.logLevel)
+}
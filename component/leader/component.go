@@ -0,0 +1,260 @@
+// Package leader provides a component electing a single leader among the replicas of a service.
+package leader
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/beatlabs/patron/observability/log"
+	"github.com/google/uuid"
+)
+
+const (
+	defaultLeaseDuration = 15 * time.Second
+	defaultRenewInterval = 5 * time.Second
+	releaseTimeout       = 5 * time.Second
+)
+
+// Lock is a lease backend shared by the candidates of an election.
+type Lock interface {
+	// Acquire acquires the lease for the candidate, or renews it if the candidate already holds it,
+	// and returns the current holder of the lease.
+	Acquire(ctx context.Context, candidate string, ttl time.Duration) (string, error)
+	// Release releases the lease if it is held by the candidate.
+	Release(ctx context.Context, candidate string) error
+}
+
+// Component takes part in an election and runs the elected callback while it holds the lease.
+type Component struct {
+	name          string
+	id            string
+	instance      string
+	lock          Lock
+	leaseDuration time.Duration
+	renewInterval time.Duration
+	onElected     func(ctx context.Context)
+	onRevoked     func()
+
+	mu        sync.Mutex
+	leaderCtx context.Context
+	cancel    context.CancelFunc
+	renewed   time.Time
+	wg        sync.WaitGroup
+	// electedDone is closed once the elected callback of the last term returns.
+	electedDone chan struct{}
+
+	holder    atomic.Value
+	connected atomic.Bool
+}
+
+// New creates a new leader election component with support for functional configuration.
+// The default lease duration is 15s and the lease is renewed every 5s.
+// The candidate ID defaults to the hostname followed by a random suffix.
+func New(name string, lock Lock, oo ...OptionFunc) (*Component, error) {
+	if name == "" {
+		return nil, errors.New("name is required")
+	}
+
+	if lock == nil {
+		return nil, errors.New("lock is nil")
+	}
+
+	hostname, err := os.Hostname()
+	if err != nil {
+		hostname = "host"
+	}
+
+	leaderCtx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	cmp := &Component{
+		name:          name,
+		id:            hostname + "-" + uuid.NewString(),
+		instance:      hostname,
+		lock:          lock,
+		leaseDuration: defaultLeaseDuration,
+		renewInterval: defaultRenewInterval,
+		leaderCtx:     leaderCtx,
+		cancel:        cancel,
+	}
+
+	for _, optionFunc := range oo {
+		err := optionFunc(cmp)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if cmp.renewInterval >= cmp.leaseDuration {
+		return nil, errors.New("renew interval should be less than the lease duration")
+	}
+
+	return cmp, nil
+}
+
+// ID returns the candidate ID of the component.
+func (c *Component) ID() string {
+	return c.id
+}
+
+// IsLeader returns true while the component holds the lease.
+func (c *Component) IsLeader() bool {
+	return c.Context().Err() == nil
+}
+
+// Leader returns the candidate ID of the current leader, as last seen by the component.
+func (c *Component) Leader() string {
+	holder, _ := c.holder.Load().(string)
+	return holder
+}
+
+// Context returns a context that is canceled when the leadership is lost.
+// The context is already canceled while the component is not the leader.
+func (c *Component) Context() context.Context {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.leaderCtx
+}
+
+// IsReady returns true while the lock backend is reachable, whether the component is the leader or not,
+// so that the candidates which are not the leader keep serving. The current leader is reported by Leader,
+// the leader.status metric and the logs instead.
+func (c *Component) IsReady() bool {
+	return c.connected.Load()
+}
+
+// Run takes part in the election until the context is canceled, releasing the lease if held.
+func (c *Component) Run(ctx context.Context) error {
+	slog.Debug("joining election", slog.String("election", c.name), slog.String("id", c.id))
+
+	ticker := time.NewTicker(c.renewInterval)
+	defer ticker.Stop()
+
+	for {
+		c.campaign(ctx)
+
+		select {
+		case <-ctx.Done():
+			c.resign(ctx)
+			c.wg.Wait()
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+func (c *Component) campaign(ctx context.Context) {
+	holder, err := c.lock.Acquire(ctx, c.id, c.leaseDuration)
+	if err != nil {
+		if ctx.Err() != nil {
+			return
+		}
+		c.connected.Store(false)
+		slog.Error("failed to acquire leader lease", slog.String("election", c.name), log.ErrorAttr(err))
+		// the lease might expire before the next attempt, so leadership is given up in time.
+		if c.IsLeader() && time.Since(c.renewedAt()) >= c.leaseDuration-c.renewInterval {
+			c.revoke(ctx, fmt.Errorf("lease not renewed: %w", err))
+		}
+		return
+	}
+
+	c.connected.Store(true)
+	if previous := c.Leader(); previous != holder {
+		slog.Info("leader changed", slog.String("election", c.name), slog.String("id", c.id),
+			slog.String("leader", holder), slog.String("previous_leader", previous))
+	}
+	c.holder.Store(holder)
+
+	if holder != c.id {
+		if c.IsLeader() {
+			c.revoke(ctx, fmt.Errorf("lease acquired by %s", holder))
+		}
+		leaderStatusRecord(ctx, c.name, c.instance, false)
+		return
+	}
+
+	c.mu.Lock()
+	c.renewed = time.Now()
+	c.mu.Unlock()
+
+	if !c.IsLeader() {
+		c.elect(ctx)
+	}
+	leaderStatusRecord(ctx, c.name, c.instance, true)
+}
+
+func (c *Component) renewedAt() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.renewed
+}
+
+func (c *Component) elect(ctx context.Context) {
+	// the leadership context is detached from the run context, so that it is canceled only after the lease is given up.
+	leaderCtx, cancel := context.WithCancel(context.WithoutCancel(ctx))
+	c.mu.Lock()
+	c.leaderCtx = leaderCtx
+	c.cancel = cancel
+	c.mu.Unlock()
+
+	leaderTransitionInc(ctx, c.name, true)
+	slog.Info("leadership acquired", slog.String("election", c.name), slog.String("id", c.id),
+		slog.String("leader", c.id))
+
+	if c.onElected == nil {
+		return
+	}
+
+	// the callbacks of consecutive terms run one after the other, the one of a term lost while waiting is skipped.
+	previous := c.electedDone
+	done := make(chan struct{})
+	c.electedDone = done
+	c.wg.Add(1)
+	go func() {
+		defer c.wg.Done()
+		defer close(done)
+		if previous != nil {
+			<-previous
+		}
+		if leaderCtx.Err() != nil {
+			return
+		}
+		c.onElected(leaderCtx)
+	}()
+}
+
+func (c *Component) revoke(ctx context.Context, reason error) {
+	c.mu.Lock()
+	c.cancel()
+	c.mu.Unlock()
+
+	leaderTransitionInc(ctx, c.name, false)
+	leaderStatusRecord(ctx, c.name, c.instance, false)
+	slog.Warn("leadership lost", slog.String("election", c.name), slog.String("id", c.id),
+		slog.String("leader", c.Leader()), log.ErrorAttr(reason))
+
+	if c.onRevoked != nil {
+		c.onRevoked()
+	}
+}
+
+// resign gives up the leadership, if held, and releases the lease so that another candidate can take over.
+func (c *Component) resign(ctx context.Context) {
+	if !c.IsLeader() {
+		return
+	}
+
+	c.revoke(ctx, ctx.Err())
+
+	releaseCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), releaseTimeout)
+	defer cancel()
+	if err := c.lock.Release(releaseCtx, c.id); err != nil {
+		slog.Error("failed to release leader lease", slog.String("election", c.name), log.ErrorAttr(err))
+	}
+}
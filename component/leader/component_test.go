@@ -0,0 +1,247 @@
+package leader
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/goleak"
+)
+
+func TestMain(m *testing.M) {
+	goleak.VerifyTestMain(m,
+		goleak.IgnoreTopFunction("go.opencensus.io/stats/view.(*worker).start"),
+		goleak.IgnoreTopFunction("google.golang.org/grpc/internal/grpcsync.(*CallbackSerializer).run"),
+		goleak.IgnoreTopFunction("go.opentelemetry.io/otel/sdk/metric.(*PeriodicReader).run"),
+		goleak.IgnoreTopFunction("go.opentelemetry.io/otel/sdk/trace.(*batchSpanProcessor).processQueue"),
+	)
+}
+
+type memLock struct {
+	mu        sync.Mutex
+	holder    string
+	expiresAt time.Time
+	err       error
+}
+
+func (l *memLock) Acquire(_ context.Context, candidate string, ttl time.Duration) (string, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.err != nil {
+		return "", l.err
+	}
+	if l.holder == "" || l.holder == candidate || time.Now().After(l.expiresAt) {
+		l.holder = candidate
+		l.expiresAt = time.Now().Add(ttl)
+	}
+	return l.holder, nil
+}
+
+func (l *memLock) Release(_ context.Context, candidate string) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.holder == candidate {
+		l.holder = ""
+	}
+	return nil
+}
+
+func (l *memLock) set(holder string, err error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.holder = holder
+	l.expiresAt = time.Now().Add(time.Hour)
+	l.err = err
+}
+
+func TestNew(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		name        string
+		lock        Lock
+		oo          []OptionFunc
+		expectedErr string
+	}{
+		"success":                 {name: "name", lock: &memLock{}, oo: []OptionFunc{WithID("id")}},
+		"missing name":            {lock: &memLock{}, expectedErr: "name is required"},
+		"missing lock":            {name: "name", expectedErr: "lock is nil"},
+		"option failure":          {name: "name", lock: &memLock{}, oo: []OptionFunc{WithID("")}, expectedErr: "candidate ID is empty"},
+		"renew interval too long": {name: "name", lock: &memLock{}, oo: []OptionFunc{WithLeaseDuration(time.Second), WithRenewInterval(time.Second)}, expectedErr: "renew interval should be less than the lease duration"},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := New(tt.name, tt.lock, tt.oo...)
+			if tt.expectedErr != "" {
+				require.EqualError(t, err, tt.expectedErr)
+				assert.Nil(t, got)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, "id", got.ID())
+				assert.False(t, got.IsLeader())
+				assert.False(t, got.IsReady())
+				require.Error(t, got.Context().Err())
+			}
+		})
+	}
+}
+
+type candidate struct {
+	cmp      *Component
+	elected  atomic.Int32
+	revoked  atomic.Int32
+	leaderCh chan context.Context
+	cancel   context.CancelFunc
+	done     chan error
+}
+
+func newCandidate(t *testing.T, id string, lock Lock) *candidate {
+	t.Helper()
+
+	c := &candidate{leaderCh: make(chan context.Context, 10), done: make(chan error, 1)}
+	cmp, err := New("election", lock, WithID(id), WithLeaseDuration(100*time.Millisecond),
+		WithRenewInterval(10*time.Millisecond),
+		WithOnElected(func(ctx context.Context) {
+			c.elected.Add(1)
+			c.leaderCh <- ctx
+			<-ctx.Done()
+		}),
+		WithOnRevoked(func() { c.revoked.Add(1) }))
+	require.NoError(t, err)
+	c.cmp = cmp
+	return c
+}
+
+func (c *candidate) run() {
+	ctx, cancel := context.WithCancel(context.Background())
+	c.cancel = cancel
+	go func() {
+		c.done <- c.cmp.Run(ctx)
+	}()
+}
+
+func (c *candidate) stop(t *testing.T) {
+	t.Helper()
+	c.cancel()
+	require.NoError(t, <-c.done)
+}
+
+func TestComponent_Election(t *testing.T) {
+	t.Parallel()
+
+	lock := &memLock{}
+	first := newCandidate(t, "first", lock)
+	second := newCandidate(t, "second", lock)
+
+	first.run()
+	assert.Eventually(t, first.cmp.IsLeader, time.Second, time.Millisecond)
+	leaderCtx := <-first.leaderCh
+
+	second.run()
+	assert.Eventually(t, second.cmp.IsReady, time.Second, time.Millisecond)
+	assert.False(t, second.cmp.IsLeader())
+	assert.Equal(t, "first", second.cmp.Leader())
+
+	first.stop(t)
+	require.Error(t, leaderCtx.Err())
+	assert.Equal(t, int32(1), first.revoked.Load())
+
+	assert.Eventually(t, second.cmp.IsLeader, time.Second, time.Millisecond)
+	<-second.leaderCh
+	assert.Equal(t, "second", second.cmp.Leader())
+	assert.Equal(t, int32(1), second.elected.Load())
+
+	second.stop(t)
+	assert.Equal(t, int32(1), second.revoked.Load())
+}
+
+func TestComponent_LeadershipLost(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		holder string
+		err    error
+	}{
+		"lease taken over":  {holder: "other"},
+		"lease not renewed": {err: errors.New("connection refused")},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			lock := &memLock{}
+			c := newCandidate(t, "id", lock)
+			c.run()
+			assert.Eventually(t, c.cmp.IsLeader, time.Second, time.Millisecond)
+			leaderCtx := <-c.leaderCh
+
+			lock.set(tt.holder, tt.err)
+
+			select {
+			case <-leaderCtx.Done():
+			case <-time.After(time.Second):
+				assert.Fail(t, "leadership context not canceled")
+			}
+			assert.False(t, c.cmp.IsLeader())
+			assert.Eventually(t, func() bool { return c.revoked.Load() == 1 }, time.Second, time.Millisecond)
+			assert.Equal(t, tt.err == nil, c.cmp.IsReady())
+
+			c.stop(t)
+			assert.Equal(t, int32(1), c.revoked.Load())
+		})
+	}
+}
+
+func TestComponent_ElectedCallbacksDoNotOverlap(t *testing.T) {
+	t.Parallel()
+
+	var running, overlaps, elected atomic.Int32
+	lock := &memLock{}
+	cmp, err := New("election", lock, WithID("id"), WithLeaseDuration(100*time.Millisecond),
+		WithRenewInterval(10*time.Millisecond),
+		WithOnElected(func(ctx context.Context) {
+			if running.Add(1) > 1 {
+				overlaps.Add(1)
+			}
+			defer running.Add(-1)
+			elected.Add(1)
+			<-ctx.Done()
+			// the callback outlives the term.
+			time.Sleep(50 * time.Millisecond)
+		}))
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() {
+		done <- cmp.Run(ctx)
+	}()
+
+	assert.Eventually(t, cmp.IsLeader, time.Second, time.Millisecond)
+	lock.set("other", nil)
+	assert.Eventually(t, func() bool { return !cmp.IsLeader() }, time.Second, time.Millisecond)
+	lock.set("", nil)
+	assert.Eventually(t, cmp.IsLeader, time.Second, time.Millisecond)
+	assert.Eventually(t, func() bool { return elected.Load() == 2 }, time.Second, time.Millisecond)
+
+	cancel()
+	require.NoError(t, <-done)
+	assert.Equal(t, int32(0), overlaps.Load())
+}
+
+func TestNewLocks(t *testing.T) {
+	t.Parallel()
+
+	_, err := NewRedisLock(nil, "key")
+	require.EqualError(t, err, "redis client is nil")
+
+	_, err = NewSQLLock(nil, "leader_leases", "name")
+	require.EqualError(t, err, "db is nil")
+}
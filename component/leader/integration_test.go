@@ -0,0 +1,87 @@
+//go:build integration
+
+package leader
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	patronredis "github.com/beatlabs/patron/client/redis"
+	patronsql "github.com/beatlabs/patron/client/sql"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	// Integration test.
+	_ "github.com/go-sql-driver/mysql"
+)
+
+const (
+	redisDSN = "localhost:6379"
+	sqlDSN   = "patron:test123@(localhost:3306)/patrondb?parseTime=true"
+)
+
+func TestRedisLock(t *testing.T) {
+	ctx := context.Background()
+
+	cl, err := patronredis.New(&redis.Options{Addr: redisDSN})
+	require.NoError(t, err)
+	defer func() { require.NoError(t, cl.Close()) }()
+	require.NoError(t, cl.Del(ctx, "leader-test").Err())
+
+	lock, err := NewRedisLock(cl, "leader-test")
+	require.NoError(t, err)
+
+	testLock(ctx, t, lock)
+}
+
+func TestSQLLock(t *testing.T) {
+	ctx := context.Background()
+
+	db, err := patronsql.Open("mysql", sqlDSN)
+	require.NoError(t, err)
+	defer func() { require.NoError(t, db.Close(ctx)) }()
+
+	_, err = db.Exec(ctx, "CREATE TABLE IF NOT EXISTS leader_leases "+
+		"(name VARCHAR(255) NOT NULL PRIMARY KEY, holder VARCHAR(255) NOT NULL, expires_at DATETIME(3) NOT NULL)")
+	require.NoError(t, err)
+	_, err = db.Exec(ctx, "DELETE FROM leader_leases WHERE name = ?", "test")
+	require.NoError(t, err)
+
+	lock, err := NewSQLLock(db, "leader_leases", "test")
+	require.NoError(t, err)
+
+	testLock(ctx, t, lock)
+}
+
+func testLock(ctx context.Context, t *testing.T, lock Lock) {
+	t.Helper()
+
+	holder, err := lock.Acquire(ctx, "first", time.Second)
+	require.NoError(t, err)
+	assert.Equal(t, "first", holder)
+
+	holder, err = lock.Acquire(ctx, "second", time.Second)
+	require.NoError(t, err)
+	assert.Equal(t, "first", holder)
+
+	holder, err = lock.Acquire(ctx, "first", time.Second)
+	require.NoError(t, err)
+	assert.Equal(t, "first", holder)
+
+	require.NoError(t, lock.Release(ctx, "second"))
+	holder, err = lock.Acquire(ctx, "second", time.Second)
+	require.NoError(t, err)
+	assert.Equal(t, "first", holder)
+
+	require.NoError(t, lock.Release(ctx, "first"))
+	holder, err = lock.Acquire(ctx, "second", 100*time.Millisecond)
+	require.NoError(t, err)
+	assert.Equal(t, "second", holder)
+
+	time.Sleep(200 * time.Millisecond)
+	holder, err = lock.Acquire(ctx, "first", time.Second)
+	require.NoError(t, err)
+	assert.Equal(t, "first", holder)
+}
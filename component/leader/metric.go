@@ -0,0 +1,50 @@
+package leader
+
+import (
+	"context"
+
+	patronmetric "github.com/beatlabs/patron/observability/metric"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+const (
+	packageName     = "leader"
+	actionAttribute = "action"
+)
+
+var (
+	leaderStatusGauge       metric.Int64Gauge
+	leaderTransitionCounter metric.Int64Counter
+
+	acquiredActionAttr = attribute.String(actionAttribute, "acquired")
+	lostActionAttr     = attribute.String(actionAttribute, "lost")
+)
+
+func init() {
+	leaderStatusGauge = patronmetric.Int64Gauge(packageName, "leader.status", "Leader status, 1 while the instance is the leader.", "1")
+	leaderTransitionCounter = patronmetric.Int64Counter(packageName, "leader.transitions", "Leadership transition counter.", "1")
+}
+
+// leaderStatusRecord records the status of the instance, identified by its hostname rather than its candidate ID,
+// as the candidate IDs are unbounded.
+func leaderStatusRecord(ctx context.Context, election, instance string, leader bool) {
+	var status int64
+	if leader {
+		status = 1
+	}
+	leaderStatusGauge.Record(ctx, status, metric.WithAttributes(electionAttribute(election),
+		attribute.String("instance", instance)))
+}
+
+func leaderTransitionInc(ctx context.Context, election string, acquired bool) {
+	actionAttr := lostActionAttr
+	if acquired {
+		actionAttr = acquiredActionAttr
+	}
+	leaderTransitionCounter.Add(ctx, 1, metric.WithAttributes(electionAttribute(election), actionAttr))
+}
+
+func electionAttribute(election string) attribute.KeyValue {
+	return attribute.String("election", election)
+}
@@ -0,0 +1,69 @@
+package leader
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// OptionFunc definition for configuring the component in a functional way.
+type OptionFunc func(*Component) error
+
+// WithID sets the candidate ID of the component, which has to be unique among the candidates of the election.
+func WithID(id string) OptionFunc {
+	return func(c *Component) error {
+		if id == "" {
+			return errors.New("candidate ID is empty")
+		}
+		c.id = id
+		return nil
+	}
+}
+
+// WithLeaseDuration sets how long the lease is held without being renewed.
+// A new leader is elected at the latest after this duration when the leader stops renewing the lease.
+func WithLeaseDuration(duration time.Duration) OptionFunc {
+	return func(c *Component) error {
+		if duration <= 0 {
+			return errors.New("negative or zero lease duration provided")
+		}
+		c.leaseDuration = duration
+		return nil
+	}
+}
+
+// WithRenewInterval sets how often the lease is acquired or renewed. It has to be less than the lease duration.
+func WithRenewInterval(interval time.Duration) OptionFunc {
+	return func(c *Component) error {
+		if interval <= 0 {
+			return errors.New("negative or zero renew interval provided")
+		}
+		c.renewInterval = interval
+		return nil
+	}
+}
+
+// WithOnElected sets the callback invoked in a new goroutine when the leadership is acquired.
+// The context of the callback is canceled when the leadership is lost, and the component waits
+// for the callback to return before stopping. The callback of a term starts once the one of the previous
+// term has returned, and is skipped if the leadership is lost in the meantime.
+func WithOnElected(fn func(ctx context.Context)) OptionFunc {
+	return func(c *Component) error {
+		if fn == nil {
+			return errors.New("elected callback is nil")
+		}
+		c.onElected = fn
+		return nil
+	}
+}
+
+// WithOnRevoked sets the callback invoked when the leadership is lost.
+func WithOnRevoked(fn func()) OptionFunc {
+	return func(c *Component) error {
+		if fn == nil {
+			return errors.New("revoked callback is nil")
+		}
+		c.onRevoked = fn
+		return nil
+	}
+}
@@ -0,0 +1,42 @@
+package leader
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOptions(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		option      OptionFunc
+		expectedErr string
+	}{
+		"id":                      {option: WithID("id")},
+		"empty id":                {option: WithID(""), expectedErr: "candidate ID is empty"},
+		"lease duration":          {option: WithLeaseDuration(time.Second)},
+		"zero lease duration":     {option: WithLeaseDuration(0), expectedErr: "negative or zero lease duration provided"},
+		"renew interval":          {option: WithRenewInterval(time.Second)},
+		"negative renew interval": {option: WithRenewInterval(-time.Second), expectedErr: "negative or zero renew interval provided"},
+		"elected callback":        {option: WithOnElected(func(context.Context) {})},
+		"nil elected callback":    {option: WithOnElected(nil), expectedErr: "elected callback is nil"},
+		"revoked callback":        {option: WithOnRevoked(func() {})},
+		"nil revoked callback":    {option: WithOnRevoked(nil), expectedErr: "revoked callback is nil"},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			err := tt.option(&Component{})
+			if tt.expectedErr != "" {
+				assert.EqualError(t, err, tt.expectedErr)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,58 @@
+package leader
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// acquireScript sets the key to the candidate if it is not set, extends its expiry if it is already set to
+// the candidate and returns the value of the key.
+var acquireScript = redis.NewScript(`
+local holder = redis.call("GET", KEYS[1])
+if holder == false then
+	redis.call("SET", KEYS[1], ARGV[1], "PX", ARGV[2])
+	return ARGV[1]
+end
+if holder == ARGV[1] then
+	redis.call("PEXPIRE", KEYS[1], ARGV[2])
+end
+return holder
+`)
+
+// releaseScript deletes the key if it is set to the candidate.
+var releaseScript = redis.NewScript(`
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("DEL", KEYS[1])
+end
+return 0
+`)
+
+// RedisLock is a Lock holding the lease in a Redis key with an expiry.
+type RedisLock struct {
+	client redis.Scripter
+	key    string
+}
+
+// NewRedisLock creates a Lock on the given key, using a client like the one created by the client/redis package.
+func NewRedisLock(client redis.Scripter, key string) (*RedisLock, error) {
+	if client == nil {
+		return nil, errors.New("redis client is nil")
+	}
+	if key == "" {
+		return nil, errors.New("key is empty")
+	}
+	return &RedisLock{client: client, key: key}, nil
+}
+
+// Acquire sets the key to the candidate if it has expired, or extends its expiry if the candidate holds it.
+func (l *RedisLock) Acquire(ctx context.Context, candidate string, ttl time.Duration) (string, error) {
+	return acquireScript.Run(ctx, l.client, []string{l.key}, candidate, ttl.Milliseconds()).Text()
+}
+
+// Release deletes the key if the candidate holds it.
+func (l *RedisLock) Release(ctx context.Context, candidate string) error {
+	return releaseScript.Run(ctx, l.client, []string{l.key}, candidate).Err()
+}
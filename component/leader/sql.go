@@ -0,0 +1,107 @@
+package leader
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"regexp"
+	"time"
+
+	patronsql "github.com/beatlabs/patron/client/sql"
+)
+
+var tablePattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_.]*$`)
+
+// SQLLock is a Lock holding the lease in a row of a table with the columns name, holder and expires_at:
+//
+//	CREATE TABLE leader_leases (
+//		name       VARCHAR(255) NOT NULL PRIMARY KEY,
+//		holder     VARCHAR(255) NOT NULL,
+//		expires_at DATETIME(3)  NOT NULL
+//	);
+//
+// The expiry is based on the clock of the candidates, which should be synchronized.
+type SQLLock struct {
+	db      *patronsql.DB
+	name    string
+	update  string
+	insert  string
+	query   string
+	release string
+}
+
+// NewSQLLock creates a Lock on the row with the given name, in a table of a database opened by the client/sql package.
+// The statements use the ? placeholder, as supported by the MySQL driver.
+func NewSQLLock(db *patronsql.DB, table, name string) (*SQLLock, error) {
+	if db == nil {
+		return nil, errors.New("db is nil")
+	}
+	if !tablePattern.MatchString(table) {
+		return nil, errors.New("table name is invalid")
+	}
+	if name == "" {
+		return nil, errors.New("lease name is empty")
+	}
+
+	return &SQLLock{
+		db:      db,
+		name:    name,
+		update:  fmt.Sprintf("UPDATE %s SET holder = ?, expires_at = ? WHERE name = ? AND (holder = ? OR expires_at < ?)", table),
+		insert:  fmt.Sprintf("INSERT INTO %s (name, holder, expires_at) VALUES (?, ?, ?)", table),
+		query:   fmt.Sprintf("SELECT holder FROM %s WHERE name = ?", table),
+		release: fmt.Sprintf("DELETE FROM %s WHERE name = ? AND holder = ?", table),
+	}, nil
+}
+
+// Acquire takes over the lease row if it has expired or extends it if the candidate holds it.
+// The row is created if it does not exist.
+func (l *SQLLock) Acquire(ctx context.Context, candidate string, ttl time.Duration) (string, error) {
+	now := time.Now().UTC()
+	expiresAt := now.Add(ttl)
+
+	res, err := l.db.Exec(ctx, l.update, candidate, expiresAt, l.name, candidate, now)
+	if err != nil {
+		return "", err
+	}
+	updated, err := res.RowsAffected()
+	if err != nil {
+		return "", err
+	}
+	if updated > 0 {
+		return candidate, nil
+	}
+
+	holder, err := l.holder(ctx)
+	if err == nil {
+		return holder, nil
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return "", err
+	}
+
+	_, insertErr := l.db.Exec(ctx, l.insert, l.name, candidate, expiresAt)
+	if insertErr == nil {
+		return candidate, nil
+	}
+
+	// the insert violates the primary key if another candidate created the row concurrently,
+	// in which case the election is lost to the holder of the row.
+	holder, err = l.holder(ctx)
+	if err != nil {
+		return "", insertErr
+	}
+	return holder, nil
+}
+
+func (l *SQLLock) holder(ctx context.Context) (string, error) {
+	var holder string
+	err := l.db.QueryRow(ctx, l.query, l.name).Scan(&holder)
+	return holder, err
+}
+
+// Release deletes the lease row if the candidate holds it.
+func (l *SQLLock) Release(ctx context.Context, candidate string) error {
+	_, err := l.db.Exec(ctx, l.release, l.name, candidate)
+	return err
+}
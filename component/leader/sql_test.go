@@ -0,0 +1,145 @@
+package leader
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	patronsql "github.com/beatlabs/patron/client/sql"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewSQLLock(t *testing.T) {
+	t.Parallel()
+
+	db := patronsql.OpenDB(&raceConnector{})
+	defer func() { require.NoError(t, db.Close(context.Background())) }()
+
+	tests := map[string]struct {
+		db          *patronsql.DB
+		table       string
+		name        string
+		expectedErr string
+	}{
+		"success":       {db: db, table: "leader_leases", name: "test"},
+		"missing db":    {table: "leader_leases", name: "test", expectedErr: "db is nil"},
+		"invalid table": {db: db, table: "leases; DROP TABLE users", name: "test", expectedErr: "table name is invalid"},
+		"missing name":  {db: db, table: "leader_leases", expectedErr: "lease name is empty"},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			lock, err := NewSQLLock(tt.db, tt.table, tt.name)
+			if tt.expectedErr != "" {
+				require.EqualError(t, err, tt.expectedErr)
+				assert.Nil(t, lock)
+			} else {
+				require.NoError(t, err)
+				assert.NotNil(t, lock)
+			}
+		})
+	}
+}
+
+func TestSQLLock_Acquire_ConcurrentInsert(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		winner         string
+		expectedHolder string
+		expectedErr    string
+	}{
+		"lost to the concurrent candidate": {winner: "other", expectedHolder: "other"},
+		"insert failure without a holder":  {expectedErr: "db.Exec: duplicate entry"},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			// the row does not exist when queried, but is created by another candidate before the insert.
+			db := patronsql.OpenDB(&raceConnector{winner: tt.winner})
+			defer func() { require.NoError(t, db.Close(context.Background())) }()
+			lock, err := NewSQLLock(db, "leader_leases", "test")
+			require.NoError(t, err)
+
+			holder, err := lock.Acquire(context.Background(), "candidate", time.Second)
+			if tt.expectedErr != "" {
+				require.EqualError(t, err, tt.expectedErr)
+			} else {
+				require.NoError(t, err)
+			}
+			assert.Equal(t, tt.expectedHolder, holder)
+		})
+	}
+}
+
+// raceConnector opens connections to a table whose lease row is inserted concurrently by the winner, if any.
+type raceConnector struct {
+	winner string
+}
+
+func (c *raceConnector) Connect(context.Context) (driver.Conn, error) {
+	return &raceConn{winner: c.winner}, nil
+}
+
+func (c *raceConnector) Driver() driver.Driver {
+	return nil
+}
+
+type raceConn struct {
+	winner  string
+	queries int
+}
+
+func (c *raceConn) ExecContext(_ context.Context, query string, _ []driver.NamedValue) (driver.Result, error) {
+	if strings.HasPrefix(query, "INSERT") {
+		return nil, errors.New("duplicate entry")
+	}
+	return driver.RowsAffected(0), nil
+}
+
+func (c *raceConn) QueryContext(_ context.Context, _ string, _ []driver.NamedValue) (driver.Rows, error) {
+	c.queries++
+	if c.queries == 1 || c.winner == "" {
+		return &holderRows{}, nil
+	}
+	return &holderRows{holders: []string{c.winner}}, nil
+}
+
+func (c *raceConn) Prepare(string) (driver.Stmt, error) {
+	return nil, errors.New("not supported")
+}
+
+func (c *raceConn) Close() error {
+	return nil
+}
+
+func (c *raceConn) Begin() (driver.Tx, error) {
+	return nil, errors.New("not supported")
+}
+
+type holderRows struct {
+	holders []string
+}
+
+func (r *holderRows) Columns() []string {
+	return []string{"holder"}
+}
+
+func (r *holderRows) Close() error {
+	return nil
+}
+
+func (r *holderRows) Next(dest []driver.Value) error {
+	if len(r.holders) == 0 {
+		return io.EOF
+	}
+	dest[0] = r.holders[0]
+	r.holders = r.holders[1:]
+	return nil
+}
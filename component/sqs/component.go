@@ -8,6 +8,7 @@ import (
 	"log/slog"
 	"strconv"
 	"strings"
+	"sync/atomic"
 	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
@@ -28,6 +29,9 @@ const (
 	defaultMaxMessages   = 3
 )
 
+// logger of the component, whose level can be changed at runtime through the "sqs" name.
+var logger = log.Named("sqs")
+
 // ProcessorFunc definition of an async processor.
 type ProcessorFunc func(context.Context, Batch)
 
@@ -82,6 +86,13 @@ type Component struct {
 	proc       ProcessorFunc
 	stats      stats
 	retry      retry
+	logLevel   *log.LevelOverride
+	connected  atomic.Bool
+}
+
+// IsReady returns true while messages are received from the queue without errors.
+func (c *Component) IsReady() bool {
+	return c.connected.Load()
 }
 
 // New creates a new component with support for functional configuration.
@@ -162,20 +173,20 @@ func (c *Component) Run(ctx context.Context) error {
 			<-consumeDone
 			return err
 		case <-ctx.Done():
-			log.FromContext(ctx).Info("context cancellation received. exiting...")
+			logger.Info("context cancellation received. exiting...")
 			<-consumeDone
 			return nil
 		case <-tickerStats.C:
 			err := c.report(ctx, c.api, c.queue.url)
 			if err != nil {
-				log.FromContext(ctx).Error("failed to report sqsAPI stats", log.ErrorAttr(err))
+				logger.Error("failed to report sqsAPI stats", log.ErrorAttr(err))
 			}
 		}
 	}
 }
 
 func (c *Component) consume(ctx context.Context, chErr chan error) {
-	logger := log.FromContext(ctx)
+	defer c.connected.Store(false)
 
 	retries := c.retry.count
 
@@ -197,6 +208,7 @@ func (c *Component) consume(ctx context.Context, chErr chan error) {
 			},
 		})
 		if err != nil {
+			c.connected.Store(false)
 			logger.Error("failed to receive messages, sleeping", log.ErrorAttr(err), slog.Duration("wait", c.retry.wait))
 			select {
 			case <-time.After(c.retry.wait):
@@ -211,6 +223,7 @@ func (c *Component) consume(ctx context.Context, chErr chan error) {
 			return
 		}
 		retries = c.retry.count
+		c.connected.Store(true)
 
 		if ctx.Err() != nil {
 			return
@@ -247,7 +260,11 @@ func (c *Component) createBatch(ctx context.Context, output *sqs.ReceiveMessageO
 		ctx, sp := patrontrace.StartSpan(ctx, consumerComponent, trace.WithSpanKind(trace.SpanKindConsumer))
 
 		ctx = correlation.ContextWithID(ctx, corID)
-		ctx = log.WithContext(ctx, slog.With(slog.String(correlation.ID, corID)))
+		ctx = log.WithContext(ctx, logger.With(slog.String(correlation.ID, corID)))
+		if c.logLevel != nil {
+			carrier := consumerMessageCarrier{msg: &msg} // nolint:gosec
+			ctx = c.logLevel.Context(ctx, carrier.Get(log.LevelHeader), carrier.Get(log.LevelSignatureHeader))
+		}
 
 		btc.messages = append(btc.messages, message{
 			ctx:   ctx,
@@ -262,7 +279,7 @@ func (c *Component) createBatch(ctx context.Context, output *sqs.ReceiveMessageO
 }
 
 func (c *Component) report(ctx context.Context, sqsAPI API, queueURL string) error {
-	slog.Debug("retrieve stats for SQS", slog.String("queue", c.queue.name))
+	logger.Debug("retrieve stats for SQS", slog.String("queue", c.queue.name))
 	rsp, err := sqsAPI.GetQueueAttributes(ctx, &sqs.GetQueueAttributesInput{
 		AttributeNames: []types.QueueAttributeName{
 			sqsAttributeApproximateNumberOfMessages,
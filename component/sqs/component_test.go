@@ -3,12 +3,17 @@ package sqs
 import (
 	"context"
 	"errors"
+	"log/slog"
 	"os"
 	"sync"
 	"testing"
 	"time"
 
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/sqs"
+	"github.com/aws/aws-sdk-go-v2/service/sqs/types"
 	"github.com/beatlabs/patron/internal/test"
+	"github.com/beatlabs/patron/observability/log"
 	patrontrace "github.com/beatlabs/patron/observability/trace"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -322,3 +327,30 @@ func (sp stubProcessor) process(_ context.Context, b Batch) {
 	_, err := b.ACK()
 	require.NoError(sp.t, err)
 }
+
+func TestComponent_createBatch_LogLevel(t *testing.T) {
+	override, err := log.NewLevelOverride(log.LevelOverrideConfig{Levels: []string{"debug"}})
+	require.NoError(t, err)
+	c := &Component{queue: queue{name: queueName, url: queueURL}, logLevel: override}
+
+	btc := c.createBatch(context.Background(), &sqs.ReceiveMessageOutput{Messages: []types.Message{
+		{MessageId: aws.String("1"), MessageAttributes: map[string]types.MessageAttributeValue{
+			log.LevelHeader: {DataType: aws.String("String"), StringValue: aws.String("debug")},
+		}},
+		{MessageId: aws.String("2")},
+	}})
+	require.Len(t, btc.messages, 2)
+	t.Cleanup(func() {
+		for _, msg := range btc.messages {
+			msg.Span().End()
+		}
+		require.NoError(t, tracePublisher.ForceFlush(context.Background()))
+		traceExporter.Reset()
+	})
+
+	lvl, ok := log.LevelFromContext(btc.messages[0].Context())
+	assert.True(t, ok)
+	assert.Equal(t, slog.LevelDebug, lvl)
+	_, ok = log.LevelFromContext(btc.messages[1].Context())
+	assert.False(t, ok)
+}
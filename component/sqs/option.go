@@ -4,6 +4,8 @@ import (
 	"errors"
 	"fmt"
 	"time"
+
+	"github.com/beatlabs/patron/observability/log"
 )
 
 const twelveHoursInSeconds = 43200
@@ -91,3 +93,16 @@ func WithQueueOwner(queueOwner string) OptionFunc {
 		return nil
 	}
 }
+
+// WithLogLevelOverride processes a message at the log level it requests with the log.LevelHeader message attribute, e.g. debug,
+// if allowed by the configuration.
+func WithLogLevelOverride(cfg log.LevelOverrideConfig) OptionFunc {
+	return func(c *Component) error {
+		override, err := log.NewLevelOverride(cfg)
+		if err != nil {
+			return err
+		}
+		c.logLevel = override
+		return nil
+	}
+}
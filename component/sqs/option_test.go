@@ -4,6 +4,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/beatlabs/patron/observability/log"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -218,3 +219,10 @@ func TestQueueOwner(t *testing.T) {
 		})
 	}
 }
+
+func TestLogLevelOverride(t *testing.T) {
+	c := &Component{}
+	require.EqualError(t, WithLogLevelOverride(log.LevelOverrideConfig{})(c), "no log levels allowed")
+	require.NoError(t, WithLogLevelOverride(log.LevelOverrideConfig{Levels: []string{"debug"}})(c))
+	assert.NotNil(t, c.logLevel)
+}
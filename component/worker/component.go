@@ -0,0 +1,276 @@
+// Package worker provides a component running jobs submitted in-process on a bounded queue.
+package worker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/beatlabs/patron/correlation"
+	"github.com/beatlabs/patron/observability"
+	"github.com/beatlabs/patron/observability/log"
+	patrontrace "github.com/beatlabs/patron/observability/trace"
+	"github.com/beatlabs/patron/reliability/retry"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	defaultConcurrency  = 10
+	defaultQueueSize    = 100
+	defaultDrainTimeout = 10 * time.Second
+
+	workerComponent = "worker"
+)
+
+var (
+	// ErrQueueFull is returned by Submit when the queue is full and the backpressure policy rejects jobs.
+	ErrQueueFull = errors.New("worker queue is full")
+	// ErrStopped is returned by Submit when the component is not running.
+	ErrStopped = errors.New("worker component is stopped")
+)
+
+// JobFunc is the function of a submitted job.
+// The context carries the values of the submitting context, like the correlation ID, and a span in the trace of the caller.
+// It is not canceled when the submitting context is, but when the drain timeout of the component elapses.
+type JobFunc func(ctx context.Context) error
+
+// Backpressure defines what happens when a job is submitted while the queue is full.
+type Backpressure int
+
+const (
+	// RejectWhenFull returns ErrQueueFull. It is the default policy.
+	RejectWhenFull Backpressure = iota
+	// BlockWhenFull waits until the job is queued or the submitting context is done.
+	BlockWhenFull
+)
+
+type job struct {
+	ctx        context.Context
+	name       string
+	fn         JobFunc
+	enqueuedAt time.Time
+}
+
+// Component runs the submitted jobs with a bounded concurrency.
+type Component struct {
+	name         string
+	concurrency  int
+	queueSize    int
+	backpressure Backpressure
+	retry        *retry.Retry
+	drainTimeout time.Duration
+
+	queue chan job
+	// mu makes sure that no job is queued after the workers are told to drain the queue.
+	mu       sync.RWMutex
+	stopping chan struct{}
+	running  atomic.Bool
+}
+
+// New creates a new worker component with support for functional configuration.
+// The defaults are 10 workers, a queue of 100 jobs rejecting new jobs when full, no retries and a 10s drain timeout.
+func New(name string, oo ...OptionFunc) (*Component, error) {
+	if name == "" {
+		return nil, errors.New("name is required")
+	}
+
+	cmp := &Component{
+		name:         name,
+		concurrency:  defaultConcurrency,
+		queueSize:    defaultQueueSize,
+		backpressure: RejectWhenFull,
+		drainTimeout: defaultDrainTimeout,
+		stopping:     make(chan struct{}),
+	}
+
+	for _, optionFunc := range oo {
+		err := optionFunc(cmp)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	cmp.queue = make(chan job, cmp.queueSize)
+
+	return cmp, nil
+}
+
+// IsReady returns true while the component accepts jobs.
+func (c *Component) IsReady() bool {
+	return c.running.Load()
+}
+
+// Submit queues a job. Jobs can be queued before the component runs, and are rejected with ErrStopped after it stops.
+// The backpressure policy decides between returning ErrQueueFull and blocking when the queue is full.
+func (c *Component) Submit(ctx context.Context, name string, fn JobFunc) error {
+	if name == "" {
+		return errors.New("job name is required")
+	}
+	if fn == nil {
+		return errors.New("job function is nil")
+	}
+
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	select {
+	case <-c.stopping:
+		jobRejectedInc(ctx, c.name, stoppedReason)
+		return ErrStopped
+	default:
+	}
+
+	if correlation.IDFromContext(ctx) == "" {
+		ctx = correlation.ContextWithID(ctx, correlation.New())
+	}
+	// the job outlives the submitting context, e.g. of an HTTP request, but keeps its values.
+	j := job{ctx: context.WithoutCancel(ctx), name: name, fn: fn, enqueuedAt: time.Now()}
+
+	if c.backpressure == RejectWhenFull {
+		select {
+		case c.queue <- j:
+		default:
+			jobRejectedInc(ctx, c.name, queueFullReason)
+			return ErrQueueFull
+		}
+	} else {
+		select {
+		case c.queue <- j:
+		case <-c.stopping:
+			jobRejectedInc(ctx, c.name, stoppedReason)
+			return ErrStopped
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+
+	queueDepthRecord(ctx, c.name, len(c.queue))
+	return nil
+}
+
+// Run starts the workers and, once the context is canceled, stops accepting jobs and drains the queue.
+// Jobs still running or queued when the drain timeout elapses are canceled or dropped.
+func (c *Component) Run(ctx context.Context) error {
+	if !c.running.CompareAndSwap(false, true) {
+		return errors.New("worker component is already running")
+	}
+
+	drain := make(chan struct{})
+	// jobs are detached from the run context, so that the queue can be drained.
+	abortCtx, abort := context.WithCancel(context.WithoutCancel(ctx))
+	defer abort()
+
+	wg := sync.WaitGroup{}
+	for range c.concurrency {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			c.work(abortCtx, drain)
+		}()
+	}
+
+	<-ctx.Done()
+	c.running.Store(false)
+	close(c.stopping)
+	// wait for the submissions in progress, so that the queue is complete before draining it.
+	c.mu.Lock()
+	close(drain)
+	c.mu.Unlock()
+
+	slog.Info("stopping worker component, draining queue", slog.String("component", c.name),
+		slog.Int("queued", len(c.queue)))
+
+	done := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(c.drainTimeout):
+		slog.Warn("drain timeout elapsed, canceling running jobs", slog.String("component", c.name),
+			slog.Int("dropped", len(c.queue)))
+		abort()
+		<-done
+	}
+	return nil
+}
+
+func (c *Component) work(abortCtx context.Context, drain <-chan struct{}) {
+	for {
+		select {
+		case j := <-c.queue:
+			c.process(abortCtx, j)
+		case <-drain:
+			for {
+				select {
+				case j := <-c.queue:
+					c.process(abortCtx, j)
+				default:
+					return
+				}
+			}
+		}
+	}
+}
+
+func (c *Component) process(abortCtx context.Context, j job) {
+	queueDepthRecord(j.ctx, c.name, len(c.queue))
+
+	if abortCtx.Err() != nil {
+		jobRejectedInc(j.ctx, c.name, drainTimeoutReason)
+		log.FromContext(j.ctx).Error("drain timeout elapsed, dropping job", slog.String("job", j.name))
+		return
+	}
+
+	ctx, cancel := context.WithCancel(j.ctx)
+	defer cancel()
+	stop := context.AfterFunc(abortCtx, cancel)
+	defer stop()
+
+	ctx, sp := patrontrace.StartSpan(ctx, patrontrace.ComponentOpName(workerComponent, j.name),
+		trace.WithSpanKind(trace.SpanKindConsumer), trace.WithAttributes(observability.ComponentAttribute(c.name)))
+	defer sp.End()
+
+	logger := log.FromContext(ctx).With(slog.String(correlation.ID, correlation.IDFromContext(ctx)),
+		slog.String("job", j.name))
+	ctx = log.WithContext(ctx, logger)
+
+	jobLatencyRecord(ctx, c.name, j.name, time.Since(j.enqueuedAt))
+
+	start := time.Now()
+	err := c.execute(ctx, j.fn)
+	jobDurationRecord(ctx, c.name, j.name, time.Since(start), err)
+
+	if err != nil {
+		patrontrace.SetSpanError(sp, "job failed", err)
+		logger.Error("job failed", slog.Duration("duration", time.Since(start)), log.ErrorAttr(err))
+		return
+	}
+	patrontrace.SetSpanSuccess(sp)
+	logger.Debug("job succeeded", slog.Duration("duration", time.Since(start)))
+}
+
+func (c *Component) execute(ctx context.Context, fn JobFunc) error {
+	if c.retry == nil {
+		return run(ctx, fn)
+	}
+	_, err := c.retry.Execute(func() (any, error) {
+		return nil, run(ctx, fn)
+	})
+	return err
+}
+
+func run(ctx context.Context, fn JobFunc) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("job panicked: %v", r)
+		}
+	}()
+	return fn(ctx)
+}
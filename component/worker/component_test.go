@@ -0,0 +1,249 @@
+package worker
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/beatlabs/patron/correlation"
+	patrontrace "github.com/beatlabs/patron/observability/trace"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/goleak"
+)
+
+func TestMain(m *testing.M) {
+	goleak.VerifyTestMain(m,
+		goleak.IgnoreTopFunction("go.opencensus.io/stats/view.(*worker).start"),
+		goleak.IgnoreTopFunction("google.golang.org/grpc/internal/grpcsync.(*CallbackSerializer).run"),
+		goleak.IgnoreTopFunction("go.opentelemetry.io/otel/sdk/metric.(*PeriodicReader).run"),
+		goleak.IgnoreTopFunction("go.opentelemetry.io/otel/sdk/trace.(*batchSpanProcessor).processQueue"),
+	)
+}
+
+func noopJob(context.Context) error { return nil }
+
+func TestNew(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		name        string
+		oo          []OptionFunc
+		expectedErr string
+	}{
+		"success":        {name: "worker", oo: []OptionFunc{WithConcurrency(2), WithQueueSize(5)}},
+		"missing name":   {expectedErr: "name is required"},
+		"option failure": {name: "worker", oo: []OptionFunc{WithQueueSize(0)}, expectedErr: "queue size should be greater than 0"},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := New(tt.name, tt.oo...)
+			if tt.expectedErr != "" {
+				require.EqualError(t, err, tt.expectedErr)
+				assert.Nil(t, got)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, 5, cap(got.queue))
+				assert.False(t, got.IsReady())
+			}
+		})
+	}
+}
+
+func runComponent(t *testing.T, cmp *Component) (context.CancelFunc, <-chan error) {
+	t.Helper()
+	ctx, cancel := context.WithCancel(context.Background())
+	chErr := make(chan error, 1)
+	go func() {
+		chErr <- cmp.Run(ctx)
+	}()
+	assert.Eventually(t, cmp.IsReady, time.Second, time.Millisecond)
+	return cancel, chErr
+}
+
+func TestComponent_Submit(t *testing.T) {
+	t.Parallel()
+
+	cmp, err := New("worker")
+	require.NoError(t, err)
+
+	tests := map[string]struct {
+		name        string
+		fn          JobFunc
+		expectedErr string
+	}{
+		"success":      {name: "job", fn: noopJob},
+		"missing name": {fn: noopJob, expectedErr: "job name is required"},
+		"missing func": {name: "job", expectedErr: "job function is nil"},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			err := cmp.Submit(context.Background(), tt.name, tt.fn)
+			if tt.expectedErr != "" {
+				require.EqualError(t, err, tt.expectedErr)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestComponent_JobContext(t *testing.T) {
+	exp := tracetest.NewInMemoryExporter()
+	tp := patrontrace.Setup("test", nil, exp)
+	t.Cleanup(func() { require.NoError(t, tp.Shutdown(context.Background())) })
+
+	cmp, err := New("worker")
+	require.NoError(t, err)
+	cancel, chErr := runComponent(t, cmp)
+
+	ctx, sp := patrontrace.StartSpan(correlation.ContextWithID(context.Background(), "123"), "caller")
+	submitCtx, submitCancel := context.WithCancel(ctx)
+
+	type jobInfo struct {
+		corID  string
+		span   trace.SpanContext
+		ctxErr error
+	}
+	jobs := make(chan jobInfo, 1)
+	require.NoError(t, cmp.Submit(submitCtx, "job", func(ctx context.Context) error {
+		jobs <- jobInfo{corID: correlation.IDFromContext(ctx), span: trace.SpanContextFromContext(ctx), ctxErr: ctx.Err()}
+		return errors.New("job error")
+	}))
+	// the job outlives the submitting context.
+	submitCancel()
+	sp.End()
+
+	got := <-jobs
+	cancel()
+	require.NoError(t, <-chErr)
+
+	assert.Equal(t, "123", got.corID)
+	require.NoError(t, got.ctxErr)
+	assert.Equal(t, sp.SpanContext().TraceID(), got.span.TraceID())
+	assert.NotEqual(t, sp.SpanContext().SpanID(), got.span.SpanID())
+
+	require.NoError(t, tp.ForceFlush(context.Background()))
+	spans := exp.GetSpans()
+	require.Len(t, spans, 2)
+	assert.Equal(t, "worker job", spans[1].Name)
+	assert.Equal(t, "job failed", spans[1].Status.Description)
+}
+
+func TestComponent_Backpressure(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		backpressure Backpressure
+		expectedErr  error
+	}{
+		"reject": {backpressure: RejectWhenFull, expectedErr: ErrQueueFull},
+		"block":  {backpressure: BlockWhenFull, expectedErr: context.DeadlineExceeded},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			cmp, err := New("worker", WithConcurrency(1), WithQueueSize(1), WithBackpressure(tt.backpressure))
+			require.NoError(t, err)
+			cancel, chErr := runComponent(t, cmp)
+
+			started, release := make(chan struct{}), make(chan struct{})
+			require.NoError(t, cmp.Submit(context.Background(), "blocking", func(context.Context) error {
+				close(started)
+				<-release
+				return nil
+			}))
+			<-started
+			require.NoError(t, cmp.Submit(context.Background(), "queued", noopJob))
+
+			ctx, ctxCancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+			defer ctxCancel()
+			require.ErrorIs(t, cmp.Submit(ctx, "rejected", noopJob), tt.expectedErr)
+
+			close(release)
+			cancel()
+			require.NoError(t, <-chErr)
+			require.ErrorIs(t, cmp.Submit(context.Background(), "stopped", noopJob), ErrStopped)
+		})
+	}
+}
+
+func TestComponent_Retry(t *testing.T) {
+	t.Parallel()
+
+	cmp, err := New("worker", WithRetry(3, time.Millisecond))
+	require.NoError(t, err)
+	cancel, chErr := runComponent(t, cmp)
+
+	var attempts atomic.Int32
+	done := make(chan struct{})
+	require.NoError(t, cmp.Submit(context.Background(), "job", func(context.Context) error {
+		if attempts.Add(1) < 3 {
+			panic("boom")
+		}
+		close(done)
+		return nil
+	}))
+	<-done
+	cancel()
+	require.NoError(t, <-chErr)
+
+	assert.Equal(t, int32(3), attempts.Load())
+}
+
+func TestComponent_Drain(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		duration    time.Duration
+		expCanceled bool
+	}{
+		"queued jobs are processed":          {duration: 10 * time.Millisecond},
+		"drain timeout cancels running jobs": {duration: time.Hour, expCanceled: true},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			cmp, err := New("worker", WithConcurrency(1), WithDrainTimeout(100*time.Millisecond))
+			require.NoError(t, err)
+			cancel, chErr := runComponent(t, cmp)
+
+			var finished, canceled atomic.Int32
+			started := make(chan struct{}, 3)
+			for range 3 {
+				require.NoError(t, cmp.Submit(context.Background(), "job", func(ctx context.Context) error {
+					started <- struct{}{}
+					select {
+					case <-time.After(tt.duration):
+						finished.Add(1)
+					case <-ctx.Done():
+						canceled.Add(1)
+					}
+					return nil
+				}))
+			}
+			<-started
+			cancel()
+			require.NoError(t, <-chErr)
+
+			if tt.expCanceled {
+				// the running job is canceled and the queued ones are dropped.
+				assert.Equal(t, int32(0), finished.Load())
+				assert.Equal(t, int32(1), canceled.Load())
+			} else {
+				assert.Equal(t, int32(3), finished.Load())
+				assert.Equal(t, int32(0), canceled.Load())
+			}
+		})
+	}
+}
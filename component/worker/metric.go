@@ -0,0 +1,55 @@
+package worker
+
+import (
+	"context"
+	"time"
+
+	"github.com/beatlabs/patron/observability"
+	patronmetric "github.com/beatlabs/patron/observability/metric"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+const (
+	packageName = "worker"
+
+	queueFullReason    = "queue_full"
+	stoppedReason      = "stopped"
+	drainTimeoutReason = "drain_timeout"
+)
+
+var (
+	queueDepthGauge      metric.Int64Gauge
+	jobLatencyHistogram  metric.Int64Histogram
+	jobDurationHistogram metric.Int64Histogram
+	jobRejectedCounter   metric.Int64Counter
+)
+
+func init() {
+	queueDepthGauge = patronmetric.Int64Gauge(packageName, "worker.queue.depth", "Worker queued jobs.", "1")
+	jobLatencyHistogram = patronmetric.Int64Histogram(packageName, "worker.job.latency", "Worker job time spent in the queue.", "ms")
+	jobDurationHistogram = patronmetric.Int64Histogram(packageName, "worker.job.duration", "Worker job processing duration, including retries.", "ms")
+	jobRejectedCounter = patronmetric.Int64Counter(packageName, "worker.job.rejected", "Worker jobs rejected or dropped.", "1")
+}
+
+func queueDepthRecord(ctx context.Context, component string, depth int) {
+	queueDepthGauge.Record(ctx, int64(depth), metric.WithAttributes(observability.ComponentAttribute(component)))
+}
+
+func jobLatencyRecord(ctx context.Context, component, job string, latency time.Duration) {
+	jobLatencyHistogram.Record(ctx, latency.Milliseconds(), metric.WithAttributes(jobAttributes(component, job)...))
+}
+
+func jobDurationRecord(ctx context.Context, component, job string, duration time.Duration, err error) {
+	jobDurationHistogram.Record(ctx, duration.Milliseconds(), metric.WithAttributes(jobAttributes(component, job)...),
+		metric.WithAttributes(observability.StatusAttribute(err)))
+}
+
+func jobRejectedInc(ctx context.Context, component, reason string) {
+	jobRejectedCounter.Add(ctx, 1, metric.WithAttributes(observability.ComponentAttribute(component),
+		attribute.String("reason", reason)))
+}
+
+func jobAttributes(component, job string) []attribute.KeyValue {
+	return []attribute.KeyValue{observability.ComponentAttribute(component), attribute.String("job", job)}
+}
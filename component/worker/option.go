@@ -0,0 +1,68 @@
+package worker
+
+import (
+	"errors"
+	"time"
+
+	"github.com/beatlabs/patron/reliability/retry"
+)
+
+// OptionFunc definition for configuring the component in a functional way.
+type OptionFunc func(*Component) error
+
+// WithConcurrency sets the number of jobs processed concurrently.
+func WithConcurrency(concurrency int) OptionFunc {
+	return func(c *Component) error {
+		if concurrency <= 0 {
+			return errors.New("concurrency should be greater than 0")
+		}
+		c.concurrency = concurrency
+		return nil
+	}
+}
+
+// WithQueueSize sets the number of jobs which can be queued waiting for a worker.
+func WithQueueSize(size int) OptionFunc {
+	return func(c *Component) error {
+		if size <= 0 {
+			return errors.New("queue size should be greater than 0")
+		}
+		c.queueSize = size
+		return nil
+	}
+}
+
+// WithBackpressure sets what happens when a job is submitted while the queue is full.
+func WithBackpressure(backpressure Backpressure) OptionFunc {
+	return func(c *Component) error {
+		if backpressure < RejectWhenFull || backpressure > BlockWhenFull {
+			return errors.New("invalid backpressure policy provided")
+		}
+		c.backpressure = backpressure
+		return nil
+	}
+}
+
+// WithRetry retries failed jobs up to the given attempts, waiting the delay between them.
+func WithRetry(attempts int, delay time.Duration) OptionFunc {
+	return func(c *Component) error {
+		r, err := retry.New(attempts, delay)
+		if err != nil {
+			return err
+		}
+		c.retry = r
+		return nil
+	}
+}
+
+// WithDrainTimeout sets how long queued and running jobs are waited for when the component stops,
+// before running jobs have their context canceled and queued jobs are dropped.
+func WithDrainTimeout(timeout time.Duration) OptionFunc {
+	return func(c *Component) error {
+		if timeout <= 0 {
+			return errors.New("negative or zero drain timeout provided")
+		}
+		c.drainTimeout = timeout
+		return nil
+	}
+}
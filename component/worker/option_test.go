@@ -0,0 +1,41 @@
+package worker
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOptions(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		option      OptionFunc
+		expectedErr string
+	}{
+		"concurrency":          {option: WithConcurrency(5)},
+		"zero concurrency":     {option: WithConcurrency(0), expectedErr: "concurrency should be greater than 0"},
+		"queue size":           {option: WithQueueSize(5)},
+		"negative queue size":  {option: WithQueueSize(-1), expectedErr: "queue size should be greater than 0"},
+		"backpressure":         {option: WithBackpressure(BlockWhenFull)},
+		"invalid backpressure": {option: WithBackpressure(Backpressure(10)), expectedErr: "invalid backpressure policy provided"},
+		"retry":                {option: WithRetry(3, time.Millisecond)},
+		"invalid retry":        {option: WithRetry(1, time.Millisecond), expectedErr: "attempts should be greater than 1"},
+		"drain timeout":        {option: WithDrainTimeout(time.Second)},
+		"zero drain timeout":   {option: WithDrainTimeout(0), expectedErr: "negative or zero drain timeout provided"},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			err := tt.option(&Component{})
+			if tt.expectedErr != "" {
+				assert.EqualError(t, err, tt.expectedErr)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
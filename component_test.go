@@ -0,0 +1,98 @@
+package patron
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewManagedComponent(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		name    string
+		cmp     Component
+		oo      []ComponentOptionFunc
+		expDeps []string
+		expErr  string
+	}{
+		"success":            {name: "http", cmp: &testRunComponent{}},
+		"success with deps":  {name: "http", cmp: &testRunComponent{}, oo: []ComponentOptionFunc{DependsOn("kafka", "sql")}, expDeps: []string{"kafka", "sql"}},
+		"missing name":       {cmp: &testRunComponent{}, expErr: "component name is required"},
+		"missing component":  {name: "http", expErr: "component is nil"},
+		"empty dependencies": {name: "http", cmp: &testRunComponent{}, oo: []ComponentOptionFunc{DependsOn()}, expErr: "dependencies are empty"},
+		"empty dependency":   {name: "http", cmp: &testRunComponent{}, oo: []ComponentOptionFunc{DependsOn("")}, expErr: "dependency name is empty"},
+		"self dependency":    {name: "http", cmp: &testRunComponent{}, oo: []ComponentOptionFunc{DependsOn("http")}, expErr: "component http cannot depend on itself"},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := NewManagedComponent(tt.name, tt.cmp, tt.oo...)
+			if tt.expErr != "" {
+				require.EqualError(t, err, tt.expErr)
+				assert.Nil(t, got)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tt.name, got.Name())
+				assert.Equal(t, tt.expDeps, got.deps)
+			}
+		})
+	}
+}
+
+func TestStartupOrder(t *testing.T) {
+	t.Parallel()
+
+	mc := func(name string, deps ...string) *ManagedComponent {
+		return &ManagedComponent{name: name, component: &testRunComponent{}, deps: deps}
+	}
+
+	tests := map[string]struct {
+		components []Component
+		expOrder   []string
+		expErr     string
+	}{
+		"no dependencies keeps order": {
+			components: []Component{mc("a"), mc("b"), &testRunComponent{}},
+			expOrder:   []string{"a", "b", "component-2"},
+		},
+		"dependencies first": {
+			components: []Component{mc("http", "kafka", "sql"), mc("kafka"), mc("sql", "kafka")},
+			expOrder:   []string{"kafka", "sql", "http"},
+		},
+		"duplicate name": {
+			components: []Component{mc("a"), mc("a")},
+			expErr:     "component name a is not unique",
+		},
+		"unknown dependency": {
+			components: []Component{mc("a", "b")},
+			expErr:     "component a depends on unknown component b",
+		},
+		"cycle": {
+			components: []Component{mc("a", "b"), mc("b", "c"), mc("c", "a")},
+			expErr:     "component dependency cycle detected: [a b c a]",
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			mcs, err := managedComponents(tt.components)
+			if err == nil {
+				mcs, err = startupOrder(mcs)
+			}
+			if tt.expErr != "" {
+				require.EqualError(t, err, tt.expErr)
+				return
+			}
+			require.NoError(t, err)
+			got := make([]string, 0, len(mcs))
+			for _, mc := range mcs {
+				got = append(got, mc.Name())
+			}
+			assert.Equal(t, tt.expOrder, got)
+		})
+	}
+}
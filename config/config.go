@@ -0,0 +1,296 @@
+// Package config provides loading and validation of typed configuration.
+//
+// Configuration is a struct whose fields are populated, in increasing order of precedence, from
+// the default tag, the section of the configuration files and the environment variable in the env tag.
+// Fields are validated with the rules of the validate tag and fields with a secret tag are redacted
+// when the effective configuration is exposed.
+//
+//	type httpConfig struct {
+//		Port     int           `yaml:"port" env:"PATRON_HTTP_DEFAULT_PORT" default:"50000" validate:"min=1,max=65535"`
+//		Timeout  time.Duration `yaml:"timeout" default:"30s" validate:"min=1ms"`
+//		Password string        `yaml:"password" env:"HTTP_PASSWORD" secret:"true"`
+//	}
+//
+// Supported validation rules are required, min, max and oneof (space separated values).
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strings"
+	"sync"
+
+	"gopkg.in/yaml.v3"
+)
+
+const redacted = "******"
+
+var (
+	mu        sync.RWMutex
+	paths     []string
+	documents []document
+	loaded    = make(map[string]json.RawMessage)
+)
+
+type document struct {
+	path string
+	root *yaml.Node
+}
+
+// SetFiles parses the YAML or JSON configuration files used by Load.
+// Files are applied in the given order, so later files override earlier ones.
+func SetFiles(pp ...string) error {
+	dd, err := parse(pp)
+	if err != nil {
+		return err
+	}
+
+	mu.Lock()
+	paths = pp
+	documents = dd
+	mu.Unlock()
+	return nil
+}
+
+// Reload parses again the configuration files set with SetFiles.
+// The previously parsed files are kept if any of them fails to parse.
+func Reload() error {
+	mu.RLock()
+	pp := paths
+	mu.RUnlock()
+
+	dd, err := parse(pp)
+	if err != nil {
+		return err
+	}
+
+	mu.Lock()
+	documents = dd
+	mu.Unlock()
+	return nil
+}
+
+func parse(pp []string) ([]document, error) {
+	dd := make([]document, 0, len(pp))
+	var errs []error
+
+	for _, path := range pp {
+		ext := strings.ToLower(filepath.Ext(path))
+		if ext != ".yaml" && ext != ".yml" && ext != ".json" {
+			errs = append(errs, fmt.Errorf("config file %s: unsupported file extension", path))
+			continue
+		}
+
+		data, err := os.ReadFile(path) //nolint:gosec
+		if err != nil {
+			errs = append(errs, fmt.Errorf("config file %s: %w", path, err))
+			continue
+		}
+
+		// JSON is a subset of YAML, so both are parsed with the same decoder.
+		root := &yaml.Node{}
+		if err := yaml.NewDecoder(bytes.NewReader(data)).Decode(root); err != nil {
+			errs = append(errs, fmt.Errorf("config file %s: %w", path, err))
+			continue
+		}
+
+		dd = append(dd, document{path: path, root: root})
+	}
+
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+	return dd, nil
+}
+
+// Load populates dst, which has to be a pointer to a struct, and validates it, see Parse.
+// On success the configuration is registered as effective under the name, see Register.
+func Load(name string, dst any) error {
+	if err := Parse(name, dst); err != nil {
+		return err
+	}
+	return Register(name, dst)
+}
+
+// Parse populates dst, which has to be a pointer to a struct, and validates it.
+// The name selects the section of the configuration files, with dots separating nested sections.
+// All errors are reported at once.
+func Parse(name string, dst any) error {
+	if name == "" {
+		return errors.New("config name is empty")
+	}
+
+	v := reflect.ValueOf(dst)
+	if v.Kind() != reflect.Pointer || v.IsNil() || v.Elem().Kind() != reflect.Struct {
+		return errors.New("config destination has to be a non-nil pointer to a struct")
+	}
+
+	var errs []error
+	errs = append(errs, applyDefaults(name, v.Elem())...)
+
+	mu.RLock()
+	dd := documents
+	mu.RUnlock()
+
+	for _, doc := range dd {
+		section := lookupSection(doc.root, name)
+		if section == nil {
+			continue
+		}
+		if err := section.Decode(dst); err != nil {
+			errs = append(errs, fmt.Errorf("%s: config file %s: %w", name, doc.path, err))
+		}
+	}
+
+	errs = append(errs, applyEnv(name, v.Elem())...)
+	errs = append(errs, validate(name, v.Elem())...)
+
+	return errors.Join(errs...)
+}
+
+// Register registers the configuration cfg, a struct or a pointer to a struct, as effective under the key,
+// replacing the one registered before under the same key.
+// A snapshot of the configuration is taken, with secrets redacted, so later changes to cfg are not reflected.
+func Register(key string, cfg any) error {
+	if key == "" {
+		return errors.New("config key is empty")
+	}
+
+	v := reflect.Indirect(reflect.ValueOf(cfg))
+	if v.Kind() != reflect.Struct {
+		return errors.New("config has to be a struct or a non-nil pointer to a struct")
+	}
+
+	snapshot, err := json.Marshal(redact(v))
+	if err != nil {
+		return fmt.Errorf("%s: failed to snapshot config: %w", key, err)
+	}
+
+	mu.Lock()
+	loaded[key] = snapshot
+	mu.Unlock()
+	return nil
+}
+
+// Effective returns the snapshots of the registered configurations keyed by name, with secrets redacted,
+// as JSON documents.
+func Effective() map[string]any {
+	mu.RLock()
+	defer mu.RUnlock()
+
+	effective := make(map[string]any, len(loaded))
+	for key, snapshot := range loaded {
+		effective[key] = slices.Clone(snapshot)
+	}
+	return effective
+}
+
+func lookupSection(root *yaml.Node, name string) *yaml.Node {
+	node := root
+	if node.Kind == yaml.DocumentNode && len(node.Content) > 0 {
+		node = node.Content[0]
+	}
+
+	for _, key := range strings.Split(name, ".") {
+		if node.Kind != yaml.MappingNode {
+			return nil
+		}
+		var next *yaml.Node
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			if node.Content[i].Value == key {
+				next = node.Content[i+1]
+				break
+			}
+		}
+		if next == nil {
+			return nil
+		}
+		node = next
+	}
+	return node
+}
+
+// fields calls fn for every exported field of the struct, recursing into nested structs.
+func fields(path string, v reflect.Value, fn func(path string, field reflect.StructField, value reflect.Value)) {
+	t := v.Type()
+	for i := range t.NumField() {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		fieldPath := path + "." + key(field)
+		if field.Type.Kind() == reflect.Struct && !isScalar(field.Type) {
+			fields(fieldPath, v.Field(i), fn)
+			continue
+		}
+		fn(fieldPath, field, v.Field(i))
+	}
+}
+
+func key(field reflect.StructField) string {
+	name, _, _ := strings.Cut(field.Tag.Get("yaml"), ",")
+	if name == "" {
+		return strings.ToLower(field.Name)
+	}
+	return name
+}
+
+func applyDefaults(name string, v reflect.Value) []error {
+	var errs []error
+	fields(name, v, func(path string, field reflect.StructField, value reflect.Value) {
+		def, ok := field.Tag.Lookup("default")
+		if !ok {
+			return
+		}
+		if err := setValue(value, def); err != nil {
+			errs = append(errs, fmt.Errorf("%s: default value is not valid: %w", path, err))
+		}
+	})
+	return errs
+}
+
+func applyEnv(name string, v reflect.Value) []error {
+	var errs []error
+	fields(name, v, func(path string, field reflect.StructField, value reflect.Value) {
+		env := field.Tag.Get("env")
+		if env == "" {
+			return
+		}
+		raw, ok := os.LookupEnv(env)
+		if !ok {
+			return
+		}
+		if err := setValue(value, raw); err != nil {
+			errs = append(errs, fmt.Errorf("%s: env var %s is not valid: %w", path, env, err))
+		}
+	})
+	return errs
+}
+
+func redact(v reflect.Value) map[string]any {
+	out := make(map[string]any)
+	t := v.Type()
+	for i := range t.NumField() {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		switch {
+		case field.Tag.Get("secret") == "true":
+			out[key(field)] = redacted
+		case field.Type.Kind() == reflect.Struct && !isScalar(field.Type):
+			out[key(field)] = redact(v.Field(i))
+		case field.Type == durationType:
+			out[key(field)] = v.Field(i).Interface().(fmt.Stringer).String()
+		default:
+			out[key(field)] = v.Field(i).Interface()
+		}
+	}
+	return out
+}
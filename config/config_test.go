@@ -0,0 +1,175 @@
+package config
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testNested struct {
+	Brokers []string `yaml:"brokers" env:"TEST_CONFIG_BROKERS" validate:"min=1"`
+}
+
+type testConfig struct {
+	Port     int           `yaml:"port" env:"TEST_CONFIG_PORT" default:"8080" validate:"min=1,max=65535"`
+	Timeout  time.Duration `yaml:"timeout" env:"TEST_CONFIG_TIMEOUT" default:"5s" validate:"min=1ms"`
+	Mode     string        `yaml:"mode" default:"fast" validate:"oneof=fast slow"`
+	Enabled  bool          `yaml:"enabled"`
+	Password string        `yaml:"password" env:"TEST_CONFIG_PASSWORD" secret:"true"`
+	Nested   testNested    `yaml:"nested"`
+}
+
+func writeFile(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	return path
+}
+
+func TestLoad(t *testing.T) {
+	yamlFile := writeFile(t, "app.yaml", `
+app:
+  test:
+    port: 9090
+    mode: slow
+    enabled: true
+    nested:
+      brokers: [a, b]
+`)
+	jsonFile := writeFile(t, "app.json", `{"app": {"test": {"port": 9191, "timeout": "1s"}}}`)
+
+	tests := map[string]struct {
+		files     []string
+		env       map[string]string
+		expected  testConfig
+		expectErr string
+	}{
+		"defaults only": {
+			env:      map[string]string{"TEST_CONFIG_BROKERS": "a"},
+			expected: testConfig{Port: 8080, Timeout: 5 * time.Second, Mode: "fast", Nested: testNested{Brokers: []string{"a"}}},
+		},
+		"yaml file": {
+			files:    []string{yamlFile},
+			expected: testConfig{Port: 9090, Timeout: 5 * time.Second, Mode: "slow", Enabled: true, Nested: testNested{Brokers: []string{"a", "b"}}},
+		},
+		"later json file overrides": {
+			files:    []string{yamlFile, jsonFile},
+			expected: testConfig{Port: 9191, Timeout: time.Second, Mode: "slow", Enabled: true, Nested: testNested{Brokers: []string{"a", "b"}}},
+		},
+		"env overrides files": {
+			files:    []string{yamlFile},
+			env:      map[string]string{"TEST_CONFIG_PORT": "7070", "TEST_CONFIG_BROKERS": "c, d", "TEST_CONFIG_PASSWORD": "pass"},
+			expected: testConfig{Port: 7070, Timeout: 5 * time.Second, Mode: "slow", Enabled: true, Password: "pass", Nested: testNested{Brokers: []string{"c", "d"}}},
+		},
+		"all errors reported": {
+			env: map[string]string{"TEST_CONFIG_PORT": "70000", "TEST_CONFIG_TIMEOUT": "aaa"},
+			expectErr: "app.test.timeout: env var TEST_CONFIG_TIMEOUT is not valid: time: invalid duration \"aaa\"\n" +
+				"app.test.port: must be at most 65535\n" +
+				"app.test.nested.brokers: must be at least 1",
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			require.NoError(t, SetFiles(tt.files...))
+			t.Cleanup(func() { require.NoError(t, SetFiles()) })
+			for k, v := range tt.env {
+				t.Setenv(k, v)
+			}
+
+			got := testConfig{}
+			err := Load("app.test", &got)
+			if tt.expectErr != "" {
+				require.EqualError(t, err, tt.expectErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, got)
+		})
+	}
+}
+
+func TestLoad_InvalidArguments(t *testing.T) {
+	t.Parallel()
+
+	cfg := testConfig{}
+	require.EqualError(t, Load("", &cfg), "config name is empty")
+	require.EqualError(t, Load("test", cfg), "config destination has to be a non-nil pointer to a struct")
+	require.EqualError(t, Load("test", (*testConfig)(nil)), "config destination has to be a non-nil pointer to a struct")
+}
+
+func TestSetFiles(t *testing.T) {
+	t.Parallel()
+
+	invalid := writeFile(t, "invalid.yaml", "a: [")
+
+	err := SetFiles("app.toml", filepath.Join(t.TempDir(), "missing.json"), invalid)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "config file app.toml: unsupported file extension")
+	assert.Contains(t, err.Error(), "missing.json: open")
+	assert.Contains(t, err.Error(), "invalid.yaml: yaml:")
+}
+
+func TestEffective(t *testing.T) {
+	t.Setenv("TEST_CONFIG_PASSWORD", "pass")
+	t.Setenv("TEST_CONFIG_BROKERS", "a")
+
+	cfg := testConfig{}
+	require.NoError(t, Load("effective", &cfg))
+
+	// later changes are not reflected in the effective configuration.
+	cfg.Port = 9090
+	cfg.Nested.Brokers[0] = "b"
+
+	got, ok := Effective()["effective"].(json.RawMessage)
+	require.True(t, ok)
+	assert.JSONEq(t, `{
+		"port": 8080,
+		"timeout": "5s",
+		"mode": "fast",
+		"enabled": false,
+		"password": "******",
+		"nested": {"brokers": ["a"]}
+	}`, string(got))
+}
+
+func TestRegister(t *testing.T) {
+	t.Parallel()
+
+	require.EqualError(t, Register("", testConfig{}), "config key is empty")
+	require.EqualError(t, Register("register", "config"), "config has to be a struct or a non-nil pointer to a struct")
+	require.EqualError(t, Register("register", (*testConfig)(nil)), "config has to be a struct or a non-nil pointer to a struct")
+
+	require.NoError(t, Register("register", testConfig{Port: 1}))
+	require.NoError(t, Register("register", &testConfig{Port: 2, Password: "pass"}))
+	got, ok := Effective()["register"].(json.RawMessage)
+	require.True(t, ok)
+	assert.JSONEq(t, `{"port": 2, "timeout": "0s", "mode": "", "enabled": false, "password": "******",
+		"nested": {"brokers": null}}`, string(got))
+}
+
+func TestReload(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app.yaml")
+	require.NoError(t, os.WriteFile(path, []byte("reload:\n  port: 9090\n"), 0o600))
+	require.NoError(t, SetFiles(path))
+	t.Cleanup(func() { require.NoError(t, SetFiles()) })
+	t.Setenv("TEST_CONFIG_BROKERS", "a")
+
+	cfg := testConfig{}
+	require.NoError(t, Load("reload", &cfg))
+	assert.Equal(t, 9090, cfg.Port)
+
+	require.NoError(t, os.WriteFile(path, []byte("reload:\n  port: 9191\n"), 0o600))
+	require.NoError(t, Reload())
+	require.NoError(t, Load("reload", &cfg))
+	assert.Equal(t, 9191, cfg.Port)
+
+	require.NoError(t, os.WriteFile(path, []byte("reload: ["), 0o600))
+	require.Error(t, Reload())
+	require.NoError(t, Load("reload", &cfg))
+	assert.Equal(t, 9191, cfg.Port)
+}
@@ -0,0 +1,146 @@
+package config
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var durationType = reflect.TypeFor[time.Duration]()
+
+// isScalar returns true for struct types which are set from a single value instead of per field.
+func isScalar(t reflect.Type) bool {
+	return t == reflect.TypeFor[time.Time]()
+}
+
+func setValue(v reflect.Value, raw string) error {
+	if v.Type() == durationType {
+		d, err := time.ParseDuration(raw)
+		if err != nil {
+			return err
+		}
+		v.SetInt(int64(d))
+		return nil
+	}
+
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(raw)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(raw)
+		if err != nil {
+			return err
+		}
+		v.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		i, err := strconv.ParseInt(raw, 10, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetInt(i)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		u, err := strconv.ParseUint(raw, 10, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetUint(u)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(raw, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetFloat(f)
+	case reflect.Slice:
+		if v.Type().Elem().Kind() != reflect.String {
+			return fmt.Errorf("unsupported slice type %s", v.Type())
+		}
+		var values []string
+		for value := range strings.SplitSeq(raw, ",") {
+			if value = strings.TrimSpace(value); value != "" {
+				values = append(values, value)
+			}
+		}
+		v.Set(reflect.ValueOf(values).Convert(v.Type()))
+	default:
+		return fmt.Errorf("unsupported type %s", v.Type())
+	}
+	return nil
+}
+
+func validate(name string, v reflect.Value) []error {
+	var errs []error
+	fields(name, v, func(path string, field reflect.StructField, value reflect.Value) {
+		rules := field.Tag.Get("validate")
+		if rules == "" {
+			return
+		}
+		for rule := range strings.SplitSeq(rules, ",") {
+			if err := validateRule(value, strings.TrimSpace(rule)); err != nil {
+				errs = append(errs, fmt.Errorf("%s: %w", path, err))
+			}
+		}
+	})
+	return errs
+}
+
+func validateRule(v reflect.Value, rule string) error {
+	ruleName, arg, _ := strings.Cut(rule, "=")
+	switch ruleName {
+	case "required":
+		if v.IsZero() {
+			return errors.New("is required")
+		}
+	case "min", "max":
+		c, err := compareBound(v, arg)
+		if err != nil {
+			return fmt.Errorf("invalid %s rule: %w", ruleName, err)
+		}
+		if ruleName == "min" && c < 0 {
+			return fmt.Errorf("must be at least %s", arg)
+		}
+		if ruleName == "max" && c > 0 {
+			return fmt.Errorf("must be at most %s", arg)
+		}
+	case "oneof":
+		allowed := strings.Fields(arg)
+		if !slices.Contains(allowed, fmt.Sprint(v.Interface())) {
+			return fmt.Errorf("must be one of %s", strings.Join(allowed, ", "))
+		}
+	default:
+		return fmt.Errorf("unknown validation rule %s", ruleName)
+	}
+	return nil
+}
+
+// compareBound returns -1, 0 or 1 when the value is less, equal or greater than the bound.
+// Strings and slices are compared by their length.
+func compareBound(v reflect.Value, raw string) (int, error) {
+	if v.Kind() == reflect.String || v.Kind() == reflect.Slice {
+		n, err := strconv.Atoi(raw)
+		if err != nil {
+			return 0, err
+		}
+		return cmp.Compare(v.Len(), n), nil
+	}
+
+	bound := reflect.New(v.Type()).Elem()
+	if err := setValue(bound, raw); err != nil {
+		return 0, err
+	}
+
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return cmp.Compare(v.Int(), bound.Int()), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return cmp.Compare(v.Uint(), bound.Uint()), nil
+	case reflect.Float32, reflect.Float64:
+		return cmp.Compare(v.Float(), bound.Float()), nil
+	default:
+		return 0, fmt.Errorf("unsupported type %s", v.Type())
+	}
+}
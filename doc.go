@@ -6,21 +6,33 @@ orchestrates one or more Components implementing Run(context.Context) error.
 
 Defaults and lifecycle
 
-  - A default HTTP component is started to host management endpoints:
-    /debug, /alive, /ready, /metrics. Additional routes can be added.
-  - Observability (structured logging via slog, OpenTelemetry traces and metrics)
+  - An opt-in HTTP component hosts management endpoints on its own port
+    (WithManagementServer): /alive, /ready, /debug/pprof, /debug/vars,
+    /debug/log, /debug/info and /debug/config.
+  - Observability (structured logging via slog, OpenTelemetry traces, metrics and logs)
     is set up on Service construction and shut down when the Service stops.
+  - An opt-in profiler (WithProfiler) captures CPU, heap and goroutine profiles
+    periodically and when usage thresholds are crossed.
 
 Programming model
 
   - Components: long-running units with a Run(ctx) error method. The Service
     runs each component in a goroutine and aggregates errors.
+  - Lifecycle: components may implement Initializer, Readier and Shutdowner.
+    NewManagedComponent and DependsOn declare dependencies, so components start
+    after their dependencies are ready and stop before them.
   - Synchronous components: HTTP, gRPC.
   - Asynchronous components: AMQP (RabbitMQ), Kafka, AWS SQS.
+  - Scheduled components: cron jobs on cron expressions or intervals.
+  - Worker component: bounded in-process queue for background jobs.
+  - Coordination components: leader election on Redis or SQL.
 
 Key packages
 
-  - component/http, component/grpc, component/kafka, component/amqp, component/sqs
+  - component/http, component/grpc, component/kafka, component/amqp, component/sqs,
+    component/cron, component/leader, component/worker
+  - config for typed configuration loaded from files and environment variables
+  - patrontest for running a Service in-process in end to end tests
   - client/* packages for instrumented clients (HTTP, gRPC, Kafka, AMQP, SQS,
     Elasticsearch, MongoDB, MQTT, Redis, SNS, SQL)
 
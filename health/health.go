@@ -0,0 +1,133 @@
+// Package health provides the readiness registry of a service.
+package health
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+// Checker is implemented by components that can report whether they are ready to accept work,
+// e.g. whether they are connected to their broker.
+type Checker interface {
+	IsReady() bool
+}
+
+type entry struct {
+	name    string
+	started bool
+	check   Checker
+}
+
+// Registry aggregates the readiness of the components of a service.
+// A registry is ready when all registered components are started and ready, and shutdown has not begun.
+type Registry struct {
+	mu           sync.RWMutex
+	entries      []*entry
+	shuttingDown bool
+}
+
+// NewRegistry creates an empty registry.
+func NewRegistry() *Registry {
+	return &Registry{}
+}
+
+var defaultRegistry atomic.Pointer[Registry]
+
+func init() {
+	defaultRegistry.Store(NewRegistry())
+}
+
+// Default returns the default registry, which is owned by the running service.
+func Default() *Registry {
+	return defaultRegistry.Load()
+}
+
+// SetDefault makes the registry the default one.
+func SetDefault(r *Registry) {
+	if r == nil {
+		return
+	}
+	defaultRegistry.Store(r)
+}
+
+// Register adds a component to the registry. The component is not ready until it is marked as started.
+// The check is optional and is consulted on every readiness evaluation once the component is started.
+func (r *Registry) Register(name string, check Checker) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, e := range r.entries {
+		if e.name == name {
+			e.started = false
+			e.check = check
+			return
+		}
+	}
+	r.entries = append(r.entries, &entry{name: name, check: check})
+}
+
+// SetStarted marks a registered component as started or stopped.
+func (r *Registry) SetStarted(name string, started bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, e := range r.entries {
+		if e.name == name {
+			e.started = started
+			return
+		}
+	}
+}
+
+// Shutdown marks the registry as not ready, regardless of the status of the components.
+func (r *Registry) Shutdown() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.shuttingDown = true
+}
+
+// ShuttingDown returns true if shutdown has begun.
+func (r *Registry) ShuttingDown() bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.shuttingDown
+}
+
+// Ready returns true if shutdown has not begun and all the registered components are ready.
+func (r *Registry) Ready() bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if r.shuttingDown {
+		return false
+	}
+
+	for _, e := range r.entries {
+		if !e.ready() {
+			return false
+		}
+	}
+	return true
+}
+
+// Status returns the readiness of each registered component.
+func (r *Registry) Status() map[string]bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	status := make(map[string]bool, len(r.entries))
+	for _, e := range r.entries {
+		status[e.name] = !r.shuttingDown && e.ready()
+	}
+	return status
+}
+
+func (e *entry) ready() bool {
+	if !e.started {
+		return false
+	}
+	if e.check == nil {
+		return true
+	}
+	return e.check.IsReady()
+}
@@ -0,0 +1,72 @@
+package health
+
+import (
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type stubChecker struct {
+	ready atomic.Bool
+}
+
+func (s *stubChecker) IsReady() bool {
+	return s.ready.Load()
+}
+
+func TestRegistry_Ready(t *testing.T) {
+	t.Parallel()
+
+	registry := NewRegistry()
+	assert.True(t, registry.Ready())
+
+	checker := &stubChecker{}
+	registry.Register("kafka", checker)
+	registry.Register("http", nil)
+	assert.False(t, registry.Ready())
+	assert.Equal(t, map[string]bool{"kafka": false, "http": false}, registry.Status())
+
+	registry.SetStarted("kafka", true)
+	registry.SetStarted("http", true)
+	assert.False(t, registry.Ready())
+	assert.Equal(t, map[string]bool{"kafka": false, "http": true}, registry.Status())
+
+	checker.ready.Store(true)
+	assert.True(t, registry.Ready())
+
+	registry.SetStarted("http", false)
+	assert.False(t, registry.Ready())
+	registry.SetStarted("http", true)
+	assert.True(t, registry.Ready())
+
+	registry.Shutdown()
+	assert.True(t, registry.ShuttingDown())
+	assert.False(t, registry.Ready())
+	assert.Equal(t, map[string]bool{"kafka": false, "http": false}, registry.Status())
+}
+
+func TestRegistry_RegisterTwiceResetsComponent(t *testing.T) {
+	t.Parallel()
+
+	registry := NewRegistry()
+	registry.Register("http", nil)
+	registry.SetStarted("http", true)
+	assert.True(t, registry.Ready())
+
+	registry.Register("http", nil)
+	assert.False(t, registry.Ready())
+	assert.Len(t, registry.Status(), 1)
+}
+
+func TestSetDefault(t *testing.T) {
+	original := Default()
+	t.Cleanup(func() { SetDefault(original) })
+
+	registry := NewRegistry()
+	SetDefault(registry)
+	assert.Same(t, registry, Default())
+
+	SetDefault(nil)
+	assert.Same(t, registry, Default())
+}
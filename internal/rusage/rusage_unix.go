@@ -0,0 +1,19 @@
+//go:build unix
+
+// Package rusage provides the resource usage of the process.
+package rusage
+
+import "syscall"
+
+// CPUTimes returns the user and system CPU seconds of the process, and false if they are not available.
+func CPUTimes() (float64, float64, bool) {
+	var ru syscall.Rusage
+	if err := syscall.Getrusage(syscall.RUSAGE_SELF, &ru); err != nil {
+		return 0, 0, false
+	}
+	return timevalSeconds(ru.Utime), timevalSeconds(ru.Stime), true
+}
+
+func timevalSeconds(tv syscall.Timeval) float64 {
+	return float64(tv.Sec) + float64(tv.Usec)/1e6
+}
@@ -0,0 +1,17 @@
+//go:build unix
+
+package rusage
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCPUTimes(t *testing.T) {
+	user, system, ok := CPUTimes()
+	assert.True(t, ok)
+	assert.Positive(t, user+system)
+	assert.GreaterOrEqual(t, user, 0.0)
+	assert.GreaterOrEqual(t, system, 0.0)
+}
@@ -0,0 +1,49 @@
+package patron
+
+import (
+	"fmt"
+
+	patronhttp "github.com/beatlabs/patron/component/http"
+	"github.com/beatlabs/patron/component/http/middleware"
+	"github.com/beatlabs/patron/component/http/router"
+	"github.com/beatlabs/patron/observability"
+)
+
+const managementComponentName = "patron-management"
+
+type managementConfig struct {
+	port        int
+	middlewares []middleware.Func
+}
+
+// managementComponent creates the HTTP component serving liveness, readiness, profiling,
+// expvar, log level, build info and configuration endpoints on the management port,
+// as well as the Prometheus metrics when they are exported with the Prometheus exporter.
+func (s *Service) managementComponent() (*ManagedComponent, error) {
+	var routes []*patronhttp.Route
+	routes = append(routes, patronhttp.LoggingRoutes(s.management.middlewares...)...)
+	routes = append(routes, patronhttp.BuildInfoRoutes(s.name, s.version, s.management.middlewares...)...)
+	routes = append(routes, patronhttp.ConfigRoutes(s.management.middlewares...)...)
+
+	oo := []router.OptionFunc{
+		router.WithRoutes(routes...),
+		router.WithProfiling(s.management.middlewares...),
+		router.WithExpVarProfiling(),
+	}
+	if s.observabilityCfg.MetricExporter == observability.ExporterPrometheus {
+		oo = append(oo, router.WithPrometheusMetrics())
+	}
+
+	rt, err := router.New(oo...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create management router: %w", err)
+	}
+
+	cmp, err := patronhttp.New(rt, patronhttp.WithName(managementComponentName),
+		patronhttp.WithPort(s.management.port))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create management component: %w", err)
+	}
+
+	return NewManagedComponent(managementComponentName, cmp)
+}
@@ -0,0 +1,47 @@
+package patron
+
+import (
+	"context"
+
+	patronmetric "github.com/beatlabs/patron/observability/metric"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+const (
+	packageName      = "patron"
+	actionAttribute  = "action"
+	outcomeAttribute = "outcome"
+)
+
+var (
+	componentRestartCounter metric.Int64Counter
+	reloadCounter           metric.Int64Counter
+
+	restartedActionAttr = attribute.String(actionAttribute, "restarted")
+	escalatedActionAttr = attribute.String(actionAttribute, "escalated")
+
+	succeededOutcomeAttr = attribute.String(outcomeAttribute, "succeeded")
+	failedOutcomeAttr    = attribute.String(outcomeAttribute, "failed")
+)
+
+func init() {
+	componentRestartCounter = patronmetric.Int64Counter(packageName, "component.restarts", "Component restart counter.", "1")
+	reloadCounter = patronmetric.Int64Counter(packageName, "service.reloads", "Service reload counter.", "1")
+}
+
+func componentRestartInc(ctx context.Context, name string, escalated bool) {
+	actionAttr := restartedActionAttr
+	if escalated {
+		actionAttr = escalatedActionAttr
+	}
+	componentRestartCounter.Add(ctx, 1, metric.WithAttributes(attribute.String("component", name), actionAttr))
+}
+
+func reloadInc(ctx context.Context, failed bool) {
+	outcomeAttr := succeededOutcomeAttr
+	if failed {
+		outcomeAttr = failedOutcomeAttr
+	}
+	reloadCounter.Add(ctx, 1, metric.WithAttributes(outcomeAttr))
+}
@@ -0,0 +1,279 @@
+package log
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"maps"
+	"math"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// LoggerKey is the attribute of the records holding the name of the logger returned by Named.
+const LoggerKey = "logger"
+
+// minLevel lets the handlers pass all the records, which are filtered by the levels of the loggers instead.
+const minLevel = slog.Level(math.MinInt)
+
+// levelState is replaced on every change, so that checking the level of a record does not lock.
+type levelState struct {
+	base      slog.Level
+	overrides map[string]levelOverride
+	names     map[string]struct{}
+}
+
+type levelOverride struct {
+	level     slog.Level
+	expiresAt time.Time
+}
+
+var (
+	levels   atomic.Pointer[levelState]
+	levelsMu sync.Mutex
+	// timers revert the overrides set with a TTL.
+	timers = map[string]*time.Timer{}
+)
+
+func init() {
+	levels.Store(&levelState{base: slog.LevelInfo})
+}
+
+// updateLevels replaces the level state with a modified copy. It must be called with levelsMu held.
+func updateLevels(fn func(s *levelState)) {
+	s := *levels.Load()
+	s.overrides = maps.Clone(s.overrides)
+	s.names = maps.Clone(s.names)
+	fn(&s)
+	levels.Store(&s)
+}
+
+// effectiveLevel returns the level of the logger with the given name: the level set for its name or,
+// for a dotted name like "http.router", for its closest parent, e.g. "http", then the level set for
+// all the loggers, and finally the configured level.
+func effectiveLevel(name string) slog.Level {
+	s := levels.Load()
+	if len(s.overrides) == 0 {
+		return s.base
+	}
+	for {
+		if o, ok := s.overrides[name]; ok {
+			return o.level
+		}
+		if name == "" {
+			return s.base
+		}
+		i := strings.LastIndexByte(name, '.')
+		if i == -1 {
+			name = ""
+		} else {
+			name = name[:i]
+		}
+	}
+}
+
+// SetLoggerLevel sets the level of the logger with the given name and of the loggers named after it,
+// e.g. "http" applies to "http.router" as well. An empty name sets the level of all the loggers.
+// With a positive TTL the level is reverted after it elapses.
+func SetLoggerLevel(name, lvl string, ttl time.Duration) error {
+	l, err := level(lvl)
+	if err != nil {
+		return err
+	}
+	if ttl < 0 {
+		return errors.New("negative level TTL provided")
+	}
+
+	levelsMu.Lock()
+	defer levelsMu.Unlock()
+
+	stopTimer(name)
+	o := levelOverride{level: l}
+	if ttl > 0 {
+		o.expiresAt = time.Now().Add(ttl)
+		timers[name] = time.AfterFunc(ttl, func() { revertLoggerLevel(name, o.expiresAt) })
+	}
+	updateLevels(func(s *levelState) {
+		if s.overrides == nil {
+			s.overrides = map[string]levelOverride{}
+		}
+		s.overrides[name] = o
+	})
+	return nil
+}
+
+// ResetLoggerLevel removes the level set for the logger with the given name, which follows its parent
+// or the configured level again.
+func ResetLoggerLevel(name string) {
+	levelsMu.Lock()
+	defer levelsMu.Unlock()
+
+	stopTimer(name)
+	updateLevels(func(s *levelState) {
+		delete(s.overrides, name)
+	})
+}
+
+func revertLoggerLevel(name string, expiresAt time.Time) {
+	levelsMu.Lock()
+	defer levelsMu.Unlock()
+
+	// the level might have been set again in the meantime.
+	if o, ok := levels.Load().overrides[name]; !ok || !o.expiresAt.Equal(expiresAt) {
+		return
+	}
+	delete(timers, name)
+	updateLevels(func(s *levelState) {
+		delete(s.overrides, name)
+	})
+}
+
+func stopTimer(name string) {
+	if t, ok := timers[name]; ok {
+		t.Stop()
+		delete(timers, name)
+	}
+}
+
+func setBaseLevel(l slog.Level) {
+	levelsMu.Lock()
+	defer levelsMu.Unlock()
+
+	updateLevels(func(s *levelState) {
+		s.base = l
+	})
+}
+
+// LoggerLevel is the level of a named logger.
+type LoggerLevel struct {
+	Name  string
+	Level slog.Level
+	// Set is true if the level is set for the logger itself, instead of following its parent or the configured level.
+	Set bool
+	// ExpiresAt is when the level set with a TTL is reverted, zero without a TTL.
+	ExpiresAt time.Time
+}
+
+// Levels returns the configured level and the levels of the named loggers, sorted by name.
+// The loggers are the ones created with Named and the ones a level is set for.
+// The level set for all the loggers, if any, has an empty name.
+func Levels() (slog.Level, []LoggerLevel) {
+	s := levels.Load()
+
+	names := maps.Clone(s.names)
+	if names == nil {
+		names = map[string]struct{}{}
+	}
+	for name := range s.overrides {
+		names[name] = struct{}{}
+	}
+
+	ll := make([]LoggerLevel, 0, len(names))
+	for _, name := range slices.Sorted(maps.Keys(names)) {
+		o, set := s.overrides[name]
+		ll = append(ll, LoggerLevel{Name: name, Level: effectiveLevel(name), Set: set, ExpiresAt: o.expiresAt})
+	}
+	return s.base, ll
+}
+
+// levelHandler drops the records below the level of the logger it belongs to, unless the context requests them.
+type levelHandler struct {
+	slog.Handler
+	name string
+}
+
+func (h *levelHandler) Enabled(ctx context.Context, lvl slog.Level) bool {
+	return (lvl >= effectiveLevel(h.name) || contextEnabled(ctx, lvl)) && h.Handler.Enabled(ctx, lvl)
+}
+
+func (h *levelHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &levelHandler{Handler: h.Handler.WithAttrs(attrs), name: h.name}
+}
+
+func (h *levelHandler) WithGroup(name string) slog.Handler {
+	return &levelHandler{Handler: h.Handler.WithGroup(name), name: h.name}
+}
+
+// baseHandler is the handler of the default logger, before the level of the loggers is applied.
+type baseHandler struct {
+	generation uint64
+	handler    slog.Handler
+}
+
+var base atomic.Pointer[baseHandler]
+
+func setBaseHandler(h slog.Handler) {
+	var generation uint64
+	if b := base.Load(); b != nil {
+		generation = b.generation
+	}
+	base.Store(&baseHandler{generation: generation + 1, handler: h})
+}
+
+// Named returns a logger for the component or package with the given name, e.g. "kafka" or "http.router",
+// whose level can be changed independently with SetLoggerLevel. The records carry the name in the logger attribute.
+//
+// The logger writes to the handler of the default logger configured by Setup, even if it is created before.
+func Named(name string) *slog.Logger {
+	levelsMu.Lock()
+	updateLevels(func(s *levelState) {
+		if s.names == nil {
+			s.names = map[string]struct{}{}
+		}
+		s.names[name] = struct{}{}
+	})
+	levelsMu.Unlock()
+
+	return slog.New(&namedHandler{name: name}).With(slog.String(LoggerKey, name))
+}
+
+// namedHandler resolves the base handler when it logs, replaying the attributes and groups it was created with.
+type namedHandler struct {
+	name     string
+	ops      []func(slog.Handler) slog.Handler
+	resolved atomic.Pointer[baseHandler]
+}
+
+func (h *namedHandler) handler() slog.Handler {
+	b := base.Load()
+	if b == nil {
+		// not set up yet, the default logger of the slog package is used.
+		return h.apply(slog.Default().Handler())
+	}
+	if r := h.resolved.Load(); r != nil && r.generation == b.generation {
+		return r.handler
+	}
+	hnd := h.apply(b.handler)
+	h.resolved.Store(&baseHandler{generation: b.generation, handler: hnd})
+	return hnd
+}
+
+func (h *namedHandler) apply(hnd slog.Handler) slog.Handler {
+	for _, op := range h.ops {
+		hnd = op(hnd)
+	}
+	return hnd
+}
+
+func (h *namedHandler) Enabled(ctx context.Context, lvl slog.Level) bool {
+	return (lvl >= effectiveLevel(h.name) || contextEnabled(ctx, lvl)) && h.handler().Enabled(ctx, lvl)
+}
+
+func (h *namedHandler) Handle(ctx context.Context, r slog.Record) error {
+	return h.handler().Handle(ctx, r)
+}
+
+func (h *namedHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return h.with(func(hnd slog.Handler) slog.Handler { return hnd.WithAttrs(attrs) })
+}
+
+func (h *namedHandler) WithGroup(name string) slog.Handler {
+	return h.with(func(hnd slog.Handler) slog.Handler { return hnd.WithGroup(name) })
+}
+
+func (h *namedHandler) with(op func(slog.Handler) slog.Handler) slog.Handler {
+	return &namedHandler{name: h.name, ops: append(slices.Clip(h.ops), op)}
+}
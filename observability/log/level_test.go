@@ -0,0 +1,108 @@
+package log
+
+import (
+	"bytes"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNamed(t *testing.T) {
+	// loggers are created before the setup, like package variables.
+	kafka := Named("test.kafka")
+	router := Named("test.http.router").With(slog.String("route", "/index"))
+
+	out := &bytes.Buffer{}
+	require.NoError(t, Setup(&Config{IsJSON: true, Level: "info", Output: out}))
+	t.Cleanup(func() {
+		ResetLoggerLevel("test.kafka")
+		ResetLoggerLevel("test.http")
+		require.NoError(t, Setup(&Config{Level: "info"}))
+	})
+
+	kafka.Debug("kafka debug")
+	router.Debug("router debug")
+	assert.Empty(t, out.String())
+
+	require.NoError(t, SetLoggerLevel("test.kafka", "debug", 0))
+	// the level of a parent applies to its children.
+	require.NoError(t, SetLoggerLevel("test.http", "warn", 0))
+
+	kafka.Debug("kafka debug")
+	router.Info("router info")
+	router.Warn("router warn")
+	slog.Debug("default debug")
+
+	records := decodeRecords(t, out)
+	require.Len(t, records, 2)
+	assert.Equal(t, "kafka debug", records[0][slog.MessageKey])
+	assert.Equal(t, "test.kafka", records[0][LoggerKey])
+	assert.Equal(t, "router warn", records[1][slog.MessageKey])
+	assert.Equal(t, "test.http.router", records[1][LoggerKey])
+	assert.Equal(t, "/index", records[1]["route"])
+
+	lvl, ll := Levels()
+	assert.Equal(t, slog.LevelInfo, lvl)
+	assert.Contains(t, ll, LoggerLevel{Name: "test.kafka", Level: slog.LevelDebug, Set: true})
+	assert.Contains(t, ll, LoggerLevel{Name: "test.http", Level: slog.LevelWarn, Set: true})
+	assert.Contains(t, ll, LoggerLevel{Name: "test.http.router", Level: slog.LevelWarn})
+
+	ResetLoggerLevel("test.kafka")
+	out.Reset()
+	kafka.Debug("kafka debug")
+	assert.Empty(t, out.String())
+}
+
+func TestSetLoggerLevel_TTL(t *testing.T) {
+	require.NoError(t, Setup(&Config{Level: "info"}))
+	logger := Named("test.ttl")
+	t.Cleanup(func() { ResetLoggerLevel("test.ttl") })
+
+	require.NoError(t, SetLoggerLevel("test.ttl", "debug", 50*time.Millisecond))
+	assert.True(t, logger.Enabled(t.Context(), slog.LevelDebug))
+
+	_, ll := Levels()
+	assert.Contains(t, ll, LoggerLevel{Name: "test.ttl", Level: slog.LevelDebug, Set: true, ExpiresAt: levels.Load().overrides["test.ttl"].expiresAt})
+
+	assert.Eventually(t, func() bool {
+		return !logger.Enabled(t.Context(), slog.LevelDebug)
+	}, time.Second, 10*time.Millisecond)
+}
+
+func TestSetLoggerLevel_Root(t *testing.T) {
+	require.NoError(t, Setup(&Config{Level: "info"}))
+	logger := Named("test.root")
+	t.Cleanup(func() { ResetLoggerLevel("") })
+
+	require.NoError(t, SetLoggerLevel("", "error", 0))
+	assert.False(t, logger.Enabled(t.Context(), slog.LevelWarn))
+	assert.False(t, Enabled(slog.LevelWarn))
+
+	// the configured level does not apply while the level of all the loggers is set.
+	require.NoError(t, SetLevel("debug"))
+	assert.False(t, Enabled(slog.LevelWarn))
+
+	ResetLoggerLevel("")
+	assert.True(t, logger.Enabled(t.Context(), slog.LevelDebug))
+	assert.True(t, Enabled(slog.LevelDebug))
+	require.NoError(t, SetLevel("info"))
+}
+
+func TestSetLoggerLevel_Errors(t *testing.T) {
+	tests := map[string]struct {
+		lvl         string
+		ttl         time.Duration
+		expectedErr string
+	}{
+		"invalid level": {lvl: "xxx", expectedErr: `slog: level string "xxx": unknown name`},
+		"negative ttl":  {lvl: "debug", ttl: -time.Second, expectedErr: "negative level TTL provided"},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			require.EqualError(t, SetLoggerLevel("test.errors", tt.lvl, tt.ttl), tt.expectedErr)
+		})
+	}
+}
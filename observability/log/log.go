@@ -4,9 +4,14 @@ package log
 import (
 	"context"
 	"errors"
+	"io"
 	"log/slog"
 	"os"
 	"sync"
+
+	"github.com/beatlabs/patron/observability/redact"
+	"go.opentelemetry.io/contrib/bridges/otelslog"
+	otellog "go.opentelemetry.io/otel/log"
 )
 
 // Config represents the configuration for setting up the logger.
@@ -14,6 +19,14 @@ type Config struct {
 	Attributes []slog.Attr
 	IsJSON     bool
 	Level      string
+	// Output is where the logs are written, os.Stderr if nil.
+	Output io.Writer
+	// LoggerProvider receives the records as well when set, through the OpenTelemetry slog bridge.
+	LoggerProvider otellog.LoggerProvider
+	// Sampling limits the records logged repeatedly with the same level and message, disabled when nil.
+	Sampling *SamplingConfig
+	// Redactor masks the sensitive data of the records before they are written or exported, disabled when nil.
+	Redactor *redact.Redactor
 }
 
 type ctxKey struct{}
@@ -31,15 +44,20 @@ func Setup(cfg *Config) error {
 	return setDefaultLogger(cfg)
 }
 
-// SetLevel sets the logger level.
+// SetLevel sets the logger level, which applies to the named loggers without a level set with SetLoggerLevel.
 func SetLevel(lvl string) error {
 	logCfgMu.Lock()
 	defer logCfgMu.Unlock()
 	if logCfg == nil {
 		return errors.New("logger not configured, call Setup first")
 	}
+	l, err := level(lvl)
+	if err != nil {
+		return err
+	}
 	logCfg.Level = lvl
-	return setDefaultLogger(logCfg)
+	setBaseLevel(l)
+	return nil
 }
 
 func setDefaultLogger(cfg *Config) error {
@@ -48,20 +66,68 @@ func setDefaultLogger(cfg *Config) error {
 		return err
 	}
 
+	// the records are filtered by the level of the logger they are logged with.
 	ho := &slog.HandlerOptions{
 		AddSource: true,
-		Level:     lvl,
+		Level:     minLevel,
+	}
+
+	var out io.Writer = os.Stderr
+	if cfg.Output != nil {
+		out = cfg.Output
 	}
 
 	var hnd slog.Handler
 
 	if cfg.IsJSON {
-		hnd = slog.NewJSONHandler(os.Stderr, ho)
+		hnd = slog.NewJSONHandler(out, ho)
 	} else {
-		hnd = slog.NewTextHandler(os.Stderr, ho)
+		hnd = slog.NewTextHandler(out, ho)
+	}
+
+	hnd = NewTraceHandler(hnd.WithAttrs(cfg.Attributes))
+
+	if cfg.LoggerProvider != nil {
+		// the bridge takes the trace context of the records from their context, without the trace attributes.
+		otelHnd := otelslog.NewHandler(instrumentationScope, otelslog.WithLoggerProvider(cfg.LoggerProvider),
+			otelslog.WithSource(true))
+		hnd = newFanoutHandler(hnd, otelHnd.WithAttrs(cfg.Attributes))
+	}
+
+	if cfg.Redactor != nil {
+		hnd = redact.NewHandler(hnd, cfg.Redactor)
+	}
+
+	if cfg.Sampling != nil {
+		hnd, err = NewSamplingHandler(hnd, *cfg.Sampling)
+		if err != nil {
+			return err
+		}
 	}
 
-	slog.SetDefault(slog.New(hnd.WithAttrs(cfg.Attributes)))
+	previous := base.Load()
+	setBaseHandler(hnd)
+	setBaseLevel(lvl)
+	slog.SetDefault(slog.New(&levelHandler{Handler: hnd}))
+	if previous != nil {
+		return closeHandler(previous.handler)
+	}
+	return nil
+}
+
+// Close closes the handler of the default logger set up by Setup, logging the records it holds back,
+// e.g. the summaries of the records suppressed by sampling. It is called by the service on shutdown.
+func Close() error {
+	if b := base.Load(); b != nil {
+		return closeHandler(b.handler)
+	}
+	return nil
+}
+
+func closeHandler(h slog.Handler) error {
+	if c, ok := h.(io.Closer); ok {
+		return c.Close()
+	}
 	return nil
 }
 
@@ -74,15 +140,17 @@ func level(lvl string) (slog.Level, error) {
 	return lv.Level(), nil
 }
 
-// FromContext returns the logger, if it exists in the context, or nil.
+// FromContext returns the logger, if it exists in the context, or the default one.
+// If the context has a level set with WithLevel, the logger logs the records of that level and above.
 func FromContext(ctx context.Context) *slog.Logger {
-	if l, ok := ctx.Value(ctxKey{}).(*slog.Logger); ok {
-		if l == nil {
-			return slog.Default()
-		}
-		return l
+	l, ok := ctx.Value(ctxKey{}).(*slog.Logger)
+	if !ok || l == nil {
+		l = slog.Default()
+	}
+	if cl, ok := ctx.Value(levelCtxKey{}).(contextLevel); ok {
+		return slog.New(&contextLevelHandler{Handler: l.Handler(), level: cl})
 	}
-	return slog.Default()
+	return l
 }
 
 // WithContext associates a logger to a context.
@@ -1,6 +1,7 @@
 package log
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"log/slog"
@@ -32,6 +33,15 @@ func TestSetup(t *testing.T) {
 	})
 }
 
+func TestSetup_Output(t *testing.T) {
+	out := &bytes.Buffer{}
+	require.NoError(t, Setup(&Config{IsJSON: true, Level: "info", Output: out}))
+	t.Cleanup(func() { require.NoError(t, Setup(&Config{Level: "info"})) })
+
+	slog.Info("to output")
+	assert.Contains(t, out.String(), `"msg":"to output"`)
+}
+
 func TestContext(t *testing.T) {
 	l := slog.Default()
 
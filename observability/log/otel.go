@@ -0,0 +1,105 @@
+package log
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+
+	"go.opentelemetry.io/otel/exporters/otlp/otlplog/otlploggrpc"
+	"go.opentelemetry.io/otel/exporters/otlp/otlplog/otlploghttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdoutlog"
+	"go.opentelemetry.io/otel/log/global"
+	sdklog "go.opentelemetry.io/otel/sdk/log"
+	"go.opentelemetry.io/otel/sdk/resource"
+)
+
+const instrumentationScope = "github.com/beatlabs/patron"
+
+// SetupGRPC creates a logger provider exporting with OTLP over gRPC and sets it as the global one.
+func SetupGRPC(ctx context.Context, res *resource.Resource) (*sdklog.LoggerProvider, error) {
+	exp, err := otlploggrpc.New(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return SetupWithExporter(res, exp), nil
+}
+
+// SetupHTTP creates a logger provider exporting with OTLP over HTTP and sets it as the global one.
+func SetupHTTP(ctx context.Context, res *resource.Resource) (*sdklog.LoggerProvider, error) {
+	exp, err := otlploghttp.New(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return SetupWithExporter(res, exp), nil
+}
+
+// SetupStdout creates a logger provider pretty printing the records to stdout and sets it as the global one.
+func SetupStdout(res *resource.Resource) (*sdklog.LoggerProvider, error) {
+	exp, err := stdoutlog.New(stdoutlog.WithPrettyPrint())
+	if err != nil {
+		return nil, err
+	}
+
+	return SetupWithExporter(res, exp), nil
+}
+
+// SetupWithExporter creates a logger provider batching the records to the given exporter
+// and sets it as the global one.
+func SetupWithExporter(res *resource.Resource, exp sdklog.Exporter) *sdklog.LoggerProvider {
+	lp := sdklog.NewLoggerProvider(
+		sdklog.WithResource(res),
+		sdklog.WithProcessor(sdklog.NewBatchProcessor(exp)),
+	)
+	global.SetLoggerProvider(lp)
+	return lp
+}
+
+// fanoutHandler passes the records to all the handlers enabled for them.
+type fanoutHandler struct {
+	handlers []slog.Handler
+}
+
+func newFanoutHandler(hh ...slog.Handler) *fanoutHandler {
+	return &fanoutHandler{handlers: hh}
+}
+
+func (h *fanoutHandler) Enabled(ctx context.Context, lvl slog.Level) bool {
+	for _, hnd := range h.handlers {
+		if hnd.Enabled(ctx, lvl) {
+			return true
+		}
+	}
+	return false
+}
+
+func (h *fanoutHandler) Handle(ctx context.Context, r slog.Record) error {
+	var errs []error
+	for _, hnd := range h.handlers {
+		if !hnd.Enabled(ctx, r.Level) {
+			continue
+		}
+		// handlers may add attributes to the record, so each gets its own copy.
+		if err := hnd.Handle(ctx, r.Clone()); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (h *fanoutHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	hh := make([]slog.Handler, 0, len(h.handlers))
+	for _, hnd := range h.handlers {
+		hh = append(hh, hnd.WithAttrs(attrs))
+	}
+	return newFanoutHandler(hh...)
+}
+
+func (h *fanoutHandler) WithGroup(name string) slog.Handler {
+	hh := make([]slog.Handler, 0, len(h.handlers))
+	for _, hnd := range h.handlers {
+		hh = append(hh, hnd.WithGroup(name))
+	}
+	return newFanoutHandler(hh...)
+}
@@ -0,0 +1,106 @@
+package log
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	otellog "go.opentelemetry.io/otel/log"
+	sdklog "go.opentelemetry.io/otel/sdk/log"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+func TestSetup_LoggerProvider(t *testing.T) {
+	exp := &recordExporter{}
+	lp := sdklog.NewLoggerProvider(sdklog.WithProcessor(sdklog.NewSimpleProcessor(exp)))
+	out := &bytes.Buffer{}
+	require.NoError(t, Setup(&Config{
+		Attributes:     []slog.Attr{slog.String("service", "test")},
+		IsJSON:         true,
+		Level:          "info",
+		Output:         out,
+		LoggerProvider: lp,
+	}))
+	t.Cleanup(func() { require.NoError(t, Setup(&Config{Level: "info"})) })
+
+	tp := sdktrace.NewTracerProvider()
+	ctx, sp := tp.Tracer("test").Start(context.Background(), "test")
+	defer sp.End()
+
+	slog.DebugContext(ctx, "below level")
+	slog.InfoContext(ctx, "exported", slog.String("key", "value"))
+
+	assert.Contains(t, out.String(), `"msg":"exported"`)
+	assert.NotContains(t, out.String(), "below level")
+
+	records := exp.Records()
+	require.Len(t, records, 1)
+	rec := records[0]
+	assert.Equal(t, "exported", rec.Body().AsString())
+	assert.Equal(t, otellog.SeverityInfo, rec.Severity())
+	assert.Equal(t, sp.SpanContext().TraceID(), rec.TraceID())
+	assert.Equal(t, sp.SpanContext().SpanID(), rec.SpanID())
+	assert.Equal(t, instrumentationScope, rec.InstrumentationScope().Name)
+
+	attrs := map[string]string{}
+	rec.WalkAttributes(func(kv otellog.KeyValue) bool {
+		attrs[kv.Key] = kv.Value.String()
+		return true
+	})
+	assert.Equal(t, "test", attrs["service"])
+	assert.Equal(t, "value", attrs["key"])
+	// the trace context is part of the record, not of its attributes.
+	assert.NotContains(t, attrs, traceIDKey)
+
+	require.NoError(t, SetLevel("debug"))
+	slog.Debug("after level change")
+	assert.Len(t, exp.Records(), 2)
+}
+
+func TestSetupWithExporter(t *testing.T) {
+	exp := &recordExporter{}
+	lp := SetupWithExporter(resource.Empty(), exp)
+
+	lp.Logger("test").Emit(context.Background(), otellog.Record{})
+	require.NoError(t, lp.Shutdown(context.Background()))
+
+	assert.Len(t, exp.Records(), 1)
+	assert.True(t, exp.shutdown)
+}
+
+type recordExporter struct {
+	mu       sync.Mutex
+	records  []sdklog.Record
+	shutdown bool
+}
+
+func (e *recordExporter) Export(_ context.Context, records []sdklog.Record) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	for _, r := range records {
+		e.records = append(e.records, r.Clone())
+	}
+	return nil
+}
+
+func (e *recordExporter) Shutdown(context.Context) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.shutdown = true
+	return nil
+}
+
+func (e *recordExporter) ForceFlush(context.Context) error {
+	return nil
+}
+
+func (e *recordExporter) Records() []sdklog.Record {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return append([]sdklog.Record(nil), e.records...)
+}
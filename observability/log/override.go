@@ -0,0 +1,160 @@
+package log
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/beatlabs/patron/correlation"
+)
+
+const (
+	// LevelHeader is the header, or message attribute, requesting the level of the logs of a single request or message, e.g. debug.
+	LevelHeader = "X-Log-Level"
+	// LevelSignatureHeader is the header, or message attribute, holding the signature of the level requested with LevelHeader.
+	LevelSignatureHeader = "X-Log-Level-Signature"
+)
+
+type levelCtxKey struct{}
+
+// contextLevel is the level requested for a request or message, along with the header values propagating it downstream.
+type contextLevel struct {
+	level     slog.Level
+	value     string
+	signature string
+}
+
+// WithLevel returns a context whose records of the given level and above are logged, whatever the level of the
+// logger, when logged with the context or with the logger returned by FromContext.
+// The level is propagated downstream by the clients configured to, e.g. in the LevelHeader of the HTTP requests.
+func WithLevel(ctx context.Context, lvl slog.Level) context.Context {
+	return context.WithValue(ctx, levelCtxKey{}, contextLevel{level: lvl, value: strings.ToLower(lvl.String())})
+}
+
+// LevelFromContext returns the level set in the context with WithLevel or LevelOverride.Context, if any.
+func LevelFromContext(ctx context.Context) (slog.Level, bool) {
+	cl, ok := ctx.Value(levelCtxKey{}).(contextLevel)
+	return cl.level, ok
+}
+
+// PropagatedLevel returns the values of the LevelHeader and LevelSignatureHeader propagating the level set in the context,
+// if any. The signature is empty for a level set with WithLevel.
+func PropagatedLevel(ctx context.Context) (value, signature string, ok bool) {
+	cl, ok := ctx.Value(levelCtxKey{}).(contextLevel)
+	return cl.value, cl.signature, ok
+}
+
+func contextEnabled(ctx context.Context, lvl slog.Level) bool {
+	cl, ok := ctx.Value(levelCtxKey{}).(contextLevel)
+	return ok && lvl >= cl.level
+}
+
+// contextLevelHandler applies the level of a context to the records logged without it.
+type contextLevelHandler struct {
+	slog.Handler
+	level contextLevel
+}
+
+func (h *contextLevelHandler) Enabled(ctx context.Context, lvl slog.Level) bool {
+	if _, ok := ctx.Value(levelCtxKey{}).(contextLevel); !ok {
+		ctx = context.WithValue(ctx, levelCtxKey{}, h.level)
+	}
+	return h.Handler.Enabled(ctx, lvl)
+}
+
+func (h *contextLevelHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &contextLevelHandler{Handler: h.Handler.WithAttrs(attrs), level: h.level}
+}
+
+func (h *contextLevelHandler) WithGroup(name string) slog.Handler {
+	return &contextLevelHandler{Handler: h.Handler.WithGroup(name), level: h.level}
+}
+
+// LevelOverrideConfig configures the levels a request or message can request with the LevelHeader.
+type LevelOverrideConfig struct {
+	// Levels are the levels that can be requested, e.g. "debug".
+	Levels []string
+	// Secret, when set, requires the LevelSignatureHeader to hold an unexpired signature of the LevelHeader value
+	// for the correlation ID of the request or message, see SignLevel.
+	Secret []byte
+}
+
+// LevelOverride honours the levels requested by requests or messages with the LevelHeader.
+type LevelOverride struct {
+	levels map[slog.Level]struct{}
+	secret []byte
+}
+
+// NewLevelOverride returns a LevelOverride honouring the allowed levels, signed with the secret if any.
+func NewLevelOverride(cfg LevelOverrideConfig) (*LevelOverride, error) {
+	if len(cfg.Levels) == 0 {
+		return nil, errors.New("no log levels allowed")
+	}
+
+	levels := make(map[slog.Level]struct{}, len(cfg.Levels))
+	for _, lvl := range cfg.Levels {
+		l, err := level(lvl)
+		if err != nil {
+			return nil, fmt.Errorf("invalid allowed log level: %w", err)
+		}
+		levels[l] = struct{}{}
+	}
+
+	return &LevelOverride{levels: levels, secret: cfg.Secret}, nil
+}
+
+// Context returns a context with the level requested by the values of the LevelHeader and LevelSignatureHeader,
+// see WithLevel. The context is returned as is if no level is requested, or the level is not allowed or not signed
+// for the correlation ID of the context, or the signature expired.
+func (o *LevelOverride) Context(ctx context.Context, value, signature string) context.Context {
+	if value == "" {
+		return ctx
+	}
+
+	lvl, err := level(value)
+	if err != nil {
+		return ctx
+	}
+	if _, ok := o.levels[lvl]; !ok {
+		return ctx
+	}
+	if len(o.secret) > 0 && !o.verify(value, correlation.IDFromContext(ctx), signature) {
+		return ctx
+	}
+
+	return context.WithValue(ctx, levelCtxKey{}, contextLevel{level: lvl, value: value, signature: signature})
+}
+
+func (o *LevelOverride) verify(value, correlationID, signature string) bool {
+	expires, mac, ok := strings.Cut(signature, ".")
+	if !ok {
+		return false
+	}
+	unix, err := strconv.ParseInt(expires, 10, 64)
+	if err != nil || !time.Now().Before(time.Unix(unix, 0)) {
+		return false
+	}
+	return hmac.Equal([]byte(mac), []byte(levelMAC(o.secret, value, correlationID, expires)))
+}
+
+// SignLevel returns the value of the LevelSignatureHeader for the given LevelHeader value and secret, valid for the
+// request or message with the given correlation ID, and the ones it propagates to, until the expiry.
+// The signature is the expiry in Unix seconds and the hex encoded HMAC-SHA256 of the value, the correlation ID and
+// the expiry, separated by a dot.
+func SignLevel(secret []byte, value, correlationID string, expiry time.Time) string {
+	expires := strconv.FormatInt(expiry.Unix(), 10)
+	return expires + "." + levelMAC(secret, value, correlationID, expires)
+}
+
+func levelMAC(secret []byte, value, correlationID, expires string) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(value + "\n" + correlationID + "\n" + expires))
+	return hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,112 @@
+package log
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/beatlabs/patron/correlation"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewLevelOverride(t *testing.T) {
+	tests := map[string]struct {
+		cfg         LevelOverrideConfig
+		expectedErr string
+	}{
+		"success":       {cfg: LevelOverrideConfig{Levels: []string{"debug"}, Secret: []byte("secret")}},
+		"no levels":     {cfg: LevelOverrideConfig{}, expectedErr: "no log levels allowed"},
+		"invalid level": {cfg: LevelOverrideConfig{Levels: []string{"xxx"}}, expectedErr: `invalid allowed log level: slog: level string "xxx": unknown name`},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, err := NewLevelOverride(tt.cfg)
+			if tt.expectedErr != "" {
+				require.EqualError(t, err, tt.expectedErr)
+				assert.Nil(t, got)
+			} else {
+				require.NoError(t, err)
+				assert.NotNil(t, got)
+			}
+		})
+	}
+}
+
+func TestLevelOverride_Context(t *testing.T) {
+	allowed, err := NewLevelOverride(LevelOverrideConfig{Levels: []string{"debug"}})
+	require.NoError(t, err)
+	signed, err := NewLevelOverride(LevelOverrideConfig{Levels: []string{"debug"}, Secret: []byte("secret")})
+	require.NoError(t, err)
+
+	secret := []byte("secret")
+	expiry := time.Now().Add(time.Minute)
+
+	tests := map[string]struct {
+		override  *LevelOverride
+		value     string
+		signature string
+		expected  bool
+	}{
+		"allowed":              {override: allowed, value: "debug", expected: true},
+		"allowed uppercase":    {override: allowed, value: "DEBUG", expected: true},
+		"not requested":        {override: allowed},
+		"not allowed":          {override: allowed, value: "warn"},
+		"invalid":              {override: allowed, value: "xxx"},
+		"signed":               {override: signed, value: "debug", signature: SignLevel(secret, "debug", "cor-1", expiry), expected: true},
+		"not signed":           {override: signed, value: "debug"},
+		"wrong secret":         {override: signed, value: "debug", signature: SignLevel([]byte("other"), "debug", "cor-1", expiry)},
+		"other level":          {override: signed, value: "debug", signature: SignLevel(secret, "info", "cor-1", expiry)},
+		"other correlation ID": {override: signed, value: "debug", signature: SignLevel(secret, "debug", "cor-2", expiry)},
+		"expired":              {override: signed, value: "debug", signature: SignLevel(secret, "debug", "cor-1", time.Now().Add(-time.Second))},
+		"tampered expiry":      {override: signed, value: "debug", signature: "9999999999" + SignLevel(secret, "debug", "cor-1", expiry)[10:]},
+		"malformed signature":  {override: signed, value: "debug", signature: "xxx"},
+		"malformed expiry":     {override: signed, value: "debug", signature: "xxx.yyy"},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			ctx := correlation.ContextWithID(context.Background(), "cor-1")
+			ctx = tt.override.Context(ctx, tt.value, tt.signature)
+
+			lvl, ok := LevelFromContext(ctx)
+			assert.Equal(t, tt.expected, ok)
+			value, signature, ok := PropagatedLevel(ctx)
+			assert.Equal(t, tt.expected, ok)
+			if tt.expected {
+				assert.Equal(t, slog.LevelDebug, lvl)
+				assert.Equal(t, tt.value, value)
+				assert.Equal(t, tt.signature, signature)
+			}
+		})
+	}
+}
+
+func TestWithLevel(t *testing.T) {
+	out := &bytes.Buffer{}
+	require.NoError(t, Setup(&Config{IsJSON: true, Level: "info", Output: out}))
+	t.Cleanup(func() { require.NoError(t, Setup(&Config{Level: "info"})) })
+	named := Named("test.override")
+
+	ctx := WithLevel(context.Background(), slog.LevelDebug)
+	value, signature, ok := PropagatedLevel(ctx)
+	assert.True(t, ok)
+	assert.Equal(t, "debug", value)
+	assert.Empty(t, signature)
+
+	slog.Debug("without context")
+	slog.DebugContext(ctx, "default with context")
+	named.DebugContext(ctx, "named with context")
+	FromContext(ctx).Debug("from context")
+	FromContext(WithContext(ctx, named.With(slog.String("key", "value")))).Debug("named from context")
+	FromContext(context.Background()).Debug("from context without level")
+
+	records := decodeRecords(t, out)
+	require.Len(t, records, 4)
+	assert.Equal(t, "default with context", records[0][slog.MessageKey])
+	assert.Equal(t, "named with context", records[1][slog.MessageKey])
+	assert.Equal(t, "from context", records[2][slog.MessageKey])
+	assert.Equal(t, "named from context", records[3][slog.MessageKey])
+	assert.Equal(t, "value", records[3]["key"])
+}
@@ -0,0 +1,225 @@
+package log
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"sync"
+	"time"
+)
+
+const (
+	suppressedMessage = "suppressed repeated log records"
+	suppressedMsgKey  = "suppressed_msg"
+	suppressedKey     = "suppressed"
+	windowKey         = "window"
+)
+
+// SamplingConfig limits the records logged repeatedly with the same level and message.
+// In every window the first records of a level and message are logged, then every Nth one, and the rest
+// are suppressed. Once the window of a suppressing level and message elapses, a record with the count
+// of the suppressed ones is logged, at the latest one window later, and on Close for the windows in progress.
+type SamplingConfig struct {
+	// Window is the period the records are counted in.
+	Window time.Duration
+	// First is the number of records of a level and message logged in a window.
+	First int
+	// Thereafter logs every Nth record of a level and message after the first ones in a window,
+	// 0 suppresses them all.
+	Thereafter int
+}
+
+// Validate returns the errors of the configuration.
+func (c SamplingConfig) Validate() error {
+	var errs []error
+	if c.Window <= 0 {
+		errs = append(errs, errors.New("sampling window must be positive"))
+	}
+	if c.First <= 0 {
+		errs = append(errs, errors.New("sampling first must be positive"))
+	}
+	if c.Thereafter < 0 {
+		errs = append(errs, errors.New("sampling thereafter must not be negative"))
+	}
+	return errors.Join(errs...)
+}
+
+type samplingKey struct {
+	level slog.Level
+	msg   string
+}
+
+type samplingEntry struct {
+	start      time.Time
+	count      int
+	suppressed int
+	// handler logs the summary, with the attributes of the last record handled.
+	handler slog.Handler
+}
+
+// sampler is shared by a handler and the handlers derived from it with attributes or groups.
+type sampler struct {
+	cfg       SamplingConfig
+	now       func() time.Time
+	mu        sync.Mutex
+	entries   map[samplingKey]*samplingEntry
+	lastSweep time.Time
+	stop      chan struct{}
+	stopOnce  sync.Once
+	done      chan struct{}
+	closeErr  error
+}
+
+type samplingHandler struct {
+	slog.Handler
+	sampler *sampler
+}
+
+// NewSamplingHandler wraps a handler, sampling the records logged repeatedly with the same level and message.
+// The summaries of the elapsed windows are logged every window, even if no record is logged anymore, until the
+// handler is closed with its Close method, which logs the summaries of the windows in progress.
+// It returns an error if the configuration is invalid.
+func NewSamplingHandler(h slog.Handler, cfg SamplingConfig) (slog.Handler, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	s := &sampler{
+		cfg:     cfg,
+		now:     time.Now,
+		entries: map[samplingKey]*samplingEntry{},
+		stop:    make(chan struct{}),
+		done:    make(chan struct{}),
+	}
+	go s.run()
+	return &samplingHandler{Handler: h, sampler: s}, nil
+}
+
+func (h *samplingHandler) Handle(ctx context.Context, r slog.Record) error {
+	now := h.sampler.now()
+	key := samplingKey{level: r.Level, msg: r.Message}
+
+	h.sampler.mu.Lock()
+	summaries := h.sampler.sweep(now)
+	e, ok := h.sampler.entries[key]
+	if ok && now.Sub(e.start) >= h.sampler.cfg.Window {
+		summaries = appendSummary(summaries, key, e, now, h.sampler.cfg.Window)
+		ok = false
+	}
+	if !ok {
+		e = &samplingEntry{start: now}
+		h.sampler.entries[key] = e
+	}
+	e.handler = h.Handler
+	e.count++
+	logged := e.count <= h.sampler.cfg.First ||
+		(h.sampler.cfg.Thereafter > 0 && (e.count-h.sampler.cfg.First)%h.sampler.cfg.Thereafter == 0)
+	if !logged {
+		e.suppressed++
+	}
+	h.sampler.mu.Unlock()
+
+	var errs []error
+	for _, s := range summaries {
+		errs = append(errs, s.handler.Handle(ctx, s.record))
+	}
+	if logged {
+		errs = append(errs, h.Handler.Handle(ctx, r))
+	}
+	return errors.Join(errs...)
+}
+
+// Close stops logging the summaries of the elapsed windows and logs the ones of the windows in progress.
+// It is shared by the handlers derived with attributes or groups, and only the first call has an effect.
+func (h *samplingHandler) Close() error {
+	h.sampler.stopOnce.Do(func() {
+		close(h.sampler.stop)
+		<-h.sampler.done
+	})
+	return h.sampler.closeErr
+}
+
+func (h *samplingHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &samplingHandler{Handler: h.Handler.WithAttrs(attrs), sampler: h.sampler}
+}
+
+func (h *samplingHandler) WithGroup(name string) slog.Handler {
+	return &samplingHandler{Handler: h.Handler.WithGroup(name), sampler: h.sampler}
+}
+
+type summary struct {
+	handler slog.Handler
+	record  slog.Record
+}
+
+// run logs the summaries of the elapsed windows every window, so that they are logged even if the records stop,
+// and the ones of the windows in progress once stopped.
+func (s *sampler) run() {
+	defer close(s.done)
+
+	ticker := time.NewTicker(s.cfg.Window)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			s.mu.Lock()
+			summaries := s.expire(s.now(), false)
+			s.mu.Unlock()
+			_ = handleSummaries(summaries)
+		case <-s.stop:
+			s.mu.Lock()
+			summaries := s.expire(s.now(), true)
+			s.mu.Unlock()
+			s.closeErr = handleSummaries(summaries)
+			return
+		}
+	}
+}
+
+// sweep removes the entries whose window elapsed and returns the summaries of the ones that suppressed records.
+// The entries are swept at most once per window, so that logging does not walk them on every record;
+// the entry of a handled record is renewed on its own.
+// It must be called with the lock held.
+func (s *sampler) sweep(now time.Time) []summary {
+	if now.Sub(s.lastSweep) < s.cfg.Window {
+		return nil
+	}
+	return s.expire(now, false)
+}
+
+// expire removes the entries whose window elapsed, or all of them, and returns the summaries of the ones that
+// suppressed records. It must be called with the lock held.
+func (s *sampler) expire(now time.Time, all bool) []summary {
+	s.lastSweep = now
+
+	var summaries []summary
+	for key, e := range s.entries {
+		if !all && now.Sub(e.start) < s.cfg.Window {
+			continue
+		}
+		delete(s.entries, key)
+		summaries = appendSummary(summaries, key, e, now, min(now.Sub(e.start), s.cfg.Window))
+	}
+	return summaries
+}
+
+func handleSummaries(summaries []summary) error {
+	var errs []error
+	for _, s := range summaries {
+		errs = append(errs, s.handler.Handle(context.Background(), s.record))
+	}
+	return errors.Join(errs...)
+}
+
+func appendSummary(summaries []summary, key samplingKey, e *samplingEntry, now time.Time, window time.Duration) []summary {
+	if e.suppressed == 0 {
+		return summaries
+	}
+	r := slog.NewRecord(now, key.level, suppressedMessage, 0)
+	r.AddAttrs(
+		slog.String(suppressedMsgKey, key.msg),
+		slog.Int(suppressedKey, e.suppressed),
+		slog.Duration(windowKey, window),
+	)
+	return append(summaries, summary{handler: e.handler, record: r})
+}
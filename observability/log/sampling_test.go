@@ -0,0 +1,227 @@
+package log
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewSamplingHandler_InvalidConfig(t *testing.T) {
+	tests := map[string]struct {
+		cfg         SamplingConfig
+		expectedErr string
+	}{
+		"missing window":      {cfg: SamplingConfig{First: 1}, expectedErr: "sampling window must be positive"},
+		"missing first":       {cfg: SamplingConfig{Window: time.Second}, expectedErr: "sampling first must be positive"},
+		"negative thereafter": {cfg: SamplingConfig{Window: time.Second, First: 1, Thereafter: -1}, expectedErr: "sampling thereafter must not be negative"},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, err := NewSamplingHandler(slog.DiscardHandler, tt.cfg)
+			require.EqualError(t, err, tt.expectedErr)
+			assert.Nil(t, got)
+		})
+	}
+}
+
+func TestSamplingHandler_Sampling(t *testing.T) {
+	tests := map[string]struct {
+		first      int
+		thereafter int
+		expected   int
+	}{
+		"first only":       {first: 3, expected: 3},
+		"first thereafter": {first: 2, thereafter: 3, expected: 4},
+		"all":              {first: 1, thereafter: 1, expected: 10},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			logger, out, _ := newSamplingLogger(t, SamplingConfig{Window: time.Second, First: tt.first, Thereafter: tt.thereafter})
+
+			for range 10 {
+				logger.Error("fetch error")
+			}
+			logger.Error("another error")
+			logger.Info("fetch error")
+
+			records := decodeRecords(t, out)
+			assert.Len(t, records, tt.expected+2)
+		})
+	}
+}
+
+func TestSamplingHandler_Summary(t *testing.T) {
+	logger, out, clock := newSamplingLogger(t, SamplingConfig{Window: time.Second, First: 1})
+
+	for range 5 {
+		logger.Error("fetch error", slog.String("topic", "orders"))
+	}
+	logger.Warn("retrying")
+	logger.Warn("retrying")
+
+	clock.Add(time.Second)
+	logger.Error("fetch error", slog.String("topic", "orders"))
+
+	records := decodeRecords(t, out)
+	require.Len(t, records, 5)
+	assert.Equal(t, "fetch error", records[0][slog.MessageKey])
+	assert.Equal(t, "retrying", records[1][slog.MessageKey])
+
+	// the summaries of the elapsed windows are logged before the record renewing its window.
+	summaries := map[string]map[string]any{}
+	for _, r := range records[2:4] {
+		assert.Equal(t, suppressedMessage, r[slog.MessageKey])
+		summaries[r[suppressedMsgKey].(string)] = r
+	}
+	assert.InDelta(t, 4, summaries["fetch error"][suppressedKey], 0)
+	assert.Equal(t, "ERROR", summaries["fetch error"][slog.LevelKey])
+	assert.Equal(t, "service", summaries["fetch error"]["srv"])
+	assert.InDelta(t, 1, summaries["retrying"][suppressedKey], 0)
+	assert.Equal(t, "WARN", summaries["retrying"][slog.LevelKey])
+	assert.Equal(t, "fetch error", records[4][slog.MessageKey])
+}
+
+func TestSamplingHandler_SummaryWithoutLaterRecords(t *testing.T) {
+	out := &syncBuffer{}
+	hnd, err := NewSamplingHandler(slog.NewJSONHandler(out, nil), SamplingConfig{Window: 20 * time.Millisecond, First: 1})
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, hnd.(*samplingHandler).Close()) })
+	logger := slog.New(hnd)
+
+	for range 3 {
+		logger.Error("fetch error")
+	}
+
+	// the summary is logged once the window elapses, without any record logged afterwards.
+	assert.Eventually(t, func() bool { return len(out.records(t)) == 2 }, time.Second, time.Millisecond)
+	summary := out.records(t)[1]
+	assert.Equal(t, suppressedMessage, summary[slog.MessageKey])
+	assert.InDelta(t, 2, summary[suppressedKey], 0)
+}
+
+func TestSamplingHandler_Close(t *testing.T) {
+	logger, out, clock := newSamplingLogger(t, SamplingConfig{Window: time.Minute, First: 1})
+
+	for range 3 {
+		logger.Error("fetch error")
+	}
+	logger.Warn("retrying")
+	clock.Add(10 * time.Second)
+
+	// the summaries of the windows in progress are logged on close.
+	require.NoError(t, logger.Handler().(*samplingHandler).Close())
+	require.NoError(t, logger.Handler().(*samplingHandler).Close())
+	records := decodeRecords(t, out)
+	require.Len(t, records, 3)
+	assert.Equal(t, suppressedMessage, records[2][slog.MessageKey])
+	assert.Equal(t, "fetch error", records[2][suppressedMsgKey])
+	assert.InDelta(t, 2, records[2][suppressedKey], 0)
+	assert.InDelta(t, float64(10*time.Second), records[2][windowKey], 0)
+}
+
+func TestSamplingHandler_SharedByDerivedHandlers(t *testing.T) {
+	logger, out, _ := newSamplingLogger(t, SamplingConfig{Window: time.Second, First: 1})
+
+	logger.Error("fetch error")
+	logger.With(slog.String("topic", "orders")).Error("fetch error")
+	logger.WithGroup("kafka").Error("fetch error")
+
+	assert.Len(t, decodeRecords(t, out), 1)
+}
+
+func TestSetup_Sampling(t *testing.T) {
+	out := &bytes.Buffer{}
+	require.EqualError(t, Setup(&Config{Level: "info", Sampling: &SamplingConfig{}}),
+		"sampling window must be positive\nsampling first must be positive")
+
+	require.NoError(t, Setup(&Config{
+		IsJSON:   true,
+		Level:    "info",
+		Output:   out,
+		Sampling: &SamplingConfig{Window: time.Minute, First: 2},
+	}))
+	t.Cleanup(func() { require.NoError(t, Setup(&Config{Level: "info"})) })
+
+	for range 5 {
+		slog.Error("failed to receive messages")
+	}
+	assert.Len(t, decodeRecords(t, out), 2)
+
+	// the summary is logged when the logger is closed.
+	require.NoError(t, Close())
+	records := decodeRecords(t, out)
+	require.Len(t, records, 3)
+	assert.InDelta(t, 3, records[2][suppressedKey], 0)
+}
+
+func TestSamplingHandler_Enabled(t *testing.T) {
+	hnd, err := NewSamplingHandler(slog.NewJSONHandler(&bytes.Buffer{}, &slog.HandlerOptions{Level: slog.LevelWarn}),
+		SamplingConfig{Window: time.Second, First: 1})
+	require.NoError(t, err)
+
+	assert.False(t, hnd.Enabled(context.Background(), slog.LevelInfo))
+	assert.True(t, hnd.Enabled(context.Background(), slog.LevelError))
+}
+
+type fakeClock struct {
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time {
+	return c.now
+}
+
+func (c *fakeClock) Add(d time.Duration) {
+	c.now = c.now.Add(d)
+}
+
+func newSamplingLogger(t *testing.T, cfg SamplingConfig) (*slog.Logger, *bytes.Buffer, *fakeClock) {
+	t.Helper()
+	out := &bytes.Buffer{}
+	hnd, err := NewSamplingHandler(slog.NewJSONHandler(out, nil).WithAttrs([]slog.Attr{slog.String("srv", "service")}), cfg)
+	require.NoError(t, err)
+
+	clock := &fakeClock{now: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}
+	hnd.(*samplingHandler).sampler.mu.Lock()
+	hnd.(*samplingHandler).sampler.now = clock.Now
+	hnd.(*samplingHandler).sampler.mu.Unlock()
+	t.Cleanup(func() { require.NoError(t, hnd.(*samplingHandler).Close()) })
+	return slog.New(hnd), out, clock
+}
+
+type syncBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *syncBuffer) records(t *testing.T) []map[string]any {
+	t.Helper()
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return decodeRecords(t, &b.buf)
+}
+
+func decodeRecords(t *testing.T, out *bytes.Buffer) []map[string]any {
+	t.Helper()
+	var records []map[string]any
+	dec := json.NewDecoder(bytes.NewReader(out.Bytes()))
+	for dec.More() {
+		r := map[string]any{}
+		require.NoError(t, dec.Decode(&r))
+		records = append(records, r)
+	}
+	return records
+}
@@ -0,0 +1,47 @@
+package log
+
+import (
+	"context"
+	"log/slog"
+
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	traceIDKey      = "trace_id"
+	spanIDKey       = "span_id"
+	traceSampledKey = "trace_sampled"
+)
+
+type traceHandler struct {
+	slog.Handler
+}
+
+// NewTraceHandler wraps a handler, adding the trace_id, span_id and trace_sampled attributes of the span
+// found in the context passed to the log call, e.g. with slog.InfoContext or slog.Logger.LogAttrs.
+// Records logged without a valid span in their context are left intact.
+func NewTraceHandler(h slog.Handler) slog.Handler {
+	if _, ok := h.(*traceHandler); ok {
+		return h
+	}
+	return &traceHandler{Handler: h}
+}
+
+func (h *traceHandler) Handle(ctx context.Context, r slog.Record) error {
+	if sc := trace.SpanContextFromContext(ctx); sc.IsValid() {
+		r.AddAttrs(
+			slog.String(traceIDKey, sc.TraceID().String()),
+			slog.String(spanIDKey, sc.SpanID().String()),
+			slog.Bool(traceSampledKey, sc.IsSampled()),
+		)
+	}
+	return h.Handler.Handle(ctx, r)
+}
+
+func (h *traceHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &traceHandler{Handler: h.Handler.WithAttrs(attrs)}
+}
+
+func (h *traceHandler) WithGroup(name string) slog.Handler {
+	return &traceHandler{Handler: h.Handler.WithGroup(name)}
+}
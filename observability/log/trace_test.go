@@ -0,0 +1,61 @@
+package log
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestTraceHandler(t *testing.T) {
+	t.Parallel()
+
+	sc := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    trace.TraceID{0x01},
+		SpanID:     trace.SpanID{0x02},
+		TraceFlags: trace.FlagsSampled,
+	})
+
+	tests := map[string]struct {
+		ctx      context.Context
+		expected map[string]any
+	}{
+		"with span": {
+			ctx: trace.ContextWithSpanContext(context.Background(), sc),
+			expected: map[string]any{
+				"trace_id":      "01000000000000000000000000000000",
+				"span_id":       "0200000000000000",
+				"trace_sampled": true,
+			},
+		},
+		"without span": {ctx: context.Background()},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			out := &bytes.Buffer{}
+			logger := slog.New(NewTraceHandler(slog.NewJSONHandler(out, nil))).With(slog.String("srv", "test"))
+			logger.InfoContext(tt.ctx, "message")
+
+			got := map[string]any{}
+			require.NoError(t, json.Unmarshal(out.Bytes(), &got))
+			assert.Equal(t, "test", got["srv"])
+			for _, key := range []string{traceIDKey, spanIDKey, traceSampledKey} {
+				assert.Equal(t, tt.expected[key], got[key])
+			}
+		})
+	}
+}
+
+func TestNewTraceHandler_DoesNotWrapTwice(t *testing.T) {
+	t.Parallel()
+
+	h := NewTraceHandler(slog.NewTextHandler(&bytes.Buffer{}, nil))
+	assert.Same(t, h, NewTraceHandler(h))
+}
@@ -6,22 +6,64 @@ import (
 
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetricgrpc"
+	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetrichttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdoutmetric"
 	"go.opentelemetry.io/otel/metric"
 	"go.opentelemetry.io/otel/metric/noop"
 	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
 	"go.opentelemetry.io/otel/sdk/resource"
 )
 
-// Setup initializes OpenTelemetry's metrics.
+// DefaultInterval is the interval metrics are pushed at by the periodic readers.
+const DefaultInterval = 20 * time.Second
+
+// Setup initializes OpenTelemetry's metrics, pushed with OTLP over gRPC at the default interval.
 func Setup(ctx context.Context, res *resource.Resource) (*sdkmetric.MeterProvider, error) {
-	meterProvider, err := newMeterProvider(ctx, res)
+	return SetupGRPC(ctx, res, DefaultInterval)
+}
+
+// SetupGRPC initializes OpenTelemetry's metrics, pushed with OTLP over gRPC at the given interval.
+// The options, e.g. sdkmetric.WithProducer, are applied to the periodic reader after the interval.
+func SetupGRPC(ctx context.Context, res *resource.Resource, interval time.Duration,
+	oo ...sdkmetric.PeriodicReaderOption,
+) (*sdkmetric.MeterProvider, error) {
+	exp, err := otlpmetricgrpc.New(ctx)
 	if err != nil {
 		return nil, err
 	}
+	return SetupWithReader(res, newPeriodicReader(exp, interval, oo...)), nil
+}
 
-	SetupWithMeterProvider(meterProvider)
+// SetupHTTP initializes OpenTelemetry's metrics, pushed with OTLP over HTTP at the given interval.
+// The options, e.g. sdkmetric.WithProducer, are applied to the periodic reader after the interval.
+func SetupHTTP(ctx context.Context, res *resource.Resource, interval time.Duration,
+	oo ...sdkmetric.PeriodicReaderOption,
+) (*sdkmetric.MeterProvider, error) {
+	exp, err := otlpmetrichttp.New(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return SetupWithReader(res, newPeriodicReader(exp, interval, oo...)), nil
+}
 
-	return meterProvider, nil
+// SetupStdout initializes OpenTelemetry's metrics, pretty printed to stdout at the given interval.
+// The options, e.g. sdkmetric.WithProducer, are applied to the periodic reader after the interval.
+func SetupStdout(res *resource.Resource, interval time.Duration,
+	oo ...sdkmetric.PeriodicReaderOption,
+) (*sdkmetric.MeterProvider, error) {
+	exp, err := stdoutmetric.New(stdoutmetric.WithPrettyPrint())
+	if err != nil {
+		return nil, err
+	}
+	return SetupWithReader(res, newPeriodicReader(exp, interval, oo...)), nil
+}
+
+func newPeriodicReader(exp sdkmetric.Exporter, interval time.Duration,
+	oo ...sdkmetric.PeriodicReaderOption,
+) *sdkmetric.PeriodicReader {
+	opts := []sdkmetric.PeriodicReaderOption{sdkmetric.WithInterval(interval)}
+	opts = append(opts, oo...)
+	return sdkmetric.NewPeriodicReader(exp, opts...)
 }
 
 // SetupWithMeterProvider initializes OpenTelemetry's metrics with a custom meter provider.
@@ -29,17 +71,19 @@ func SetupWithMeterProvider(provider metric.MeterProvider) {
 	otel.SetMeterProvider(provider)
 }
 
-func newMeterProvider(ctx context.Context, res *resource.Resource) (*sdkmetric.MeterProvider, error) {
-	metricExporter, err := otlpmetricgrpc.New(ctx)
-	if err != nil {
-		return nil, err
-	}
+// SetupWithReader initializes OpenTelemetry's metrics with a meter provider collecting through the given reader,
+// e.g. a periodic reader of a push exporter.
+func SetupWithReader(res *resource.Resource, reader sdkmetric.Reader) *sdkmetric.MeterProvider {
+	meterProvider := newMeterProviderWithReader(res, reader)
+	SetupWithMeterProvider(meterProvider)
+	return meterProvider
+}
 
-	meterProvider := sdkmetric.NewMeterProvider(
+func newMeterProviderWithReader(res *resource.Resource, reader sdkmetric.Reader) *sdkmetric.MeterProvider {
+	return sdkmetric.NewMeterProvider(
 		sdkmetric.WithResource(res),
-		sdkmetric.WithReader(sdkmetric.NewPeriodicReader(metricExporter, sdkmetric.WithInterval(20*time.Second))),
+		sdkmetric.WithReader(reader),
 	)
-	return meterProvider, nil
 }
 
 // Float64Histogram creates a float64 histogram metric.
@@ -3,13 +3,17 @@ package metric
 import (
 	"context"
 	"errors"
+	"net/http"
+	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/metric"
 	"go.opentelemetry.io/otel/metric/noop"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
 	"go.opentelemetry.io/otel/sdk/resource"
 )
 
@@ -92,7 +96,7 @@ func TestNewMeterProvider(t *testing.T) {
 		ctx := context.Background()
 		res := resource.Default()
 
-		mp, err := newMeterProvider(ctx, res)
+		mp, err := SetupGRPC(ctx, res, DefaultInterval)
 
 		// In unit test environment without OTLP collector, this may fail
 		// but we test that the function handles errors appropriately
@@ -301,3 +305,41 @@ func TestSetup_WithCustomResource(t *testing.T) {
 		_ = mp.Shutdown(ctx)
 	}
 }
+
+func TestSetupExporters(t *testing.T) {
+	collector := httptest.NewServer(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {}))
+	t.Cleanup(collector.Close)
+	t.Setenv("OTEL_EXPORTER_OTLP_ENDPOINT", collector.URL)
+
+	ctx := context.Background()
+
+	tests := map[string]func() (*sdkmetric.MeterProvider, error){
+		"http":   func() (*sdkmetric.MeterProvider, error) { return SetupHTTP(ctx, resource.Default(), time.Minute) },
+		"stdout": func() (*sdkmetric.MeterProvider, error) { return SetupStdout(resource.Default(), time.Minute) },
+	}
+	for name, setup := range tests {
+		t.Run(name, func(t *testing.T) {
+			mp, err := setup()
+			require.NoError(t, err)
+			assert.Equal(t, mp, otel.GetMeterProvider())
+			require.NoError(t, mp.Shutdown(ctx))
+		})
+	}
+}
+
+func TestPrometheusHandler(t *testing.T) {
+	rec := httptest.NewRecorder()
+	PrometheusHandler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+
+	mp, err := SetupPrometheus(resource.Default())
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, mp.Shutdown(context.Background())) })
+
+	Int64Counter("test", "prometheus.requests", "Requests.", "1").Add(context.Background(), 3)
+
+	rec = httptest.NewRecorder()
+	PrometheusHandler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), "prometheus_requests_total")
+}
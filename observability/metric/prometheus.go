@@ -0,0 +1,52 @@
+package metric
+
+import (
+	"net/http"
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	otelprometheus "go.opentelemetry.io/otel/exporters/prometheus"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/resource"
+)
+
+var (
+	promHandler   http.Handler
+	promHandlerMu sync.RWMutex
+)
+
+// SetupPrometheus initializes OpenTelemetry's metrics with a Prometheus exporter, which is pulled through PrometheusHandler.
+// The options, e.g. otelprometheus.WithProducer, are applied to the exporter after its registerer.
+func SetupPrometheus(res *resource.Resource, oo ...otelprometheus.Option) (*sdkmetric.MeterProvider, error) {
+	registry := prometheus.NewRegistry()
+
+	opts := []otelprometheus.Option{otelprometheus.WithRegisterer(registry)}
+	opts = append(opts, oo...)
+	exporter, err := otelprometheus.New(opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	promHandlerMu.Lock()
+	promHandler = promhttp.HandlerFor(registry, promhttp.HandlerOpts{})
+	promHandlerMu.Unlock()
+
+	return SetupWithReader(res, exporter), nil
+}
+
+// PrometheusHandler returns the handler serving the metrics in the Prometheus exposition format.
+// It responds with 404 if the metrics are not set up with the Prometheus exporter.
+func PrometheusHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		promHandlerMu.RLock()
+		h := promHandler
+		promHandlerMu.RUnlock()
+
+		if h == nil {
+			http.Error(w, "prometheus exporter is not enabled", http.StatusNotFound)
+			return
+		}
+		h.ServeHTTP(w, r)
+	})
+}
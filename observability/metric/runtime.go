@@ -0,0 +1,103 @@
+package metric
+
+import (
+	"context"
+	"errors"
+	"os"
+	"runtime/debug"
+	"time"
+
+	"github.com/beatlabs/patron/internal/rusage"
+	"go.opentelemetry.io/contrib/instrumentation/runtime"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+)
+
+const processMeterName = "process"
+
+var (
+	processStart = time.Now()
+
+	cpuModeUser   = attribute.String("cpu.mode", "user")
+	cpuModeSystem = attribute.String("cpu.mode", "system")
+)
+
+// SetupRuntime produces the Go runtime and process metrics with the given meter provider.
+//
+// The runtime metrics are the goroutines, the heap, the GC goal, the processor and memory limits, and,
+// with the producer of NewRuntimeProducer registered with the reader, the scheduler latency.
+// The process metrics are the GC pauses and cycles, the CPU time, the open file descriptors and the uptime.
+// The metrics are produced until the meter provider is shut down.
+func SetupRuntime(mp metric.MeterProvider) error {
+	if err := runtime.Start(runtime.WithMeterProvider(mp)); err != nil {
+		return err
+	}
+	return setupProcess(mp.Meter(processMeterName))
+}
+
+// NewRuntimeProducer returns the producer of the go.schedule.duration histogram of the scheduler latency,
+// to be registered with the reader of the meter provider.
+func NewRuntimeProducer() sdkmetric.Producer {
+	return runtime.NewProducer()
+}
+
+func setupProcess(meter metric.Meter) error {
+	uptime, err := meter.Float64ObservableGauge("process.uptime",
+		metric.WithDescription("The time the process has been running."), metric.WithUnit("s"))
+	if err != nil {
+		return err
+	}
+	cpuTime, err := meter.Float64ObservableCounter("process.cpu.time",
+		metric.WithDescription("Total CPU seconds broken down by mode."), metric.WithUnit("s"))
+	if err != nil {
+		return err
+	}
+	fds, err := meter.Int64ObservableUpDownCounter("process.unix.file_descriptor.count",
+		metric.WithDescription("Number of unix file descriptors in use by the process."), metric.WithUnit("{file_descriptor}"))
+	if err != nil {
+		return err
+	}
+	gcPause, err := meter.Float64ObservableCounter("go.gc.pause.time",
+		metric.WithDescription("Total time the program was paused by the garbage collector."), metric.WithUnit("s"))
+	if err != nil {
+		return err
+	}
+	gcCycles, err := meter.Int64ObservableCounter("go.gc.cycles",
+		metric.WithDescription("Number of completed garbage collection cycles."), metric.WithUnit("{gc_cycle}"))
+	if err != nil {
+		return err
+	}
+
+	_, err = meter.RegisterCallback(func(_ context.Context, o metric.Observer) error {
+		o.ObserveFloat64(uptime, time.Since(processStart).Seconds())
+
+		if user, system, ok := rusage.CPUTimes(); ok {
+			o.ObserveFloat64(cpuTime, user, metric.WithAttributes(cpuModeUser))
+			o.ObserveFloat64(cpuTime, system, metric.WithAttributes(cpuModeSystem))
+		}
+
+		if n, err := openFileDescriptors(); err == nil {
+			o.ObserveInt64(fds, int64(n))
+		}
+
+		stats := debug.GCStats{}
+		debug.ReadGCStats(&stats)
+		o.ObserveFloat64(gcPause, stats.PauseTotal.Seconds())
+		o.ObserveInt64(gcCycles, stats.NumGC)
+		return nil
+	}, uptime, cpuTime, fds, gcPause, gcCycles)
+	return err
+}
+
+// openFileDescriptors counts the entries of the file descriptor directory of the process, on Linux and
+// the BSDs, excluding the descriptor opened to read it.
+func openFileDescriptors() (int, error) {
+	for _, dir := range []string{"/proc/self/fd", "/dev/fd"} {
+		entries, err := os.ReadDir(dir)
+		if err == nil {
+			return len(entries) - 1, nil
+		}
+	}
+	return 0, errors.New("file descriptor directory not found")
+}
@@ -0,0 +1,53 @@
+package metric
+
+import (
+	"context"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+func TestSetupRuntime(t *testing.T) {
+	reader := sdkmetric.NewManualReader(sdkmetric.WithProducer(NewRuntimeProducer()))
+	mp := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+	t.Cleanup(func() { require.NoError(t, mp.Shutdown(context.Background())) })
+
+	require.NoError(t, SetupRuntime(mp))
+	runtime.GC()
+
+	rm := metricdata.ResourceMetrics{}
+	require.NoError(t, reader.Collect(context.Background(), &rm))
+
+	mm := map[string]metricdata.Aggregation{}
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			mm[m.Name] = m.Data
+		}
+	}
+
+	for _, name := range []string{
+		"go.goroutine.count", "go.memory.used", "go.memory.gc.goal", "go.schedule.duration",
+		"go.gc.pause.time", "go.gc.cycles", "process.uptime", "process.cpu.time",
+		"process.unix.file_descriptor.count",
+	} {
+		assert.Contains(t, mm, name)
+	}
+
+	cycles, ok := mm["go.gc.cycles"].(metricdata.Sum[int64])
+	require.True(t, ok)
+	require.Len(t, cycles.DataPoints, 1)
+	assert.Positive(t, cycles.DataPoints[0].Value)
+
+	cpu, ok := mm["process.cpu.time"].(metricdata.Sum[float64])
+	require.True(t, ok)
+	assert.Len(t, cpu.DataPoints, 2)
+
+	fds, ok := mm["process.unix.file_descriptor.count"].(metricdata.Sum[int64])
+	require.True(t, ok)
+	require.Len(t, fds.DataPoints, 1)
+	assert.Positive(t, fds.DataPoints[0].Value)
+}
@@ -1,28 +1,38 @@
-// Package observability provides functionality for initializing OpenTelemetry's traces and metrics.
+// Package observability provides functionality for initializing OpenTelemetry's traces, metrics and logs.
 // It includes methods for setting up and shutting down the observability components.
 package observability
 
 import (
 	"context"
 	"errors"
+	"fmt"
 	"log/slog"
+	"time"
 
 	"github.com/beatlabs/patron/observability/log"
 	patronmetric "github.com/beatlabs/patron/observability/metric"
+	"github.com/beatlabs/patron/observability/redact"
 	patrontrace "github.com/beatlabs/patron/observability/trace"
 	"go.opentelemetry.io/otel/attribute"
+	otelprometheus "go.opentelemetry.io/otel/exporters/prometheus"
+	otelmetric "go.opentelemetry.io/otel/metric"
+	noopmetric "go.opentelemetry.io/otel/metric/noop"
+	sdklog "go.opentelemetry.io/otel/sdk/log"
 	"go.opentelemetry.io/otel/sdk/metric"
 	"go.opentelemetry.io/otel/sdk/resource"
 	"go.opentelemetry.io/otel/sdk/trace"
 	semconv "go.opentelemetry.io/otel/semconv/v1.41.0"
+	oteltrace "go.opentelemetry.io/otel/trace"
+	nooptrace "go.opentelemetry.io/otel/trace/noop"
 )
 
 const statusAttribute = "status"
 
-// Provider represents the observability provider that includes the metric and trace providers.
+// Provider represents the observability provider that includes the metric, trace and logger providers.
 type Provider struct {
 	mp *metric.MeterProvider
 	tp *trace.TracerProvider
+	lp *sdklog.LoggerProvider
 }
 
 var (
@@ -52,44 +62,192 @@ func StatusAttribute(err error) attribute.KeyValue {
 
 // Config represents the configuration for setting up traces, metrics and logs.
 type Config struct {
-	Name      string
-	Version   string
+	Name    string
+	Version string
+	// LogConfig configures the logger. When its LoggerProvider is set, it replaces the OTLP logger provider,
+	// is owned by the caller and is not shut down.
 	LogConfig log.Config
+	// MeterProvider replaces the OTLP meter provider when set. It is owned by the caller and not shut down.
+	MeterProvider otelmetric.MeterProvider
+	// TracerProvider replaces the OTLP tracer provider when set. It is owned by the caller and not shut down.
+	TracerProvider oteltrace.TracerProvider
+	// MetricExporter selects where the metrics are exported to, OTLP over gRPC by default.
+	MetricExporter Exporter
+	// MetricInterval is the interval of the push metric exporters, 20s by default.
+	MetricInterval time.Duration
+	// TraceExporter selects where the spans are exported to, OTLP over gRPC by default.
+	TraceExporter Exporter
+	// TraceSampler decides which spans are recorded, the SDK default sampler when nil.
+	TraceSampler trace.Sampler
+	// LogExporter selects where the log records are exported to, in addition to the log output,
+	// OTLP over gRPC by default.
+	LogExporter Exporter
+	// DisableRuntimeMetrics stops producing the Go runtime and process metrics with the meter provider of Setup.
+	// They are never produced with a MeterProvider of the caller, see metric.SetupRuntime.
+	DisableRuntimeMetrics bool
+	// Redactor replaces the default redact.Redactor when set. The logs are redacted with the Redactor of
+	// the LogConfig, the default one when unset.
+	Redactor *redact.Redactor
 }
 
-// Setup initializes OpenTelemetry's traces and metrics.
-// It creates a resource with the given name and version, sets up the metric and trace providers,
+// Exporter selects the destination of the metrics, the spans or the log records.
+type Exporter string
+
+const (
+	// ExporterOTLPGRPC pushes with OTLP over gRPC, configured with the OTEL_EXPORTER_OTLP_* environment variables.
+	ExporterOTLPGRPC Exporter = "otlp-grpc"
+	// ExporterOTLPHTTP pushes with OTLP over HTTP, configured with the OTEL_EXPORTER_OTLP_* environment variables.
+	ExporterOTLPHTTP Exporter = "otlp-http"
+	// ExporterStdout pretty prints to stdout, for local development.
+	ExporterStdout Exporter = "stdout"
+	// ExporterPrometheus exposes the metrics to be pulled by Prometheus, see metric.PrometheusHandler.
+	// It is not supported for spans and log records.
+	ExporterPrometheus Exporter = "prometheus"
+	// ExporterNone disables the metrics, the spans or the export of the log records.
+	ExporterNone Exporter = "none"
+)
+
+// Setup initializes OpenTelemetry's traces, metrics and logs.
+// It creates a resource with the given name and version, sets up the logger, the metric, trace and logger providers,
 // and returns a Provider containing the initialized providers.
 func Setup(ctx context.Context, cfg Config) (*Provider, error) {
-	err := log.Setup(&cfg.LogConfig)
+	res, err := createResource(cfg.Name, cfg.Version)
 	if err != nil {
 		return nil, err
 	}
 
-	res, err := createResource(cfg.Name, cfg.Version)
-	if err != nil {
-		return nil, err
+	p := &Provider{}
+
+	if cfg.Redactor != nil {
+		redact.SetDefault(cfg.Redactor)
+	}
+	if cfg.LogConfig.Redactor == nil {
+		cfg.LogConfig.Redactor = redact.Default()
+	}
+
+	if cfg.LogConfig.LoggerProvider == nil && cfg.LogExporter != ExporterNone {
+		p.lp, err = setupLogs(ctx, cfg, res)
+		if err != nil {
+			return nil, err
+		}
+		cfg.LogConfig.LoggerProvider = p.lp
 	}
 
-	metricProvider, err := patronmetric.Setup(ctx, res)
+	err = log.Setup(&cfg.LogConfig)
 	if err != nil {
+		_ = p.Shutdown(ctx)
 		return nil, err
 	}
-	traceProvider, err := patrontrace.SetupGRPC(ctx, cfg.Name, res)
+
+	switch {
+	case cfg.MeterProvider != nil:
+		patronmetric.SetupWithMeterProvider(cfg.MeterProvider)
+	case cfg.MetricExporter == ExporterNone:
+		patronmetric.SetupWithMeterProvider(noopmetric.NewMeterProvider())
+	default:
+		p.mp, err = setupMetrics(ctx, cfg, res)
+		if err != nil {
+			_ = p.Shutdown(ctx)
+			return nil, err
+		}
+	}
+
+	switch {
+	case cfg.TracerProvider != nil:
+		patrontrace.SetupWithTracerProvider(cfg.Name, cfg.TracerProvider)
+	case cfg.TraceExporter == ExporterNone:
+		patrontrace.SetupWithTracerProvider(cfg.Name, nooptrace.NewTracerProvider())
+	default:
+		p.tp, err = setupTraces(ctx, cfg, res)
+		if err != nil {
+			_ = p.Shutdown(ctx)
+			return nil, err
+		}
+	}
+
+	return p, nil
+}
+
+func setupMetrics(ctx context.Context, cfg Config, res *resource.Resource) (*metric.MeterProvider, error) {
+	mp, err := newMeterProvider(ctx, cfg, res)
 	if err != nil {
-		_ = metricProvider.Shutdown(ctx)
 		return nil, err
 	}
+	if cfg.DisableRuntimeMetrics {
+		return mp, nil
+	}
 
-	return &Provider{
-		mp: metricProvider,
-		tp: traceProvider,
-	}, nil
+	if err := patronmetric.SetupRuntime(mp); err != nil {
+		return nil, errors.Join(fmt.Errorf("failed to set up runtime metrics: %w", err), mp.Shutdown(ctx))
+	}
+	return mp, nil
 }
 
-// Shutdown flushes and shuts down the metrics and traces.
-// It forces a flush of metrics and traces, logs any errors encountered during flushing,
-// and shuts down the metric and trace providers.
+func newMeterProvider(ctx context.Context, cfg Config, res *resource.Resource) (*metric.MeterProvider, error) {
+	interval := cfg.MetricInterval
+	if interval <= 0 {
+		interval = patronmetric.DefaultInterval
+	}
+
+	var (
+		readerOptions     []metric.PeriodicReaderOption
+		prometheusOptions []otelprometheus.Option
+	)
+	if !cfg.DisableRuntimeMetrics {
+		producer := patronmetric.NewRuntimeProducer()
+		readerOptions = append(readerOptions, metric.WithProducer(producer))
+		prometheusOptions = append(prometheusOptions, otelprometheus.WithProducer(producer))
+	}
+
+	switch cfg.MetricExporter {
+	case "", ExporterOTLPGRPC:
+		return patronmetric.SetupGRPC(ctx, res, interval, readerOptions...)
+	case ExporterOTLPHTTP:
+		return patronmetric.SetupHTTP(ctx, res, interval, readerOptions...)
+	case ExporterStdout:
+		return patronmetric.SetupStdout(res, interval, readerOptions...)
+	case ExporterPrometheus:
+		return patronmetric.SetupPrometheus(res, prometheusOptions...)
+	default:
+		return nil, fmt.Errorf("unsupported metric exporter %q", cfg.MetricExporter)
+	}
+}
+
+func setupTraces(ctx context.Context, cfg Config, res *resource.Resource) (*trace.TracerProvider, error) {
+	var oo []trace.TracerProviderOption
+	if cfg.TraceSampler != nil {
+		oo = append(oo, trace.WithSampler(cfg.TraceSampler))
+	}
+
+	switch cfg.TraceExporter {
+	case "", ExporterOTLPGRPC:
+		return patrontrace.SetupGRPC(ctx, cfg.Name, res, oo...)
+	case ExporterOTLPHTTP:
+		return patrontrace.SetupHTTP(ctx, cfg.Name, res, oo...)
+	case ExporterStdout:
+		return patrontrace.SetupStdout(cfg.Name, res, oo...)
+	default:
+		return nil, fmt.Errorf("unsupported trace exporter %q", cfg.TraceExporter)
+	}
+}
+
+func setupLogs(ctx context.Context, cfg Config, res *resource.Resource) (*sdklog.LoggerProvider, error) {
+	switch cfg.LogExporter {
+	case "", ExporterOTLPGRPC:
+		return log.SetupGRPC(ctx, res)
+	case ExporterOTLPHTTP:
+		return log.SetupHTTP(ctx, res)
+	case ExporterStdout:
+		return log.SetupStdout(res)
+	default:
+		return nil, fmt.Errorf("unsupported log exporter %q", cfg.LogExporter)
+	}
+}
+
+// Shutdown flushes and shuts down the metrics, traces and logs.
+// It forces a flush of metrics, traces and logs, logs any errors encountered during flushing,
+// and shuts down the metric, trace and logger providers.
+// The logger provider is shut down last, so that it exports the errors of the other providers.
 func (p *Provider) Shutdown(ctx context.Context) error {
 	if p == nil {
 		return nil
@@ -115,6 +273,15 @@ func (p *Provider) Shutdown(ctx context.Context) error {
 		}
 	}
 
+	if p.lp != nil {
+		if err := p.lp.ForceFlush(ctx); err != nil {
+			slog.Error("failed to flush logs", log.ErrorAttr(err))
+		}
+		if err := p.lp.Shutdown(ctx); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
 	return errors.Join(errs...)
 }
 
@@ -5,12 +5,19 @@ package observability
 import (
 	"context"
 	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
 	"testing"
 
 	"github.com/beatlabs/patron/observability/log"
+	patronmetric "github.com/beatlabs/patron/observability/metric"
+	"github.com/beatlabs/patron/observability/redact"
+	patrontrace "github.com/beatlabs/patron/observability/trace"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/otel/attribute"
+	sdklog "go.opentelemetry.io/otel/sdk/log"
 	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
 	sdktrace "go.opentelemetry.io/otel/sdk/trace"
 	semconv "go.opentelemetry.io/otel/semconv/v1.41.0"
@@ -250,6 +257,14 @@ func TestProvider_Shutdown_WithOnlyTraceProvider(t *testing.T) {
 	})
 }
 
+func TestProvider_Shutdown_WithOnlyLoggerProvider(t *testing.T) {
+	provider := &Provider{lp: sdklog.NewLoggerProvider()}
+
+	assert.NotPanics(t, func() {
+		require.NoError(t, provider.Shutdown(context.Background()))
+	})
+}
+
 func TestSetup_ValidConfig(t *testing.T) {
 	t.Skip("Requires OTLP collector - tested in integration tests")
 }
@@ -306,3 +321,141 @@ func TestConfig_DefaultValues(t *testing.T) {
 	assert.Empty(t, cfg.LogConfig.Level)
 	assert.False(t, cfg.LogConfig.IsJSON)
 }
+
+func TestSetup_CustomProviders(t *testing.T) {
+	ctx := context.Background()
+	mp := sdkmetric.NewMeterProvider()
+	tp := sdktrace.NewTracerProvider()
+	lp := sdklog.NewLoggerProvider()
+
+	got, err := Setup(ctx, Config{
+		Name:           "test-service",
+		LogConfig:      log.Config{Level: "info", LoggerProvider: lp},
+		MeterProvider:  mp,
+		TracerProvider: tp,
+	})
+	require.NoError(t, err)
+	assert.Nil(t, got.mp)
+	assert.Nil(t, got.tp)
+	assert.Nil(t, got.lp)
+	require.NoError(t, got.Shutdown(ctx))
+
+	// the providers are owned by the caller, who shuts them down.
+	require.NoError(t, mp.Shutdown(ctx))
+	require.NoError(t, tp.Shutdown(ctx))
+	require.NoError(t, lp.Shutdown(ctx))
+}
+
+func TestSetup_Exporters(t *testing.T) {
+	ctx := context.Background()
+
+	tests := map[string]struct {
+		metricExporter Exporter
+		traceExporter  Exporter
+		logExporter    Exporter
+		expMetrics     bool
+		expTraces      bool
+		expLogs        bool
+		expectedErr    string
+	}{
+		"disabled": {metricExporter: ExporterNone, traceExporter: ExporterNone, logExporter: ExporterNone},
+		"stdout": {
+			metricExporter: ExporterStdout, traceExporter: ExporterStdout, logExporter: ExporterStdout,
+			expMetrics: true, expTraces: true, expLogs: true,
+		},
+		"otlp http": {
+			metricExporter: ExporterOTLPHTTP, traceExporter: ExporterOTLPHTTP, logExporter: ExporterOTLPHTTP,
+			expMetrics: true, expTraces: true, expLogs: true,
+		},
+		"prometheus":          {metricExporter: ExporterPrometheus, traceExporter: ExporterNone, logExporter: ExporterNone, expMetrics: true},
+		"unsupported metrics": {metricExporter: "statsd", logExporter: ExporterNone, expectedErr: `unsupported metric exporter "statsd"`},
+		"unsupported traces": {
+			metricExporter: ExporterNone, traceExporter: ExporterPrometheus, logExporter: ExporterNone,
+			expectedErr: `unsupported trace exporter "prometheus"`,
+		},
+		"unsupported logs": {logExporter: ExporterPrometheus, expectedErr: `unsupported log exporter "prometheus"`},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, err := Setup(ctx, Config{
+				Name:           "test-service",
+				LogConfig:      log.Config{Level: "info"},
+				MetricExporter: tt.metricExporter,
+				TraceExporter:  tt.traceExporter,
+				LogExporter:    tt.logExporter,
+			})
+			if tt.expectedErr != "" {
+				require.EqualError(t, err, tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expMetrics, got.mp != nil)
+			assert.Equal(t, tt.expTraces, got.tp != nil)
+			assert.Equal(t, tt.expLogs, got.lp != nil)
+		})
+	}
+}
+
+func TestSetup_DisableRuntimeMetrics(t *testing.T) {
+	ctx := context.Background()
+
+	for _, disabled := range []bool{false, true} {
+		got, err := Setup(ctx, Config{
+			Name:                  "test-service",
+			LogConfig:             log.Config{Level: "info"},
+			MetricExporter:        ExporterPrometheus,
+			TraceExporter:         ExporterNone,
+			LogExporter:           ExporterNone,
+			DisableRuntimeMetrics: disabled,
+		})
+		require.NoError(t, err)
+
+		rsp := httptest.NewRecorder()
+		patronmetric.PrometheusHandler().ServeHTTP(rsp, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+		assert.Equal(t, !disabled, strings.Contains(rsp.Body.String(), "go_goroutine_count"))
+		require.NoError(t, got.Shutdown(ctx))
+	}
+}
+
+func TestSetup_Redactor(t *testing.T) {
+	ctx := context.Background()
+	r, err := redact.New(redact.Config{Fields: []string{"email"}})
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		dr, err := redact.New(redact.DefaultConfig())
+		require.NoError(t, err)
+		redact.SetDefault(dr)
+	})
+
+	got, err := Setup(ctx, Config{
+		Name:           "test-service",
+		LogConfig:      log.Config{Level: "info"},
+		MetricExporter: ExporterNone,
+		TraceExporter:  ExporterNone,
+		LogExporter:    ExporterNone,
+		Redactor:       r,
+	})
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, got.Shutdown(ctx)) })
+
+	assert.Equal(t, r, redact.Default())
+}
+
+func TestSetup_TraceSampler(t *testing.T) {
+	ctx := context.Background()
+
+	got, err := Setup(ctx, Config{
+		Name:           "test-service",
+		LogConfig:      log.Config{Level: "info"},
+		MetricExporter: ExporterNone,
+		TraceExporter:  ExporterStdout,
+		TraceSampler:   sdktrace.NeverSample(),
+		LogExporter:    ExporterNone,
+	})
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, got.Shutdown(ctx)) })
+
+	_, sp := patrontrace.StartSpan(ctx, "test")
+	defer sp.End()
+	assert.False(t, sp.IsRecording())
+}
@@ -0,0 +1,28 @@
+package profiling
+
+import (
+	"runtime/metrics"
+
+	"github.com/beatlabs/patron/internal/rusage"
+)
+
+const (
+	cpuTotalMetric = "/cpu/classes/total:cpu-seconds"
+	cpuIdleMetric  = "/cpu/classes/idle:cpu-seconds"
+)
+
+// cpuSeconds returns the user and system CPU seconds of the process. Where they are not available, the CPU seconds
+// estimated by the runtime are returned instead. The estimates are only updated by the garbage collector, so a CPU
+// spike without a collection in between checks is missed.
+func cpuSeconds() float64 {
+	if user, system, ok := rusage.CPUTimes(); ok {
+		return user + system
+	}
+
+	samples := []metrics.Sample{
+		{Name: cpuTotalMetric},
+		{Name: cpuIdleMetric},
+	}
+	metrics.Read(samples)
+	return samples[0].Value.Float64() - samples[1].Value.Float64()
+}
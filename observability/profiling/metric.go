@@ -0,0 +1,23 @@
+package profiling
+
+import (
+	"context"
+
+	"github.com/beatlabs/patron/observability"
+	patronmetric "github.com/beatlabs/patron/observability/metric"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+const packageName = "profiling"
+
+var captureCounter metric.Int64Counter
+
+func init() {
+	captureCounter = patronmetric.Int64Counter(packageName, "profiling.captures", "Profiles captured.", "1")
+}
+
+func captureInc(ctx context.Context, k Kind, t Trigger, err error) {
+	captureCounter.Add(ctx, 1, metric.WithAttributes(attribute.String("kind", string(k)),
+		attribute.String("trigger", string(t)), observability.StatusAttribute(err)))
+}
@@ -0,0 +1,357 @@
+// Package profiling captures CPU, heap and goroutine profiles periodically and whenever the CPU, memory or
+// goroutine usage of the process crosses a threshold, so that short spikes are profiled as they happen.
+//
+// The profiles are in the gzipped protobuf format of pprof, labelled with the name and version of the service,
+// and written to a Sink, e.g. a local directory with retention or an HTTP endpoint.
+package profiling
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"runtime"
+	"runtime/metrics"
+	"runtime/pprof"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/beatlabs/patron/observability/log"
+)
+
+// Kind of profile.
+type Kind string
+
+const (
+	// KindCPU profiles the CPU usage for the configured duration.
+	KindCPU Kind = "cpu"
+	// KindHeap profiles the memory allocations.
+	KindHeap Kind = "heap"
+	// KindGoroutine profiles the stack traces of the goroutines.
+	KindGoroutine Kind = "goroutine"
+)
+
+// Trigger of a capture.
+type Trigger string
+
+const (
+	// TriggerInterval captures the profiles periodically.
+	TriggerInterval Trigger = "interval"
+	// TriggerCPU captures a CPU profile when the CPU threshold is crossed.
+	TriggerCPU Trigger = "cpu"
+	// TriggerMemory captures a heap profile when the memory threshold is crossed.
+	TriggerMemory Trigger = "memory"
+	// TriggerGoroutines captures a goroutine profile when the goroutine threshold is crossed.
+	TriggerGoroutines Trigger = "goroutines"
+)
+
+const (
+	defaultCPUDuration   = 10 * time.Second
+	defaultCheckInterval = 10 * time.Second
+	defaultCooldown      = 5 * time.Minute
+
+	heapMetric       = "/memory/classes/heap/objects:bytes"
+	goroutinesMetric = "/sched/goroutines:goroutines"
+)
+
+var logger = log.Named("profiling")
+
+// Thresholds trigger a capture when crossed. A zero threshold is disabled.
+type Thresholds struct {
+	// CPU is the share of the CPU available to the process, as defined by GOMAXPROCS, from 0 to 1,
+	// used since the previous check, triggering a CPU profile. The usage is the CPU time of the process reported
+	// by the OS on Unix, and the estimate of the runtime elsewhere, which is only updated by the garbage collector.
+	CPU float64
+	// Memory is the size of the heap objects in bytes triggering a heap profile.
+	Memory uint64
+	// Goroutines is the number of goroutines triggering a goroutine profile.
+	Goroutines int
+}
+
+func (t Thresholds) enabled() bool {
+	return t.CPU > 0 || t.Memory > 0 || t.Goroutines > 0
+}
+
+// Config of the profiler.
+type Config struct {
+	// Service and Version label the profiles.
+	Service string
+	Version string
+	// Interval captures the profiles of Kinds periodically, disabled when zero.
+	Interval time.Duration
+	// Kinds are the profiles captured periodically, all of them when empty.
+	Kinds []Kind
+	// CPUDuration is how long a CPU profile is captured for, 10s by default.
+	CPUDuration time.Duration
+	// Thresholds trigger a capture when crossed.
+	Thresholds Thresholds
+	// CheckInterval is how often the thresholds are checked, 10s by default.
+	CheckInterval time.Duration
+	// Cooldown is the minimum time between the captures of a threshold, 5m by default.
+	Cooldown time.Duration
+	// Sink receives the profiles.
+	Sink Sink
+}
+
+// Validate returns the errors of the configuration.
+func (c Config) Validate() error {
+	var errs []error
+	if c.Sink == nil {
+		errs = append(errs, errors.New("profile sink is nil"))
+	}
+	if c.Interval < 0 {
+		errs = append(errs, errors.New("profiling interval must not be negative"))
+	}
+	if c.Interval == 0 && !c.Thresholds.enabled() {
+		errs = append(errs, errors.New("profiling interval or thresholds must be provided"))
+	}
+	for _, k := range c.Kinds {
+		if k != KindCPU && k != KindHeap && k != KindGoroutine {
+			errs = append(errs, fmt.Errorf("unsupported profile kind %q", k))
+		}
+	}
+	if c.CPUDuration < 0 {
+		errs = append(errs, errors.New("cpu profile duration must not be negative"))
+	}
+	if c.Thresholds.CPU < 0 || c.Thresholds.CPU > 1 {
+		errs = append(errs, errors.New("cpu threshold must be between 0 and 1"))
+	}
+	if c.Thresholds.Goroutines < 0 {
+		errs = append(errs, errors.New("goroutine threshold must not be negative"))
+	}
+	if c.CheckInterval < 0 {
+		errs = append(errs, errors.New("threshold check interval must not be negative"))
+	}
+	if c.Cooldown < 0 {
+		errs = append(errs, errors.New("threshold cooldown must not be negative"))
+	}
+	return errors.Join(errs...)
+}
+
+// Profile captured by the profiler.
+type Profile struct {
+	Kind    Kind
+	Trigger Trigger
+	Service string
+	Version string
+	Time    time.Time
+	// Data is the profile in the gzipped protobuf format of pprof.
+	Data []byte
+}
+
+// Sink receives the captured profiles. Write may be called concurrently, as the CPU profiles are captured in the
+// background.
+type Sink interface {
+	Write(ctx context.Context, p Profile) error
+}
+
+// usage of the process at a point in time.
+type usage struct {
+	time time.Time
+	// cpu is the CPU seconds used by the process and procs the CPUs available to it, as defined by GOMAXPROCS.
+	cpu        float64
+	procs      int
+	heap       uint64
+	goroutines uint64
+}
+
+// Profiler captures the profiles. It is a component, run until its context is canceled.
+// The CPU profiles are captured in the background, one at a time, so that the other captures and the threshold
+// checks go on meanwhile.
+type Profiler struct {
+	cfg   Config
+	now   func() time.Time
+	usage func() usage
+	prev  usage
+	last  map[Trigger]time.Time
+	// cpuCapturing is true while a CPU profile is captured, and wg waits for it.
+	cpuCapturing atomic.Bool
+	wg           sync.WaitGroup
+}
+
+// New returns a profiler with the given configuration.
+func New(cfg Config) (*Profiler, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+
+	if len(cfg.Kinds) == 0 {
+		cfg.Kinds = []Kind{KindCPU, KindHeap, KindGoroutine}
+	}
+	if cfg.CPUDuration == 0 {
+		cfg.CPUDuration = defaultCPUDuration
+	}
+	if cfg.CheckInterval == 0 {
+		cfg.CheckInterval = defaultCheckInterval
+	}
+	if cfg.Cooldown == 0 {
+		cfg.Cooldown = defaultCooldown
+	}
+
+	return &Profiler{
+		cfg:   cfg,
+		now:   time.Now,
+		usage: readUsage,
+		last:  map[Trigger]time.Time{},
+	}, nil
+}
+
+// Run captures the profiles until the context is canceled, and the CPU profile in progress, if any, is stopped.
+func (p *Profiler) Run(ctx context.Context) error {
+	defer p.wg.Wait()
+
+	var intervalCh, checkCh <-chan time.Time
+
+	if p.cfg.Interval > 0 {
+		ticker := time.NewTicker(p.cfg.Interval)
+		defer ticker.Stop()
+		intervalCh = ticker.C
+	}
+
+	if p.cfg.Thresholds.enabled() {
+		p.prev = p.usage()
+		ticker := time.NewTicker(p.cfg.CheckInterval)
+		defer ticker.Stop()
+		checkCh = ticker.C
+	}
+
+	logger.Debug("profiler started", slog.Duration("interval", p.cfg.Interval),
+		slog.Duration("check_interval", p.cfg.CheckInterval))
+
+	for {
+		select {
+		case <-ctx.Done():
+			logger.Debug("profiler stopped")
+			return nil
+		case <-intervalCh:
+			for _, k := range p.cfg.Kinds {
+				p.capture(ctx, k, TriggerInterval)
+			}
+		case <-checkCh:
+			p.check(ctx)
+		}
+	}
+}
+
+func (p *Profiler) check(ctx context.Context) {
+	u := p.usage()
+	prev := p.prev
+	p.prev = u
+
+	th := p.cfg.Thresholds
+	if th.CPU > 0 {
+		available := u.time.Sub(prev.time).Seconds() * float64(u.procs)
+		if available > 0 && (u.cpu-prev.cpu)/available >= th.CPU {
+			p.trigger(ctx, TriggerCPU, KindCPU)
+		}
+	}
+	if th.Memory > 0 && u.heap >= th.Memory {
+		p.trigger(ctx, TriggerMemory, KindHeap)
+	}
+	if th.Goroutines > 0 && u.goroutines >= uint64(th.Goroutines) {
+		p.trigger(ctx, TriggerGoroutines, KindGoroutine)
+	}
+}
+
+func (p *Profiler) trigger(ctx context.Context, t Trigger, k Kind) {
+	now := p.now()
+	if last, ok := p.last[t]; ok && now.Sub(last) < p.cfg.Cooldown {
+		return
+	}
+	p.last[t] = now
+
+	logger.Info("profiling threshold crossed", slog.String("trigger", string(t)))
+	p.capture(ctx, k, t)
+}
+
+// capture captures a profile and writes it to the sink. A CPU profile is captured in the background, unless one
+// is captured already, in which case it is skipped.
+func (p *Profiler) capture(ctx context.Context, k Kind, t Trigger) {
+	if k != KindCPU {
+		p.collect(ctx, k, t)
+		return
+	}
+
+	if !p.cpuCapturing.CompareAndSwap(false, true) {
+		logger.Debug("cpu profile capture in progress, skipping", slog.String("trigger", string(t)))
+		return
+	}
+	p.wg.Go(func() {
+		defer p.cpuCapturing.Store(false)
+		p.collect(ctx, k, t)
+	})
+}
+
+func (p *Profiler) collect(ctx context.Context, k Kind, t Trigger) {
+	start := p.now()
+
+	data, err := p.profile(ctx, k)
+	if err != nil {
+		if ctx.Err() == nil {
+			logger.Error("failed to capture profile", slog.String("kind", string(k)), log.ErrorAttr(err))
+		}
+		captureInc(ctx, k, t, err)
+		return
+	}
+
+	err = p.cfg.Sink.Write(ctx, Profile{
+		Kind:    k,
+		Trigger: t,
+		Service: p.cfg.Service,
+		Version: p.cfg.Version,
+		Time:    start,
+		Data:    data,
+	})
+	if err != nil {
+		logger.Error("failed to write profile", slog.String("kind", string(k)), log.ErrorAttr(err))
+	}
+	captureInc(ctx, k, t, err)
+}
+
+func (p *Profiler) profile(ctx context.Context, k Kind) ([]byte, error) {
+	buf := &bytes.Buffer{}
+
+	switch k {
+	case KindCPU:
+		// fails if a CPU profile is already captured, e.g. through the pprof endpoints.
+		if err := pprof.StartCPUProfile(buf); err != nil {
+			return nil, err
+		}
+		timer := time.NewTimer(p.cfg.CPUDuration)
+		defer timer.Stop()
+		select {
+		case <-ctx.Done():
+		case <-timer.C:
+		}
+		pprof.StopCPUProfile()
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+	case KindHeap, KindGoroutine:
+		if err := pprof.Lookup(string(k)).WriteTo(buf, 0); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("unsupported profile kind %q", k)
+	}
+
+	return buf.Bytes(), nil
+}
+
+func readUsage() usage {
+	samples := []metrics.Sample{
+		{Name: heapMetric},
+		{Name: goroutinesMetric},
+	}
+	metrics.Read(samples)
+
+	return usage{
+		time:       time.Now(),
+		cpu:        cpuSeconds(),
+		procs:      runtime.GOMAXPROCS(0),
+		heap:       samples[0].Value.Uint64(),
+		goroutines: samples[1].Value.Uint64(),
+	}
+}
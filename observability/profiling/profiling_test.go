@@ -0,0 +1,228 @@
+package profiling
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/goleak"
+)
+
+func TestMain(m *testing.M) {
+	goleak.VerifyTestMain(m)
+}
+
+type memSink struct {
+	mu       sync.Mutex
+	profiles []Profile
+	err      error
+}
+
+func (s *memSink) Write(_ context.Context, p Profile) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.profiles = append(s.profiles, p)
+	return s.err
+}
+
+func (s *memSink) get() []Profile {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]Profile(nil), s.profiles...)
+}
+
+func TestNew(t *testing.T) {
+	sink := &memSink{}
+
+	tests := map[string]struct {
+		cfg         Config
+		expectedErr string
+	}{
+		"interval":   {cfg: Config{Interval: time.Minute, Sink: sink}},
+		"thresholds": {cfg: Config{Thresholds: Thresholds{CPU: 0.8, Memory: 1 << 30, Goroutines: 10000}, Sink: sink}},
+		"missing sink": {
+			cfg:         Config{Interval: time.Minute},
+			expectedErr: "profile sink is nil",
+		},
+		"missing interval and thresholds": {
+			cfg:         Config{Sink: sink},
+			expectedErr: "profiling interval or thresholds must be provided",
+		},
+		"invalid": {
+			cfg: Config{
+				Interval: -time.Second, Kinds: []Kind{"block"}, CPUDuration: -time.Second,
+				Thresholds:    Thresholds{CPU: 1.5, Goroutines: -1},
+				CheckInterval: -time.Second, Cooldown: -time.Second, Sink: sink,
+			},
+			expectedErr: "profiling interval must not be negative\n" +
+				"unsupported profile kind \"block\"\ncpu profile duration must not be negative\ncpu threshold must be between 0 and 1\n" +
+				"goroutine threshold must not be negative\nthreshold check interval must not be negative\nthreshold cooldown must not be negative",
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, err := New(tt.cfg)
+			if tt.expectedErr != "" {
+				require.EqualError(t, err, tt.expectedErr)
+				assert.Nil(t, got)
+			} else {
+				require.NoError(t, err)
+				assert.Len(t, got.cfg.Kinds, 3)
+				assert.Equal(t, defaultCPUDuration, got.cfg.CPUDuration)
+				assert.Equal(t, defaultCheckInterval, got.cfg.CheckInterval)
+				assert.Equal(t, defaultCooldown, got.cfg.Cooldown)
+			}
+		})
+	}
+}
+
+func TestProfiler_Run(t *testing.T) {
+	sink := &memSink{}
+	p, err := New(Config{
+		Service: "test", Version: "1.0.0",
+		Interval: 10 * time.Millisecond, Kinds: []Kind{KindCPU, KindHeap, KindGoroutine}, CPUDuration: 10 * time.Millisecond,
+		Sink: sink,
+	})
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	chDone := make(chan error)
+	go func() { chDone <- p.Run(ctx) }()
+
+	kinds := func() map[Kind]Profile {
+		kk := map[Kind]Profile{}
+		for _, p := range sink.get() {
+			kk[p.Kind] = p
+		}
+		return kk
+	}
+	assert.Eventually(t, func() bool { return len(kinds()) == 3 }, 5*time.Second, 10*time.Millisecond)
+	cancel()
+	require.NoError(t, <-chDone)
+
+	for kind, profile := range kinds() {
+		assert.Equal(t, kind, profile.Kind)
+		assert.Equal(t, TriggerInterval, profile.Trigger)
+		assert.Equal(t, "test", profile.Service)
+		assert.Equal(t, "1.0.0", profile.Version)
+		assert.False(t, profile.Time.IsZero())
+		// gzipped protobuf.
+		require.Greater(t, len(profile.Data), 2)
+		assert.Equal(t, []byte{0x1f, 0x8b}, profile.Data[:2])
+	}
+}
+
+func TestProfiler_Run_CPUInBackground(t *testing.T) {
+	sink := &memSink{}
+	p, err := New(Config{
+		Interval: 10 * time.Millisecond, Kinds: []Kind{KindCPU, KindHeap}, CPUDuration: time.Minute,
+		Sink: sink,
+	})
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	chDone := make(chan error)
+	go func() { chDone <- p.Run(ctx) }()
+
+	// the heap profiles are captured while the CPU profile is.
+	assert.Eventually(t, func() bool { return len(sink.get()) >= 3 }, 5*time.Second, 10*time.Millisecond)
+	assert.True(t, p.cpuCapturing.Load())
+	cancel()
+	require.NoError(t, <-chDone)
+
+	// the CPU profile is stopped on cancellation and not written.
+	assert.False(t, p.cpuCapturing.Load())
+	for _, profile := range sink.get() {
+		assert.Equal(t, KindHeap, profile.Kind)
+	}
+}
+
+func TestProfiler_capture_CPUInProgress(t *testing.T) {
+	sink := &memSink{}
+	p, err := New(Config{Interval: time.Minute, CPUDuration: 50 * time.Millisecond, Sink: sink})
+	require.NoError(t, err)
+
+	// the second capture is skipped, instead of failing to start a second CPU profile.
+	p.capture(context.Background(), KindCPU, TriggerInterval)
+	p.capture(context.Background(), KindCPU, TriggerCPU)
+	p.wg.Wait()
+
+	profiles := sink.get()
+	require.Len(t, profiles, 1)
+	assert.Equal(t, TriggerInterval, profiles[0].Trigger)
+	assert.False(t, p.cpuCapturing.Load())
+}
+
+func TestProfiler_check(t *testing.T) {
+	sink := &memSink{err: errors.New("sink error")}
+	p, err := New(Config{
+		Thresholds:  Thresholds{CPU: 0.5, Memory: 100, Goroutines: 10},
+		CPUDuration: 10 * time.Millisecond,
+		Cooldown:    time.Minute,
+		Sink:        sink,
+	})
+	require.NoError(t, err)
+
+	now := time.Now()
+	p.now = func() time.Time { return now }
+
+	// below the thresholds.
+	p.usage = func() usage { return usage{time: now, cpu: 10, procs: 2, heap: 99, goroutines: 9} }
+	p.check(context.Background())
+	assert.Empty(t, sink.get())
+
+	// 3 of the 4 CPU seconds available since the previous check were used.
+	p.usage = func() usage {
+		return usage{time: now.Add(2 * time.Second), cpu: 13, procs: 2, heap: 100, goroutines: 10}
+	}
+	p.check(context.Background())
+	p.wg.Wait()
+	profiles := sink.get()
+	require.Len(t, profiles, 3)
+	// the CPU profile is captured in the background, after the others.
+	assert.Equal(t, KindHeap, profiles[0].Kind)
+	assert.Equal(t, TriggerMemory, profiles[0].Trigger)
+	assert.Equal(t, KindGoroutine, profiles[1].Kind)
+	assert.Equal(t, TriggerGoroutines, profiles[1].Trigger)
+	assert.Equal(t, KindCPU, profiles[2].Kind)
+	assert.Equal(t, TriggerCPU, profiles[2].Trigger)
+
+	// within the cooldown.
+	p.usage = func() usage {
+		return usage{time: now.Add(4 * time.Second), cpu: 17, procs: 2, heap: 100, goroutines: 10}
+	}
+	p.check(context.Background())
+	assert.Len(t, sink.get(), 3)
+
+	// after the cooldown.
+	now = now.Add(time.Minute)
+	p.usage = func() usage { return usage{time: now, cpu: 18, procs: 2, heap: 100, goroutines: 1} }
+	p.check(context.Background())
+	profiles = sink.get()
+	require.Len(t, profiles, 4)
+	assert.Equal(t, TriggerMemory, profiles[3].Trigger)
+}
+
+func TestProfiler_profile_Canceled(t *testing.T) {
+	p, err := New(Config{Interval: time.Minute, Sink: &memSink{}})
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	data, err := p.profile(ctx, KindCPU)
+	require.ErrorIs(t, err, context.Canceled)
+	assert.Nil(t, data)
+}
+
+func TestReadUsage(t *testing.T) {
+	u := readUsage()
+	assert.False(t, u.time.IsZero())
+	assert.Positive(t, u.cpu)
+	assert.Positive(t, u.procs)
+	assert.Positive(t, u.heap)
+	assert.Positive(t, u.goroutines)
+}
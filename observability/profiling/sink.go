@@ -0,0 +1,194 @@
+package profiling
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	profileExt         = ".pb.gz"
+	defaultHTTPTimeout = 30 * time.Second
+)
+
+// Retention of the profiles written to a directory. A zero limit is disabled.
+type Retention struct {
+	// MaxFiles is the number of profiles kept, the oldest are removed.
+	MaxFiles int
+	// MaxAge is how long the profiles are kept for.
+	MaxAge time.Duration
+}
+
+// DirSink writes the profiles to the files of a directory, named after the service, version, kind, trigger
+// and time of the profiles, e.g. "service_1.0.0_heap_memory_20260101T100000.000Z.pb.gz".
+// The profiles beyond the retention are removed after every write, so the directory should be dedicated to them.
+type DirSink struct {
+	dir       string
+	retention Retention
+	mu        sync.Mutex
+}
+
+// NewDirSink returns a sink writing the profiles to the given directory, created if missing.
+func NewDirSink(dir string, retention Retention) (*DirSink, error) {
+	if dir == "" {
+		return nil, errors.New("profile directory is empty")
+	}
+	if retention.MaxFiles < 0 {
+		return nil, errors.New("retention max files must not be negative")
+	}
+	if retention.MaxAge < 0 {
+		return nil, errors.New("retention max age must not be negative")
+	}
+
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return nil, fmt.Errorf("failed to create profile directory: %w", err)
+	}
+
+	return &DirSink{dir: dir, retention: retention}, nil
+}
+
+// Write writes the profile to a file and removes the profiles beyond the retention.
+func (s *DirSink) Write(_ context.Context, p Profile) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	name := strings.Join([]string{
+		fileLabel(p.Service), fileLabel(p.Version), string(p.Kind), string(p.Trigger),
+		p.Time.UTC().Format("20060102T150405.000Z"),
+	}, "_") + profileExt
+
+	if err := os.WriteFile(filepath.Join(s.dir, name), p.Data, 0o600); err != nil {
+		return fmt.Errorf("failed to write profile: %w", err)
+	}
+
+	return s.prune(p.Time)
+}
+
+func (s *DirSink) prune(now time.Time) error {
+	if s.retention.MaxFiles == 0 && s.retention.MaxAge == 0 {
+		return nil
+	}
+
+	entries, err := os.ReadDir(s.dir)
+	if err != nil {
+		return fmt.Errorf("failed to read profile directory: %w", err)
+	}
+
+	type file struct {
+		name    string
+		modTime time.Time
+	}
+	files := make([]file, 0, len(entries))
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), profileExt) {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil {
+			continue
+		}
+		files = append(files, file{name: entry.Name(), modTime: info.ModTime()})
+	}
+	// newest first, by name for the same modification time since the names end with the time of the profiles.
+	slices.SortFunc(files, func(a, b file) int {
+		if c := b.modTime.Compare(a.modTime); c != 0 {
+			return c
+		}
+		return strings.Compare(b.name, a.name)
+	})
+
+	var errs []error
+	for i, f := range files {
+		expired := s.retention.MaxAge > 0 && now.Sub(f.modTime) > s.retention.MaxAge
+		exceeding := s.retention.MaxFiles > 0 && i >= s.retention.MaxFiles
+		if !expired && !exceeding {
+			continue
+		}
+		if err := os.Remove(filepath.Join(s.dir, f.name)); err != nil && !errors.Is(err, os.ErrNotExist) {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// fileLabel replaces the characters of a label that are not safe in a file name.
+func fileLabel(label string) string {
+	if label == "" {
+		return "unknown"
+	}
+	return strings.Map(func(r rune) rune {
+		if r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '.' || r == '-' {
+			return r
+		}
+		return '-'
+	}, label)
+}
+
+// HTTPSink pushes the profiles to an HTTP endpoint with a POST request per profile. The body is the profile
+// and the service, version, kind, trigger and time, in Unix seconds, of the profile are query parameters.
+type HTTPSink struct {
+	url    *url.URL
+	client *http.Client
+}
+
+// NewHTTPSink returns a sink pushing the profiles to the given URL, with the given client or, if nil,
+// a client with a 30s timeout.
+func NewHTTPSink(endpoint string, client *http.Client) (*HTTPSink, error) {
+	if endpoint == "" {
+		return nil, errors.New("profile endpoint is empty")
+	}
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("invalid profile endpoint: %w", err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return nil, fmt.Errorf("invalid profile endpoint scheme %q", u.Scheme)
+	}
+
+	if client == nil {
+		client = &http.Client{Timeout: defaultHTTPTimeout}
+	}
+
+	return &HTTPSink{url: u, client: client}, nil
+}
+
+// Write pushes the profile to the endpoint.
+func (s *HTTPSink) Write(ctx context.Context, p Profile) error {
+	u := *s.url
+	q := u.Query()
+	q.Set("service", p.Service)
+	q.Set("version", p.Version)
+	q.Set("kind", string(p.Kind))
+	q.Set("trigger", string(p.Trigger))
+	q.Set("time", strconv.FormatInt(p.Time.Unix(), 10))
+	u.RawQuery = q.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, u.String(), bytes.NewReader(p.Data))
+	if err != nil {
+		return fmt.Errorf("failed to create profile request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/octet-stream")
+
+	rsp, err := s.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to push profile: %w", err)
+	}
+	defer func() { _ = rsp.Body.Close() }()
+	_, _ = io.Copy(io.Discard, rsp.Body)
+
+	if rsp.StatusCode < http.StatusOK || rsp.StatusCode >= http.StatusMultipleChoices {
+		return fmt.Errorf("failed to push profile: unexpected status %d", rsp.StatusCode)
+	}
+	return nil
+}
@@ -0,0 +1,139 @@
+package profiling
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewDirSink(t *testing.T) {
+	tests := map[string]struct {
+		dir         string
+		retention   Retention
+		expectedErr string
+	}{
+		"success":            {dir: filepath.Join(t.TempDir(), "profiles"), retention: Retention{MaxFiles: 10, MaxAge: time.Hour}},
+		"missing directory":  {expectedErr: "profile directory is empty"},
+		"negative max files": {dir: t.TempDir(), retention: Retention{MaxFiles: -1}, expectedErr: "retention max files must not be negative"},
+		"negative max age":   {dir: t.TempDir(), retention: Retention{MaxAge: -time.Hour}, expectedErr: "retention max age must not be negative"},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, err := NewDirSink(tt.dir, tt.retention)
+			if tt.expectedErr != "" {
+				require.EqualError(t, err, tt.expectedErr)
+				assert.Nil(t, got)
+			} else {
+				require.NoError(t, err)
+				assert.DirExists(t, tt.dir)
+			}
+		})
+	}
+}
+
+func TestDirSink_Write(t *testing.T) {
+	dir := t.TempDir()
+	now := time.Date(2026, 1, 1, 10, 0, 0, 0, time.UTC)
+
+	// expired profile and unrelated file.
+	expired := filepath.Join(dir, "old.pb.gz")
+	require.NoError(t, os.WriteFile(expired, []byte("old"), 0o600))
+	require.NoError(t, os.Chtimes(expired, now.Add(-2*time.Hour), now.Add(-2*time.Hour)))
+	other := filepath.Join(dir, "other.txt")
+	require.NoError(t, os.WriteFile(other, []byte("other"), 0o600))
+	require.NoError(t, os.Chtimes(other, now.Add(-2*time.Hour), now.Add(-2*time.Hour)))
+
+	sink, err := NewDirSink(dir, Retention{MaxFiles: 2, MaxAge: time.Hour})
+	require.NoError(t, err)
+
+	for i := range 3 {
+		require.NoError(t, sink.Write(context.Background(), Profile{
+			Kind: KindHeap, Trigger: TriggerMemory, Service: "my service", Version: "1.0.0",
+			Time: now.Add(time.Duration(i) * time.Second), Data: []byte("profile"),
+		}))
+	}
+
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	names := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		names = append(names, entry.Name())
+	}
+	assert.Equal(t, []string{
+		"my-service_1.0.0_heap_memory_20260101T100001.000Z.pb.gz",
+		"my-service_1.0.0_heap_memory_20260101T100002.000Z.pb.gz",
+		"other.txt",
+	}, names)
+
+	data, err := os.ReadFile(filepath.Join(dir, names[1]))
+	require.NoError(t, err)
+	assert.Equal(t, []byte("profile"), data)
+}
+
+func TestNewHTTPSink(t *testing.T) {
+	tests := map[string]struct {
+		endpoint    string
+		expectedErr string
+	}{
+		"success":          {endpoint: "http://localhost:4040/ingest"},
+		"missing endpoint": {expectedErr: "profile endpoint is empty"},
+		"invalid endpoint": {endpoint: "http://local host", expectedErr: `invalid profile endpoint: parse "http://local host": invalid character " " in host name`},
+		"invalid scheme":   {endpoint: "ftp://localhost", expectedErr: `invalid profile endpoint scheme "ftp"`},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, err := NewHTTPSink(tt.endpoint, nil)
+			if tt.expectedErr != "" {
+				require.EqualError(t, err, tt.expectedErr)
+				assert.Nil(t, got)
+			} else {
+				require.NoError(t, err)
+				assert.NotNil(t, got.client)
+			}
+		})
+	}
+}
+
+func TestHTTPSink_Write(t *testing.T) {
+	var (
+		query url.Values
+		body  []byte
+	)
+	status := http.StatusOK
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "application/octet-stream", r.Header.Get("Content-Type"))
+		query = r.URL.Query()
+		var err error
+		body, err = io.ReadAll(r.Body)
+		assert.NoError(t, err)
+		w.WriteHeader(status)
+	}))
+	defer srv.Close()
+
+	sink, err := NewHTTPSink(srv.URL+"/ingest?tenant=test", srv.Client())
+	require.NoError(t, err)
+
+	p := Profile{
+		Kind: KindCPU, Trigger: TriggerCPU, Service: "service", Version: "1.0.0",
+		Time: time.Unix(1700000000, 0), Data: []byte("profile"),
+	}
+	require.NoError(t, sink.Write(context.Background(), p))
+	assert.Equal(t, url.Values{
+		"tenant": {"test"}, "service": {"service"}, "version": {"1.0.0"},
+		"kind": {"cpu"}, "trigger": {"cpu"}, "time": {"1700000000"},
+	}, query)
+	assert.Equal(t, []byte("profile"), body)
+
+	status = http.StatusInternalServerError
+	require.EqualError(t, sink.Write(context.Background(), p), "failed to push profile: unexpected status 500")
+}
@@ -0,0 +1,71 @@
+package redact
+
+import (
+	"context"
+	"log/slog"
+)
+
+type handler struct {
+	slog.Handler
+	redactor *Redactor
+}
+
+// NewHandler wraps a handler, redacting the message and the attributes of the records with the given Redactor.
+// Attributes are masked by their key, and their string and error values by the patterns.
+func NewHandler(h slog.Handler, r *Redactor) slog.Handler {
+	return &handler{Handler: h, redactor: r}
+}
+
+func (h *handler) Handle(ctx context.Context, r slog.Record) error {
+	redacted := slog.NewRecord(r.Time, r.Level, h.redactor.String(r.Message), r.PC)
+	r.Attrs(func(a slog.Attr) bool {
+		redacted.AddAttrs(h.redactor.Attr(a))
+		return true
+	})
+	return h.Handler.Handle(ctx, redacted)
+}
+
+func (h *handler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	redacted := make([]slog.Attr, 0, len(attrs))
+	for _, a := range attrs {
+		redacted = append(redacted, h.redactor.Attr(a))
+	}
+	return &handler{Handler: h.Handler.WithAttrs(redacted), redactor: h.redactor}
+}
+
+func (h *handler) WithGroup(name string) slog.Handler {
+	return &handler{Handler: h.Handler.WithGroup(name), redactor: h.redactor}
+}
+
+// Attr returns the attribute with its value redacted, see NewHandler. Groups are redacted recursively.
+func (r *Redactor) Attr(a slog.Attr) slog.Attr {
+	if r == nil {
+		return a
+	}
+
+	v := a.Value.Resolve()
+	if v.Kind() != slog.KindGroup && r.IsField(a.Key) {
+		return slog.String(a.Key, Mask)
+	}
+
+	switch v.Kind() {
+	case slog.KindString:
+		return slog.String(a.Key, r.String(v.String()))
+	case slog.KindGroup:
+		group := v.Group()
+		redacted := make([]slog.Attr, 0, len(group))
+		for _, ga := range group {
+			redacted = append(redacted, r.Attr(ga))
+		}
+		return slog.Attr{Key: a.Key, Value: slog.GroupValue(redacted...)}
+	case slog.KindAny:
+		if err, ok := v.Any().(error); ok {
+			msg := err.Error()
+			if redacted := r.String(msg); redacted != msg {
+				return slog.String(a.Key, redacted)
+			}
+		}
+	default:
+	}
+	return slog.Attr{Key: a.Key, Value: v}
+}
@@ -0,0 +1,54 @@
+package redact
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandler(t *testing.T) {
+	r, err := New(Config{
+		Fields:   []string{"password", "credentials"},
+		Patterns: []*regexp.Regexp{regexp.MustCompile(`tok-\w+`)},
+	})
+	require.NoError(t, err)
+
+	out := &bytes.Buffer{}
+	logger := slog.New(NewHandler(slog.NewJSONHandler(out, nil), r)).
+		With(slog.String("password", "p4ss"), slog.String("srv", "test"))
+
+	logger.WithGroup("request").Info("login with tok-123",
+		slog.String("user", "john"),
+		slog.String("header", "Bearer tok-456"),
+		slog.Any("error", errors.New("invalid token tok-789")),
+		slog.Group("credentials", slog.String("user", "john")),
+		slog.Group("auth", slog.String("password", "p4ss"), slog.Int("attempts", 3)),
+	)
+
+	rec := map[string]any{}
+	require.NoError(t, json.Unmarshal(out.Bytes(), &rec))
+	assert.Equal(t, "login with "+Mask, rec[slog.MessageKey])
+	assert.Equal(t, Mask, rec["password"])
+	assert.Equal(t, "test", rec["srv"])
+
+	req := rec["request"].(map[string]any)
+	assert.Equal(t, "john", req["user"])
+	assert.Equal(t, "Bearer "+Mask, req["header"])
+	assert.Equal(t, "invalid token "+Mask, req["error"])
+	// groups are redacted by their attributes.
+	assert.Equal(t, map[string]any{"user": "john"}, req["credentials"])
+	assert.Equal(t, map[string]any{"password": Mask, "attempts": float64(3)}, req["auth"])
+}
+
+func TestHandler_NilRedactor(t *testing.T) {
+	out := &bytes.Buffer{}
+	slog.New(NewHandler(slog.NewJSONHandler(out, nil), nil)).Info("msg", slog.String("password", "p4ss"))
+
+	assert.Contains(t, out.String(), `"password":"p4ss"`)
+}
@@ -0,0 +1,216 @@
+// Package redact masks sensitive data, like credentials, tokens and personal data, in the logs and the span
+// attributes before they leave the process.
+//
+// Values are masked by the name of their field, attribute or header, and the parts of the values matching
+// the configured patterns are masked wherever they appear. Payloads, e.g. message values, response bodies
+// and SQL statements, are truncated to a maximum size as well.
+package redact
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+	"sync"
+	"unicode/utf8"
+)
+
+const (
+	// Mask replaces the redacted values.
+	Mask = "[REDACTED]"
+	// TruncatedSuffix is appended to the truncated payloads.
+	TruncatedSuffix = "...[TRUNCATED]"
+)
+
+// Config defines what is redacted.
+type Config struct {
+	// Fields are the names of the fields, attributes and headers whose values are masked.
+	// They are matched case insensitively and ignoring dashes and underscores, against the whole name
+	// and its last dot separated segment, e.g. "api_key" matches "API-Key", "apiKey" and "http.request.api_key".
+	Fields []string
+	// Patterns mask the parts of the values they match, e.g. bearer tokens or card numbers.
+	Patterns []*regexp.Regexp
+	// AllowedHeaders, when set, are the only headers whose values are kept, the rest are masked.
+	AllowedHeaders []string
+	// DeniedHeaders are the headers whose values are masked, in addition to the ones matching the fields.
+	DeniedHeaders []string
+	// MaxPayloadSize truncates the payloads to the given number of bytes, unlimited when 0.
+	MaxPayloadSize int
+}
+
+// DefaultConfig returns the configuration masking the common credential fields, headers and bearer tokens.
+// Extend it with the fields and patterns of the personal data handled by the service.
+func DefaultConfig() Config {
+	return Config{
+		Fields: []string{
+			"password", "passwd", "secret", "client_secret", "token", "access_token", "refresh_token",
+			"api_key", "authorization", "cookie", "set_cookie",
+		},
+		Patterns: []*regexp.Regexp{
+			regexp.MustCompile(`(?i)bearer\s+[a-z0-9\-._~+/]+=*`),
+		},
+		DeniedHeaders: []string{"Authorization", "Proxy-Authorization", "Cookie", "Set-Cookie"},
+	}
+}
+
+// Redactor masks sensitive data according to its configuration.
+// The methods of a nil Redactor return the values intact.
+type Redactor struct {
+	fields         map[string]struct{}
+	patterns       []*regexp.Regexp
+	allowedHeaders map[string]struct{}
+	deniedHeaders  map[string]struct{}
+	maxPayloadSize int
+}
+
+// New creates a Redactor from the given configuration.
+func New(cfg Config) (*Redactor, error) {
+	var errs []error
+	if cfg.MaxPayloadSize < 0 {
+		errs = append(errs, errors.New("max payload size must not be negative"))
+	}
+	for i, p := range cfg.Patterns {
+		if p == nil {
+			errs = append(errs, fmt.Errorf("pattern %d is nil", i))
+		}
+	}
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+
+	r := &Redactor{
+		fields:         make(map[string]struct{}, len(cfg.Fields)),
+		patterns:       cfg.Patterns,
+		allowedHeaders: make(map[string]struct{}, len(cfg.AllowedHeaders)),
+		deniedHeaders:  make(map[string]struct{}, len(cfg.DeniedHeaders)),
+		maxPayloadSize: cfg.MaxPayloadSize,
+	}
+	for _, f := range cfg.Fields {
+		r.fields[normalize(f)] = struct{}{}
+	}
+	for _, h := range cfg.AllowedHeaders {
+		r.allowedHeaders[http.CanonicalHeaderKey(h)] = struct{}{}
+	}
+	for _, h := range cfg.DeniedHeaders {
+		r.deniedHeaders[http.CanonicalHeaderKey(h)] = struct{}{}
+	}
+	return r, nil
+}
+
+// IsField returns true if the values of the field, attribute or header with the given name are masked.
+func (r *Redactor) IsField(name string) bool {
+	if r == nil || len(r.fields) == 0 {
+		return false
+	}
+	if _, ok := r.fields[normalize(name)]; ok {
+		return true
+	}
+	if i := strings.LastIndexByte(name, '.'); i != -1 {
+		_, ok := r.fields[normalize(name[i+1:])]
+		return ok
+	}
+	return false
+}
+
+// Value returns the mask if the field with the given name is masked, or the value with its parts
+// matching the patterns masked.
+func (r *Redactor) Value(name, value string) string {
+	if r.IsField(name) {
+		return Mask
+	}
+	return r.String(value)
+}
+
+// String returns the value with its parts matching the patterns masked.
+func (r *Redactor) String(value string) string {
+	if r == nil {
+		return value
+	}
+	for _, p := range r.patterns {
+		value = p.ReplaceAllLiteralString(value, Mask)
+	}
+	return value
+}
+
+// Payload returns the payload with its parts matching the patterns masked, truncated to the max payload size.
+func (r *Redactor) Payload(payload string) string {
+	if r == nil {
+		return payload
+	}
+	payload = r.String(payload)
+	if r.maxPayloadSize == 0 || len(payload) <= r.maxPayloadSize {
+		return payload
+	}
+	end := r.maxPayloadSize
+	// do not split a multibyte character.
+	for end > 0 && !utf8.RuneStart(payload[end]) {
+		end--
+	}
+	return payload[:end] + TruncatedSuffix
+}
+
+// Header returns the value of the header with the given name, masked if the header is not allowed,
+// is denied or matches the fields, or with its parts matching the patterns masked otherwise.
+func (r *Redactor) Header(name, value string) string {
+	if r == nil {
+		return value
+	}
+	name = http.CanonicalHeaderKey(name)
+	if len(r.allowedHeaders) > 0 {
+		if _, ok := r.allowedHeaders[name]; !ok {
+			return Mask
+		}
+	}
+	if _, ok := r.deniedHeaders[name]; ok {
+		return Mask
+	}
+	return r.Value(name, value)
+}
+
+// Headers returns a copy of the headers with their values redacted, see Header.
+func (r *Redactor) Headers(h http.Header) http.Header {
+	redacted := make(http.Header, len(h))
+	for name, values := range h {
+		vv := make([]string, 0, len(values))
+		for _, v := range values {
+			vv = append(vv, r.Header(name, v))
+		}
+		redacted[name] = vv
+	}
+	return redacted
+}
+
+var separators = strings.NewReplacer("-", "", "_", "")
+
+func normalize(name string) string {
+	return strings.ToLower(separators.Replace(name))
+}
+
+var (
+	defaultRedactor   = mustNew(DefaultConfig())
+	defaultRedactorMu sync.RWMutex
+)
+
+func mustNew(cfg Config) *Redactor {
+	r, err := New(cfg)
+	if err != nil {
+		panic(err)
+	}
+	return r
+}
+
+// Default returns the Redactor used by the patron logger, exporters, components and clients,
+// created from DefaultConfig unless replaced with SetDefault.
+func Default() *Redactor {
+	defaultRedactorMu.RLock()
+	defer defaultRedactorMu.RUnlock()
+	return defaultRedactor
+}
+
+// SetDefault replaces the default Redactor. A nil Redactor disables the redaction.
+func SetDefault(r *Redactor) {
+	defaultRedactorMu.Lock()
+	defer defaultRedactorMu.Unlock()
+	defaultRedactor = r
+}
@@ -0,0 +1,140 @@
+package redact
+
+import (
+	"net/http"
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNew(t *testing.T) {
+	got, err := New(Config{MaxPayloadSize: -1, Patterns: []*regexp.Regexp{nil}})
+	require.EqualError(t, err, "max payload size must not be negative\npattern 0 is nil")
+	assert.Nil(t, got)
+
+	got, err = New(DefaultConfig())
+	require.NoError(t, err)
+	assert.NotNil(t, got)
+}
+
+func TestRedactor_Value(t *testing.T) {
+	r, err := New(Config{
+		Fields:   []string{"password", "api_key"},
+		Patterns: []*regexp.Regexp{regexp.MustCompile(`\d{4}-\d{4}-\d{4}-\d{4}`)},
+	})
+	require.NoError(t, err)
+
+	tests := map[string]struct {
+		name     string
+		value    string
+		expected string
+	}{
+		"field":                 {name: "password", value: "123", expected: Mask},
+		"field case":            {name: "Password", value: "123", expected: Mask},
+		"field separators":      {name: "API-Key", value: "123", expected: Mask},
+		"field camel case":      {name: "apiKey", value: "123", expected: Mask},
+		"field last segment":    {name: "http.request.api_key", value: "123", expected: Mask},
+		"other field":           {name: "user", value: "john", expected: "john"},
+		"other field substring": {name: "password_policy", value: "strict", expected: "strict"},
+		"pattern":               {name: "card", value: "paid with 1234-5678-9012-3456", expected: "paid with " + Mask},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, r.Value(tt.name, tt.value))
+		})
+	}
+}
+
+func TestRedactor_Payload(t *testing.T) {
+	r, err := New(Config{
+		Patterns:       []*regexp.Regexp{regexp.MustCompile(`secret`)},
+		MaxPayloadSize: 5,
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, "short", r.Payload("short"))
+	assert.Equal(t, "a lon"+TruncatedSuffix, r.Payload("a long payload"))
+	assert.Equal(t, "[REDA"+TruncatedSuffix, r.Payload("secret"))
+	// multibyte characters are not split.
+	assert.Equal(t, "abcé"+TruncatedSuffix, r.Payload("abcéf"))
+	assert.Equal(t, "abcd"+TruncatedSuffix, r.Payload("abcdé"))
+
+	unlimited, err := New(Config{})
+	require.NoError(t, err)
+	assert.Equal(t, "a long payload", unlimited.Payload("a long payload"))
+}
+
+func TestRedactor_Headers(t *testing.T) {
+	h := http.Header{
+		"Authorization": []string{"Bearer abc"},
+		"X-Api-Key":     []string{"key"},
+		"Content-Type":  []string{"application/json"},
+		"X-Forwarded":   []string{"for=bearer abc.def"},
+	}
+
+	tests := map[string]struct {
+		cfg      Config
+		expected http.Header
+	}{
+		"default": {
+			cfg: DefaultConfig(),
+			expected: http.Header{
+				"Authorization": []string{Mask},
+				"X-Api-Key":     []string{"key"},
+				"Content-Type":  []string{"application/json"},
+				"X-Forwarded":   []string{"for=" + Mask},
+			},
+		},
+		"denied": {
+			cfg: Config{DeniedHeaders: []string{"x-api-key"}},
+			expected: http.Header{
+				"Authorization": []string{"Bearer abc"},
+				"X-Api-Key":     []string{Mask},
+				"Content-Type":  []string{"application/json"},
+				"X-Forwarded":   []string{"for=bearer abc.def"},
+			},
+		},
+		"allowed": {
+			cfg: Config{AllowedHeaders: []string{"content-type", "authorization"}, DeniedHeaders: []string{"Authorization"}},
+			expected: http.Header{
+				"Authorization": []string{Mask},
+				"X-Api-Key":     []string{Mask},
+				"Content-Type":  []string{"application/json"},
+				"X-Forwarded":   []string{Mask},
+			},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			r, err := New(tt.cfg)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, r.Headers(h))
+		})
+	}
+
+	// the headers are copied.
+	assert.Equal(t, "Bearer abc", h.Get("Authorization"))
+}
+
+func TestRedactor_Nil(t *testing.T) {
+	var r *Redactor
+
+	assert.False(t, r.IsField("password"))
+	assert.Equal(t, "123", r.Value("password", "123"))
+	assert.Equal(t, "payload", r.Payload("payload"))
+	assert.Equal(t, "Bearer abc", r.Header("Authorization", "Bearer abc"))
+}
+
+func TestDefault(t *testing.T) {
+	assert.Equal(t, Mask, Default().Value("password", "123"))
+
+	r, err := New(Config{})
+	require.NoError(t, err)
+	SetDefault(r)
+	t.Cleanup(func() { SetDefault(mustNew(DefaultConfig())) })
+
+	assert.Equal(t, r, Default())
+	assert.Equal(t, "123", Default().Value("password", "123"))
+}
@@ -0,0 +1,88 @@
+package redact
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+// KeyValue returns the span attribute with its value redacted. Attributes are masked by their key,
+// and their string values by the patterns.
+func (r *Redactor) KeyValue(kv attribute.KeyValue) attribute.KeyValue {
+	if r == nil {
+		return kv
+	}
+	if r.IsField(string(kv.Key)) {
+		return kv.Key.String(Mask)
+	}
+	switch kv.Value.Type() {
+	case attribute.STRING:
+		return kv.Key.String(r.String(kv.Value.AsString()))
+	case attribute.STRINGSLICE:
+		values := kv.Value.AsStringSlice()
+		redacted := make([]string, 0, len(values))
+		for _, v := range values {
+			redacted = append(redacted, r.String(v))
+		}
+		return kv.Key.StringSlice(redacted)
+	default:
+		return kv
+	}
+}
+
+// KeyValues returns a copy of the span attributes with their values redacted, see KeyValue.
+func (r *Redactor) KeyValues(kvs []attribute.KeyValue) []attribute.KeyValue {
+	redacted := make([]attribute.KeyValue, 0, len(kvs))
+	for _, kv := range kvs {
+		redacted = append(redacted, r.KeyValue(kv))
+	}
+	return redacted
+}
+
+type spanExporter struct {
+	sdktrace.SpanExporter
+}
+
+// NewSpanExporter wraps a span exporter, redacting the attributes of the spans and of their events, like the
+// messages of the recorded errors, with the default Redactor at the time they are exported.
+func NewSpanExporter(exp sdktrace.SpanExporter) sdktrace.SpanExporter {
+	return &spanExporter{SpanExporter: exp}
+}
+
+func (e *spanExporter) ExportSpans(ctx context.Context, spans []sdktrace.ReadOnlySpan) error {
+	r := Default()
+	if r == nil {
+		return e.SpanExporter.ExportSpans(ctx, spans)
+	}
+
+	redacted := make([]sdktrace.ReadOnlySpan, 0, len(spans))
+	for _, sp := range spans {
+		redacted = append(redacted, newRedactedSpan(sp, r))
+	}
+	return e.SpanExporter.ExportSpans(ctx, redacted)
+}
+
+// redactedSpan overrides the attributes of an ended span.
+type redactedSpan struct {
+	sdktrace.ReadOnlySpan
+	attrs  []attribute.KeyValue
+	events []sdktrace.Event
+}
+
+func newRedactedSpan(sp sdktrace.ReadOnlySpan, r *Redactor) *redactedSpan {
+	events := make([]sdktrace.Event, 0, len(sp.Events()))
+	for _, ev := range sp.Events() {
+		ev.Attributes = r.KeyValues(ev.Attributes)
+		events = append(events, ev)
+	}
+	return &redactedSpan{ReadOnlySpan: sp, attrs: r.KeyValues(sp.Attributes()), events: events}
+}
+
+func (s *redactedSpan) Attributes() []attribute.KeyValue {
+	return s.attrs
+}
+
+func (s *redactedSpan) Events() []sdktrace.Event {
+	return s.events
+}
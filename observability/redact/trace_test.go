@@ -0,0 +1,54 @@
+package redact
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestRedactor_KeyValue(t *testing.T) {
+	r := Default()
+
+	tests := map[string]struct {
+		kv       attribute.KeyValue
+		expected attribute.KeyValue
+	}{
+		"field":        {kv: attribute.String("http.request.header.authorization", "Basic abc"), expected: attribute.String("http.request.header.authorization", Mask)},
+		"non string":   {kv: attribute.Int("password", 1), expected: attribute.String("password", Mask)},
+		"pattern":      {kv: attribute.String("db.statement", "token=Bearer abc"), expected: attribute.String("db.statement", "token="+Mask)},
+		"string slice": {kv: attribute.StringSlice("values", []string{"a", "Bearer abc"}), expected: attribute.StringSlice("values", []string{"a", Mask})},
+		"intact":       {kv: attribute.Int("http.response.status_code", 200), expected: attribute.Int("http.response.status_code", 200)},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, r.KeyValue(tt.kv))
+		})
+	}
+}
+
+func TestNewSpanExporter(t *testing.T) {
+	exp := tracetest.NewInMemoryExporter()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSyncer(NewSpanExporter(exp)))
+	t.Cleanup(func() { require.NoError(t, tp.Shutdown(context.Background())) })
+
+	_, sp := tp.Tracer("test").Start(context.Background(), "test")
+	// the attributes are redacted on export, whenever they are set.
+	sp.SetAttributes(attribute.String("user.password", "p4ss"), attribute.String("user.name", "john"))
+	sp.RecordError(errors.New("invalid header Bearer abc"))
+	sp.End()
+
+	spans := exp.GetSpans()
+	require.Len(t, spans, 1)
+	assert.Equal(t, []attribute.KeyValue{
+		attribute.String("user.password", Mask),
+		attribute.String("user.name", "john"),
+	}, spans[0].Attributes)
+	require.Len(t, spans[0].Events, 1)
+	assert.Contains(t, spans[0].Events[0].Attributes, attribute.String("exception.message", "invalid header "+Mask))
+}
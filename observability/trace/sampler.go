@@ -0,0 +1,128 @@
+package trace
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.41.0"
+	"go.opentelemetry.io/otel/trace"
+	"golang.org/x/time/rate"
+)
+
+// messagingSourceNameKey is the deprecated attribute the Kafka instrumentation sets to the consumed topic.
+const messagingSourceNameKey = attribute.Key("messaging.source.name")
+
+// ParentBasedRatioSampler samples a ratio of the root spans and follows the decision of the parent otherwise.
+func ParentBasedRatioSampler(ratio float64) sdktrace.Sampler {
+	return sdktrace.ParentBased(sdktrace.TraceIDRatioBased(ratio))
+}
+
+// Rule selects the sampler of the spans it matches, see RuleSampler.
+type Rule struct {
+	desc    string
+	match   func(p sdktrace.SamplingParameters) bool
+	sampler sdktrace.Sampler
+}
+
+// NameRule matches the spans with the given name, e.g. "amqp orders".
+func NameRule(name string, sampler sdktrace.Sampler) Rule {
+	return Rule{
+		desc:    "name=" + name,
+		match:   func(p sdktrace.SamplingParameters) bool { return p.Name == name },
+		sampler: sampler,
+	}
+}
+
+// AttributeRule matches the spans started with the given string attribute.
+func AttributeRule(key attribute.Key, value string, sampler sdktrace.Sampler) Rule {
+	return Rule{
+		desc:    string(key) + "=" + value,
+		match:   func(p sdktrace.SamplingParameters) bool { return hasAttribute(p.Attributes, value, key) },
+		sampler: sampler,
+	}
+}
+
+// RouteRule matches the HTTP server spans of requests to the given path, e.g. "/alive".
+func RouteRule(path string, sampler sdktrace.Sampler) Rule {
+	return AttributeRule(semconv.URLPathKey, path, sampler)
+}
+
+// TopicRule matches the spans of the messages consumed from or produced to the given topic or queue.
+func TopicRule(topic string, sampler sdktrace.Sampler) Rule {
+	return Rule{
+		desc: "topic=" + topic,
+		match: func(p sdktrace.SamplingParameters) bool {
+			return hasAttribute(p.Attributes, topic, semconv.MessagingDestinationNameKey, messagingSourceNameKey)
+		},
+		sampler: sampler,
+	}
+}
+
+func hasAttribute(attrs []attribute.KeyValue, value string, keys ...attribute.Key) bool {
+	for _, attr := range attrs {
+		for _, key := range keys {
+			if attr.Key == key && attr.Value.AsString() == value {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+type ruleSampler struct {
+	fallback sdktrace.Sampler
+	rules    []Rule
+}
+
+// RuleSampler samples the spans with the sampler of the first rule matching them, and the rest with the fallback.
+// Rules are evaluated before the fallback, so wrapping a parent based fallback applies them to child spans as well.
+func RuleSampler(fallback sdktrace.Sampler, rules ...Rule) sdktrace.Sampler {
+	return ruleSampler{fallback: fallback, rules: rules}
+}
+
+func (s ruleSampler) ShouldSample(p sdktrace.SamplingParameters) sdktrace.SamplingResult {
+	for _, rule := range s.rules {
+		if rule.match(p) {
+			return rule.sampler.ShouldSample(p)
+		}
+	}
+	return s.fallback.ShouldSample(p)
+}
+
+func (s ruleSampler) Description() string {
+	rules := make([]string, 0, len(s.rules))
+	for _, rule := range s.rules {
+		rules = append(rules, rule.desc+":"+rule.sampler.Description())
+	}
+	return fmt.Sprintf("RuleSampler{%s,fallback:%s}", strings.Join(rules, ","), s.fallback.Description())
+}
+
+type rateLimitedSampler struct {
+	perSecond float64
+	limiter   *rate.Limiter
+}
+
+// RateLimitedSampler samples up to the given number of spans per second and drops the rest.
+// Combine it with sdktrace.ParentBased to limit the traces instead of the spans.
+func RateLimitedSampler(perSecond float64) sdktrace.Sampler {
+	burst := int(math.Max(1, math.Ceil(perSecond)))
+	return rateLimitedSampler{perSecond: perSecond, limiter: rate.NewLimiter(rate.Limit(perSecond), burst)}
+}
+
+func (s rateLimitedSampler) ShouldSample(p sdktrace.SamplingParameters) sdktrace.SamplingResult {
+	decision := sdktrace.Drop
+	if s.limiter.Allow() {
+		decision = sdktrace.RecordAndSample
+	}
+	return sdktrace.SamplingResult{
+		Decision:   decision,
+		Tracestate: trace.SpanContextFromContext(p.ParentContext).TraceState(),
+	}
+}
+
+func (s rateLimitedSampler) Description() string {
+	return fmt.Sprintf("RateLimitedSampler{%g}", s.perSecond)
+}
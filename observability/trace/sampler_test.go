@@ -0,0 +1,91 @@
+package trace
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.41.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestRuleSampler(t *testing.T) {
+	t.Parallel()
+
+	sampler := RuleSampler(sdktrace.AlwaysSample(),
+		RouteRule("/alive", sdktrace.NeverSample()),
+		TopicRule("hot", sdktrace.NeverSample()),
+		NameRule("amqp orders", sdktrace.NeverSample()),
+		AttributeRule("tenant", "internal", sdktrace.NeverSample()),
+	)
+
+	tests := map[string]struct {
+		name     string
+		attrs    []attribute.KeyValue
+		expected sdktrace.SamplingDecision
+	}{
+		"route":                  {name: "GET /alive", attrs: []attribute.KeyValue{semconv.URLPath("/alive")}, expected: sdktrace.Drop},
+		"other route":            {name: "GET /users", attrs: []attribute.KeyValue{semconv.URLPath("/users")}, expected: sdktrace.RecordAndSample},
+		"kafka topic":            {name: "hot process", attrs: []attribute.KeyValue{messagingSourceNameKey.String("hot")}, expected: sdktrace.Drop},
+		"destination":            {name: "publish", attrs: []attribute.KeyValue{semconv.MessagingDestinationName("hot")}, expected: sdktrace.Drop},
+		"other topic":            {name: "cold process", attrs: []attribute.KeyValue{messagingSourceNameKey.String("cold")}, expected: sdktrace.RecordAndSample},
+		"name":                   {name: "amqp orders", expected: sdktrace.Drop},
+		"attribute":              {name: "job", attrs: []attribute.KeyValue{attribute.String("tenant", "internal")}, expected: sdktrace.Drop},
+		"no match uses fallback": {name: "job", expected: sdktrace.RecordAndSample},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			got := sampler.ShouldSample(sdktrace.SamplingParameters{
+				ParentContext: context.Background(),
+				TraceID:       trace.TraceID{1},
+				Name:          tt.name,
+				Attributes:    tt.attrs,
+			})
+			assert.Equal(t, tt.expected, got.Decision)
+		})
+	}
+
+	assert.Equal(t, "RuleSampler{url.path=/alive:AlwaysOffSampler,topic=hot:AlwaysOffSampler,"+
+		"name=amqp orders:AlwaysOffSampler,tenant=internal:AlwaysOffSampler,fallback:AlwaysOnSampler}", sampler.Description())
+}
+
+func TestRateLimitedSampler(t *testing.T) {
+	t.Parallel()
+
+	sampler := RateLimitedSampler(2)
+	params := sdktrace.SamplingParameters{ParentContext: context.Background(), TraceID: trace.TraceID{1}}
+
+	var sampled int
+	for range 10 {
+		if sampler.ShouldSample(params).Decision == sdktrace.RecordAndSample {
+			sampled++
+		}
+	}
+	assert.Equal(t, 2, sampled)
+	assert.Equal(t, "RateLimitedSampler{2}", sampler.Description())
+}
+
+func TestParentBasedRatioSampler(t *testing.T) {
+	t.Parallel()
+
+	sampler := ParentBasedRatioSampler(0)
+
+	root := sdktrace.SamplingParameters{ParentContext: context.Background(), TraceID: trace.TraceID{1}}
+	assert.Equal(t, sdktrace.Drop, sampler.ShouldSample(root).Decision)
+
+	parent := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    trace.TraceID{1},
+		SpanID:     trace.SpanID{1},
+		TraceFlags: trace.FlagsSampled,
+		Remote:     true,
+	})
+	child := sdktrace.SamplingParameters{
+		ParentContext: trace.ContextWithRemoteSpanContext(context.Background(), parent),
+		TraceID:       trace.TraceID{1},
+	}
+	assert.Equal(t, sdktrace.RecordAndSample, sampler.ShouldSample(child).Decision)
+}
@@ -4,9 +4,12 @@ import (
 	"context"
 	"sync"
 
+	"github.com/beatlabs/patron/observability/redact"
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/codes"
 	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
 	"go.opentelemetry.io/otel/propagation"
 	"go.opentelemetry.io/otel/sdk/resource"
 	sdktrace "go.opentelemetry.io/otel/sdk/trace"
@@ -30,19 +33,46 @@ func StartSpan(ctx context.Context, name string, opts ...trace.SpanStartOption)
 }
 
 // SetupGRPC configures the global tracer with the OTLP gRPC exporter.
-func SetupGRPC(ctx context.Context, name string, res *resource.Resource) (*sdktrace.TracerProvider, error) {
+func SetupGRPC(ctx context.Context, name string, res *resource.Resource, oo ...sdktrace.TracerProviderOption) (*sdktrace.TracerProvider, error) {
 	exp, err := otlptracegrpc.New(ctx)
 	if err != nil {
 		return nil, err
 	}
 
-	return Setup(name, res, exp), nil
+	return Setup(name, res, exp, oo...), nil
+}
+
+// SetupHTTP configures the global tracer with the OTLP HTTP exporter.
+func SetupHTTP(ctx context.Context, name string, res *resource.Resource, oo ...sdktrace.TracerProviderOption) (*sdktrace.TracerProvider, error) {
+	exp, err := otlptracehttp.New(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return Setup(name, res, exp, oo...), nil
+}
+
+// SetupStdout configures the global tracer with an exporter pretty printing the spans to stdout.
+func SetupStdout(name string, res *resource.Resource, oo ...sdktrace.TracerProviderOption) (*sdktrace.TracerProvider, error) {
+	exp, err := stdouttrace.New(stdouttrace.WithPrettyPrint())
+	if err != nil {
+		return nil, err
+	}
+
+	return Setup(name, res, exp, oo...), nil
 }
 
 // Setup TraceProvider with the given resource and exporter.
-func Setup(name string, res *resource.Resource, exp sdktrace.SpanExporter) *sdktrace.TracerProvider {
-	tp := newTraceProvider(res, exp)
+// The span attributes are redacted with the default redact.Redactor before being exported.
+// The options, e.g. sdktrace.WithSampler, are applied after the exporter and the resource.
+func Setup(name string, res *resource.Resource, exp sdktrace.SpanExporter, oo ...sdktrace.TracerProviderOption) *sdktrace.TracerProvider {
+	tp := newTraceProvider(res, exp, oo...)
+	SetupWithTracerProvider(name, tp)
+	return tp
+}
 
+// SetupWithTracerProvider configures the global tracer with a custom tracer provider.
+func SetupWithTracerProvider(name string, tp trace.TracerProvider) {
 	otel.SetTracerProvider(tp)
 	prop := propagation.NewCompositeTextMapPropagator(
 		propagation.TraceContext{},
@@ -53,15 +83,14 @@ func Setup(name string, res *resource.Resource, exp sdktrace.SpanExporter) *sdkt
 	globalTracerMu.Lock()
 	globalTracer = tp.Tracer(name)
 	globalTracerMu.Unlock()
-
-	return tp
 }
 
-func newTraceProvider(res *resource.Resource, exp sdktrace.SpanExporter) *sdktrace.TracerProvider {
+func newTraceProvider(res *resource.Resource, exp sdktrace.SpanExporter, oo ...sdktrace.TracerProviderOption) *sdktrace.TracerProvider {
 	opts := []sdktrace.TracerProviderOption{
-		sdktrace.WithBatcher(exp),
+		sdktrace.WithBatcher(redact.NewSpanExporter(exp)),
 		sdktrace.WithResource(res),
 	}
+	opts = append(opts, oo...)
 	return sdktrace.NewTracerProvider(opts...)
 }
 
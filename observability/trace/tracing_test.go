@@ -59,3 +59,15 @@ func TestSetSpanStatus(t *testing.T) {
 		assert.Equal(t, "error", spans[0].Events[0].Attributes[1].Value.AsString())
 	})
 }
+
+func TestSetupHTTP(t *testing.T) {
+	got, err := SetupHTTP(context.Background(), "test", resource.Default())
+	require.NoError(t, err)
+	assert.NotNil(t, got)
+}
+
+func TestSetupStdout(t *testing.T) {
+	got, err := SetupStdout("test", resource.Default())
+	require.NoError(t, err)
+	assert.NotNil(t, got)
+}
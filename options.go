@@ -1,14 +1,29 @@
 package patron
 
 import (
+	"context"
 	"errors"
+	"fmt"
+	"io"
 	"log/slog"
+	"time"
+
+	"github.com/beatlabs/patron/component/http/middleware"
+	"github.com/beatlabs/patron/config"
+	"github.com/beatlabs/patron/observability"
+	"github.com/beatlabs/patron/observability/log"
+	"github.com/beatlabs/patron/observability/profiling"
+	"github.com/beatlabs/patron/observability/redact"
+	"go.opentelemetry.io/otel/metric"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
 )
 
 // OptionFunc configures the Service.
 type OptionFunc func(svc *Service) error
 
-// WithSIGHUP registers a handler invoked when SIGHUP is received.
+// WithSIGHUP registers a handler invoked when SIGHUP is received, as part of the reload of the service.
+// Use WithReloadHandler for handlers that can fail.
 func WithSIGHUP(handler func()) OptionFunc {
 	return func(svc *Service) error {
 		if handler == nil {
@@ -22,6 +37,22 @@ func WithSIGHUP(handler func()) OptionFunc {
 	}
 }
 
+// WithReloadHandler registers a named handler invoked when SIGHUP is received, after the configuration files
+// are read again and before the components implementing Reloader are reloaded.
+// Handlers are invoked in registration order and their errors are reported as a failed reload.
+func WithReloadHandler(name string, handler func(ctx context.Context) error) OptionFunc {
+	return func(svc *Service) error {
+		if name == "" {
+			return errors.New("reload handler name is empty")
+		}
+		if handler == nil {
+			return errors.New("reload handler is nil")
+		}
+		svc.reloadHandlers = append(svc.reloadHandlers, reloadHandler{name: name, fn: handler})
+		return nil
+	}
+}
+
 // WithLogFields adds structured logging attributes to the default logger.
 func WithLogFields(attrs ...slog.Attr) OptionFunc {
 	return func(svc *Service) error {
@@ -49,3 +80,210 @@ func WithJSONLogger() OptionFunc {
 		return nil
 	}
 }
+
+// WithLogOutput writes the logs to the given writer instead of stderr.
+func WithLogOutput(w io.Writer) OptionFunc {
+	return func(svc *Service) error {
+		if w == nil {
+			return errors.New("log output is nil")
+		}
+		svc.observabilityCfg.LogConfig.Output = w
+		return nil
+	}
+}
+
+// WithLogSampling limits the records logged repeatedly with the same level and message,
+// e.g. the errors of a consumer loop while a dependency is down. See log.SamplingConfig.
+func WithLogSampling(cfg log.SamplingConfig) OptionFunc {
+	return func(svc *Service) error {
+		if err := cfg.Validate(); err != nil {
+			return err
+		}
+		svc.observabilityCfg.LogConfig.Sampling = &cfg
+		return nil
+	}
+}
+
+// WithRedaction replaces the default redaction of the logs, the span attributes and the payloads logged by
+// the components and clients, which masks the common credential fields, headers and bearer tokens.
+// Start from redact.DefaultConfig to extend it.
+func WithRedaction(cfg redact.Config) OptionFunc {
+	return func(svc *Service) error {
+		r, err := redact.New(cfg)
+		if err != nil {
+			return err
+		}
+		svc.observabilityCfg.Redactor = r
+		return nil
+	}
+}
+
+// WithMeterProvider uses the given meter provider instead of exporting metrics with OTLP.
+// The provider is owned by the caller, which has to shut it down.
+func WithMeterProvider(mp metric.MeterProvider) OptionFunc {
+	return func(svc *Service) error {
+		if mp == nil {
+			return errors.New("meter provider is nil")
+		}
+		svc.observabilityCfg.MeterProvider = mp
+		return nil
+	}
+}
+
+// WithTracerProvider uses the given tracer provider instead of exporting spans with OTLP.
+// The provider is owned by the caller, which has to shut it down.
+func WithTracerProvider(tp trace.TracerProvider) OptionFunc {
+	return func(svc *Service) error {
+		if tp == nil {
+			return errors.New("tracer provider is nil")
+		}
+		svc.observabilityCfg.TracerProvider = tp
+		return nil
+	}
+}
+
+// WithMetricExporter selects where the metrics are exported to, OTLP over gRPC by default.
+// With observability.ExporterPrometheus the metrics are served on GET /metrics by the management server
+// and by the routers created with router.WithPrometheusMetrics.
+func WithMetricExporter(exporter observability.Exporter) OptionFunc {
+	return func(svc *Service) error {
+		switch exporter {
+		case observability.ExporterOTLPGRPC, observability.ExporterOTLPHTTP, observability.ExporterStdout,
+			observability.ExporterPrometheus, observability.ExporterNone:
+		default:
+			return fmt.Errorf("unsupported metric exporter %q", exporter)
+		}
+		svc.observabilityCfg.MetricExporter = exporter
+		return nil
+	}
+}
+
+// WithoutRuntimeMetrics stops producing the Go runtime and process metrics, which are exported by default
+// along the metrics of the service: goroutines, heap, GC pauses, scheduler latency, CPU, open file descriptors
+// and uptime.
+func WithoutRuntimeMetrics() OptionFunc {
+	return func(svc *Service) error {
+		svc.observabilityCfg.DisableRuntimeMetrics = true
+		return nil
+	}
+}
+
+// WithMetricInterval sets the interval the metrics are pushed at, 20s by default.
+func WithMetricInterval(interval time.Duration) OptionFunc {
+	return func(svc *Service) error {
+		if interval <= 0 {
+			return errors.New("negative or zero metric interval provided")
+		}
+		svc.observabilityCfg.MetricInterval = interval
+		return nil
+	}
+}
+
+// WithTraceExporter selects where the spans are exported to, OTLP over gRPC by default.
+func WithTraceExporter(exporter observability.Exporter) OptionFunc {
+	return func(svc *Service) error {
+		switch exporter {
+		case observability.ExporterOTLPGRPC, observability.ExporterOTLPHTTP, observability.ExporterStdout,
+			observability.ExporterNone:
+		default:
+			return fmt.Errorf("unsupported trace exporter %q", exporter)
+		}
+		svc.observabilityCfg.TraceExporter = exporter
+		return nil
+	}
+}
+
+// WithLogExporter selects where the log records are exported to, OTLP over gRPC by default.
+// The records are exported in addition to being written to the log output, with the trace context of the log calls.
+func WithLogExporter(exporter observability.Exporter) OptionFunc {
+	return func(svc *Service) error {
+		switch exporter {
+		case observability.ExporterOTLPGRPC, observability.ExporterOTLPHTTP, observability.ExporterStdout,
+			observability.ExporterNone:
+		default:
+			return fmt.Errorf("unsupported log exporter %q", exporter)
+		}
+		svc.observabilityCfg.LogExporter = exporter
+		return nil
+	}
+}
+
+// WithTraceSampler sets the sampler deciding which spans are recorded and exported, e.g.
+// trace.ParentBasedRatioSampler or trace.RuleSampler of the observability/trace package.
+// By default the SDK sampler is used, which honors the OTEL_TRACES_SAMPLER environment variable.
+func WithTraceSampler(sampler sdktrace.Sampler) OptionFunc {
+	return func(svc *Service) error {
+		if sampler == nil {
+			return errors.New("trace sampler is nil")
+		}
+		svc.observabilityCfg.TraceSampler = sampler
+		return nil
+	}
+}
+
+// WithShutdownDelay delays stopping the components after the service is marked as not ready,
+// allowing load balancers to stop routing traffic before the servers drain.
+func WithShutdownDelay(delay time.Duration) OptionFunc {
+	return func(svc *Service) error {
+		if delay <= 0 {
+			return errors.New("negative or zero shutdown delay provided")
+		}
+		svc.shutdownDelay = delay
+		return nil
+	}
+}
+
+// WithShutdownTimeout sets how long the shutdown hook of each component, see Shutdowner, is waited for
+// before its run context is canceled, 30s by default.
+func WithShutdownTimeout(timeout time.Duration) OptionFunc {
+	return func(svc *Service) error {
+		if timeout <= 0 {
+			return errors.New("negative or zero shutdown timeout provided")
+		}
+		svc.shutdownTimeout = timeout
+		return nil
+	}
+}
+
+// WithManagementServer starts an HTTP component on the given port, separate from the public routes,
+// serving liveness, readiness, profiling, expvar, log level, build info and configuration endpoints.
+// The middlewares, e.g. authentication, are applied to the profiling, log level, build info and configuration endpoints.
+func WithManagementServer(port int, mm ...middleware.Func) OptionFunc {
+	return func(svc *Service) error {
+		if port <= 0 || port > 65535 {
+			return errors.New("invalid management port provided")
+		}
+		svc.management = &managementConfig{port: port, middlewares: mm}
+		return nil
+	}
+}
+
+// WithProfiler runs a profiler capturing profiles periodically and when the configured thresholds are crossed,
+// labelled with the name and version of the service unless set in the configuration.
+func WithProfiler(cfg profiling.Config) OptionFunc {
+	return func(svc *Service) error {
+		if cfg.Service == "" {
+			cfg.Service = svc.name
+		}
+		if cfg.Version == "" {
+			cfg.Version = svc.version
+		}
+		profiler, err := profiling.New(cfg)
+		if err != nil {
+			return err
+		}
+		svc.profiler = profiler
+		return nil
+	}
+}
+
+// WithConfigFiles sets the YAML or JSON files the configuration of the service and its components is loaded from.
+// Values of later files override earlier ones and environment variables override all files.
+func WithConfigFiles(paths ...string) OptionFunc {
+	return func(_ *Service) error {
+		if len(paths) == 0 {
+			return errors.New("config files are empty")
+		}
+		return config.SetFiles(paths...)
+	}
+}
@@ -2,14 +2,24 @@ package patron
 
 import (
 	"bytes"
+	"context"
 	"errors"
 	"log/slog"
+	"os"
+	"path/filepath"
 	"testing"
+	"time"
 
+	"github.com/beatlabs/patron/config"
 	"github.com/beatlabs/patron/observability"
 	"github.com/beatlabs/patron/observability/log"
+	"github.com/beatlabs/patron/observability/profiling"
+	"github.com/beatlabs/patron/observability/redact"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	noopmetric "go.opentelemetry.io/otel/metric/noop"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	nooptrace "go.opentelemetry.io/otel/trace/noop"
 )
 
 func TestLogFields(t *testing.T) {
@@ -106,3 +116,154 @@ func testSighupHandle(value *testSighupAlterable) func() {
 		value.value = 1
 	}
 }
+
+func TestWithShutdownDelay(t *testing.T) {
+	t.Parallel()
+
+	svc := &Service{}
+	require.EqualError(t, WithShutdownDelay(0)(svc), "negative or zero shutdown delay provided")
+
+	require.NoError(t, WithShutdownDelay(time.Second)(svc))
+	assert.Equal(t, time.Second, svc.shutdownDelay)
+}
+
+func TestWithShutdownTimeout(t *testing.T) {
+	t.Parallel()
+
+	svc := &Service{}
+	require.EqualError(t, WithShutdownTimeout(0)(svc), "negative or zero shutdown timeout provided")
+
+	require.NoError(t, WithShutdownTimeout(time.Second)(svc))
+	assert.Equal(t, time.Second, svc.shutdownTimeout)
+}
+
+func TestWithManagementServer(t *testing.T) {
+	t.Parallel()
+
+	svc := &Service{}
+	require.EqualError(t, WithManagementServer(0)(svc), "invalid management port provided")
+	require.EqualError(t, WithManagementServer(65536)(svc), "invalid management port provided")
+	assert.Nil(t, svc.management)
+
+	require.NoError(t, WithManagementServer(50001)(svc))
+	assert.Equal(t, &managementConfig{port: 50001}, svc.management)
+}
+
+func TestWithReloadHandler(t *testing.T) {
+	t.Parallel()
+
+	handler := func(_ context.Context) error { return nil }
+
+	svc := &Service{}
+	require.EqualError(t, WithReloadHandler("", handler)(svc), "reload handler name is empty")
+	require.EqualError(t, WithReloadHandler("limits", nil)(svc), "reload handler is nil")
+
+	require.NoError(t, WithReloadHandler("limits", handler)(svc))
+	require.Len(t, svc.reloadHandlers, 1)
+	assert.Equal(t, "limits", svc.reloadHandlers[0].name)
+}
+
+func TestWithConfigFiles(t *testing.T) {
+	require.EqualError(t, WithConfigFiles()(&Service{}), "config files are empty")
+
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(path, []byte("log:\n  level: debug\n"), 0o600))
+	t.Cleanup(func() { require.NoError(t, config.SetFiles()) })
+
+	svc, err := New("test", "", WithConfigFiles(path))
+	require.NoError(t, err)
+	assert.Equal(t, "debug", svc.observabilityCfg.LogConfig.Level)
+}
+
+func TestWithObservabilityOverrides(t *testing.T) {
+	t.Parallel()
+
+	svc := &Service{}
+	require.EqualError(t, WithLogOutput(nil)(svc), "log output is nil")
+	require.EqualError(t, WithMeterProvider(nil)(svc), "meter provider is nil")
+	require.EqualError(t, WithTracerProvider(nil)(svc), "tracer provider is nil")
+
+	out := &bytes.Buffer{}
+	mp := noopmetric.NewMeterProvider()
+	tp := nooptrace.NewTracerProvider()
+	require.NoError(t, WithLogOutput(out)(svc))
+	require.NoError(t, WithMeterProvider(mp)(svc))
+	require.NoError(t, WithTracerProvider(tp)(svc))
+	assert.Equal(t, out, svc.observabilityCfg.LogConfig.Output)
+	assert.Equal(t, mp, svc.observabilityCfg.MeterProvider)
+	assert.Equal(t, tp, svc.observabilityCfg.TracerProvider)
+}
+
+func TestWithLogSampling(t *testing.T) {
+	t.Parallel()
+
+	svc := &Service{}
+	require.EqualError(t, WithLogSampling(log.SamplingConfig{First: 1})(svc), "sampling window must be positive")
+
+	cfg := log.SamplingConfig{Window: time.Second, First: 10, Thereafter: 100}
+	require.NoError(t, WithLogSampling(cfg)(svc))
+	assert.Equal(t, &cfg, svc.observabilityCfg.LogConfig.Sampling)
+}
+
+func TestWithoutRuntimeMetrics(t *testing.T) {
+	t.Parallel()
+
+	svc := &Service{}
+	require.NoError(t, WithoutRuntimeMetrics()(svc))
+	assert.True(t, svc.observabilityCfg.DisableRuntimeMetrics)
+}
+
+func TestWithRedaction(t *testing.T) {
+	t.Parallel()
+
+	svc := &Service{}
+	require.EqualError(t, WithRedaction(redact.Config{MaxPayloadSize: -1})(svc), "max payload size must not be negative")
+
+	cfg := redact.DefaultConfig()
+	cfg.Fields = append(cfg.Fields, "email")
+	require.NoError(t, WithRedaction(cfg)(svc))
+	assert.Equal(t, redact.Mask, svc.observabilityCfg.Redactor.Value("email", "john@example.com"))
+}
+
+func TestWithExporters(t *testing.T) {
+	t.Parallel()
+
+	svc := &Service{}
+	require.EqualError(t, WithMetricExporter("statsd")(svc), `unsupported metric exporter "statsd"`)
+	require.EqualError(t, WithTraceExporter(observability.ExporterPrometheus)(svc), `unsupported trace exporter "prometheus"`)
+	require.EqualError(t, WithLogExporter(observability.ExporterPrometheus)(svc), `unsupported log exporter "prometheus"`)
+	require.EqualError(t, WithMetricInterval(0)(svc), "negative or zero metric interval provided")
+
+	require.NoError(t, WithMetricExporter(observability.ExporterPrometheus)(svc))
+	require.NoError(t, WithTraceExporter(observability.ExporterNone)(svc))
+	require.NoError(t, WithLogExporter(observability.ExporterOTLPHTTP)(svc))
+	require.NoError(t, WithMetricInterval(time.Minute)(svc))
+	assert.Equal(t, observability.ExporterPrometheus, svc.observabilityCfg.MetricExporter)
+	assert.Equal(t, observability.ExporterNone, svc.observabilityCfg.TraceExporter)
+	assert.Equal(t, observability.ExporterOTLPHTTP, svc.observabilityCfg.LogExporter)
+	assert.Equal(t, time.Minute, svc.observabilityCfg.MetricInterval)
+}
+
+func TestWithTraceSampler(t *testing.T) {
+	t.Parallel()
+
+	svc := &Service{}
+	require.EqualError(t, WithTraceSampler(nil)(svc), "trace sampler is nil")
+
+	sampler := sdktrace.NeverSample()
+	require.NoError(t, WithTraceSampler(sampler)(svc))
+	assert.Equal(t, sampler, svc.observabilityCfg.TraceSampler)
+}
+
+func TestWithProfiler(t *testing.T) {
+	t.Parallel()
+
+	svc := &Service{name: "name", version: "1.0.0"}
+	require.EqualError(t, WithProfiler(profiling.Config{})(svc), "profile sink is nil\nprofiling interval or thresholds must be provided")
+	assert.Nil(t, svc.profiler)
+
+	sink, err := profiling.NewDirSink(t.TempDir(), profiling.Retention{})
+	require.NoError(t, err)
+	require.NoError(t, WithProfiler(profiling.Config{Interval: time.Minute, Sink: sink})(svc))
+	assert.NotNil(t, svc.profiler)
+}
@@ -0,0 +1,267 @@
+package patrontest
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/metric/embedded"
+	"go.opentelemetry.io/otel/metric/noop"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+)
+
+var (
+	noopMeterProvider metric.MeterProvider = noop.NewMeterProvider()
+
+	meters = &meterProvider{}
+)
+
+// installMeterProvider sets the process-wide meter provider forwarding to the one of the running Service.
+// The metrics of the patron packages are created once, on the first global meter provider, so the forwarding
+// meter provider is installed as the global one instead of the meter provider of each Service.
+func installMeterProvider() *meterProvider {
+	otel.SetMeterProvider(meters)
+	return meters
+}
+
+// meterProvider forwards the measurements of the synchronous instruments to the meter provider of the running
+// Service, or drops them while no Service runs. The asynchronous instruments are created with the meter provider
+// running when they are created.
+type meterProvider struct {
+	embedded.MeterProvider
+	current atomic.Pointer[sdkmetric.MeterProvider]
+}
+
+func (p *meterProvider) Meter(name string, opts ...metric.MeterOption) metric.Meter {
+	return &meter{provider: p, name: name, opts: opts}
+}
+
+func (p *meterProvider) get() metric.MeterProvider {
+	if mp := p.current.Load(); mp != nil {
+		return mp
+	}
+	return noopMeterProvider
+}
+
+type meter struct {
+	embedded.Meter
+	provider *meterProvider
+	name     string
+	opts     []metric.MeterOption
+}
+
+func (m *meter) current() metric.Meter {
+	return m.provider.get().Meter(m.name, m.opts...)
+}
+
+func (m *meter) Int64Counter(name string, options ...metric.Int64CounterOption) (metric.Int64Counter, error) {
+	return &int64Counter{inst: forwardTo(m, func(mm metric.Meter) (metric.Int64Counter, error) {
+		return mm.Int64Counter(name, options...)
+	})}, nil
+}
+
+func (m *meter) Int64UpDownCounter(name string, options ...metric.Int64UpDownCounterOption) (metric.Int64UpDownCounter, error) {
+	return &int64UpDownCounter{inst: forwardTo(m, func(mm metric.Meter) (metric.Int64UpDownCounter, error) {
+		return mm.Int64UpDownCounter(name, options...)
+	})}, nil
+}
+
+func (m *meter) Int64Histogram(name string, options ...metric.Int64HistogramOption) (metric.Int64Histogram, error) {
+	return &int64Histogram{inst: forwardTo(m, func(mm metric.Meter) (metric.Int64Histogram, error) {
+		return mm.Int64Histogram(name, options...)
+	})}, nil
+}
+
+func (m *meter) Int64Gauge(name string, options ...metric.Int64GaugeOption) (metric.Int64Gauge, error) {
+	return &int64Gauge{inst: forwardTo(m, func(mm metric.Meter) (metric.Int64Gauge, error) {
+		return mm.Int64Gauge(name, options...)
+	})}, nil
+}
+
+func (m *meter) Float64Counter(name string, options ...metric.Float64CounterOption) (metric.Float64Counter, error) {
+	return &float64Counter{inst: forwardTo(m, func(mm metric.Meter) (metric.Float64Counter, error) {
+		return mm.Float64Counter(name, options...)
+	})}, nil
+}
+
+func (m *meter) Float64UpDownCounter(name string, options ...metric.Float64UpDownCounterOption) (metric.Float64UpDownCounter, error) {
+	return &float64UpDownCounter{inst: forwardTo(m, func(mm metric.Meter) (metric.Float64UpDownCounter, error) {
+		return mm.Float64UpDownCounter(name, options...)
+	})}, nil
+}
+
+func (m *meter) Float64Histogram(name string, options ...metric.Float64HistogramOption) (metric.Float64Histogram, error) {
+	return &float64Histogram{inst: forwardTo(m, func(mm metric.Meter) (metric.Float64Histogram, error) {
+		return mm.Float64Histogram(name, options...)
+	})}, nil
+}
+
+func (m *meter) Float64Gauge(name string, options ...metric.Float64GaugeOption) (metric.Float64Gauge, error) {
+	return &float64Gauge{inst: forwardTo(m, func(mm metric.Meter) (metric.Float64Gauge, error) {
+		return mm.Float64Gauge(name, options...)
+	})}, nil
+}
+
+func (m *meter) Int64ObservableCounter(name string, options ...metric.Int64ObservableCounterOption) (metric.Int64ObservableCounter, error) {
+	return m.current().Int64ObservableCounter(name, options...)
+}
+
+func (m *meter) Int64ObservableUpDownCounter(name string, options ...metric.Int64ObservableUpDownCounterOption) (metric.Int64ObservableUpDownCounter, error) {
+	return m.current().Int64ObservableUpDownCounter(name, options...)
+}
+
+func (m *meter) Int64ObservableGauge(name string, options ...metric.Int64ObservableGaugeOption) (metric.Int64ObservableGauge, error) {
+	return m.current().Int64ObservableGauge(name, options...)
+}
+
+func (m *meter) Float64ObservableCounter(name string, options ...metric.Float64ObservableCounterOption) (metric.Float64ObservableCounter, error) {
+	return m.current().Float64ObservableCounter(name, options...)
+}
+
+func (m *meter) Float64ObservableUpDownCounter(name string, options ...metric.Float64ObservableUpDownCounterOption) (metric.Float64ObservableUpDownCounter, error) {
+	return m.current().Float64ObservableUpDownCounter(name, options...)
+}
+
+func (m *meter) Float64ObservableGauge(name string, options ...metric.Float64ObservableGaugeOption) (metric.Float64ObservableGauge, error) {
+	return m.current().Float64ObservableGauge(name, options...)
+}
+
+func (m *meter) RegisterCallback(f metric.Callback, instruments ...metric.Observable) (metric.Registration, error) {
+	return m.current().RegisterCallback(f, instruments...)
+}
+
+// forward resolves the instrument of the meter provider of the running Service, created on first use.
+type forward[T any] struct {
+	meter  *meter
+	create func(metric.Meter) (T, error)
+	mu     sync.Mutex
+	mp     metric.MeterProvider
+	inst   T
+}
+
+func forwardTo[T any](m *meter, create func(metric.Meter) (T, error)) *forward[T] {
+	return &forward[T]{meter: m, create: create}
+}
+
+func (f *forward[T]) get() T {
+	mp := f.meter.provider.get()
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.mp != mp {
+		inst, err := f.create(mp.Meter(f.meter.name, f.meter.opts...))
+		if err != nil {
+			inst, _ = f.create(noopMeterProvider.Meter(f.meter.name))
+		}
+		f.mp = mp
+		f.inst = inst
+	}
+	return f.inst
+}
+
+type int64Counter struct {
+	embedded.Int64Counter
+	inst *forward[metric.Int64Counter]
+}
+
+func (c *int64Counter) Add(ctx context.Context, incr int64, options ...metric.AddOption) {
+	c.inst.get().Add(ctx, incr, options...)
+}
+
+func (c *int64Counter) Enabled(ctx context.Context) bool {
+	return c.inst.get().Enabled(ctx)
+}
+
+type int64UpDownCounter struct {
+	embedded.Int64UpDownCounter
+	inst *forward[metric.Int64UpDownCounter]
+}
+
+func (c *int64UpDownCounter) Add(ctx context.Context, incr int64, options ...metric.AddOption) {
+	c.inst.get().Add(ctx, incr, options...)
+}
+
+func (c *int64UpDownCounter) Enabled(ctx context.Context) bool {
+	return c.inst.get().Enabled(ctx)
+}
+
+type int64Histogram struct {
+	embedded.Int64Histogram
+	inst *forward[metric.Int64Histogram]
+}
+
+func (h *int64Histogram) Record(ctx context.Context, value int64, options ...metric.RecordOption) {
+	h.inst.get().Record(ctx, value, options...)
+}
+
+func (h *int64Histogram) Enabled(ctx context.Context) bool {
+	return h.inst.get().Enabled(ctx)
+}
+
+type int64Gauge struct {
+	embedded.Int64Gauge
+	inst *forward[metric.Int64Gauge]
+}
+
+func (g *int64Gauge) Record(ctx context.Context, value int64, options ...metric.RecordOption) {
+	g.inst.get().Record(ctx, value, options...)
+}
+
+func (g *int64Gauge) Enabled(ctx context.Context) bool {
+	return g.inst.get().Enabled(ctx)
+}
+
+type float64Counter struct {
+	embedded.Float64Counter
+	inst *forward[metric.Float64Counter]
+}
+
+func (c *float64Counter) Add(ctx context.Context, incr float64, options ...metric.AddOption) {
+	c.inst.get().Add(ctx, incr, options...)
+}
+
+func (c *float64Counter) Enabled(ctx context.Context) bool {
+	return c.inst.get().Enabled(ctx)
+}
+
+type float64UpDownCounter struct {
+	embedded.Float64UpDownCounter
+	inst *forward[metric.Float64UpDownCounter]
+}
+
+func (c *float64UpDownCounter) Add(ctx context.Context, incr float64, options ...metric.AddOption) {
+	c.inst.get().Add(ctx, incr, options...)
+}
+
+func (c *float64UpDownCounter) Enabled(ctx context.Context) bool {
+	return c.inst.get().Enabled(ctx)
+}
+
+type float64Histogram struct {
+	embedded.Float64Histogram
+	inst *forward[metric.Float64Histogram]
+}
+
+func (h *float64Histogram) Record(ctx context.Context, value float64, options ...metric.RecordOption) {
+	h.inst.get().Record(ctx, value, options...)
+}
+
+func (h *float64Histogram) Enabled(ctx context.Context) bool {
+	return h.inst.get().Enabled(ctx)
+}
+
+type float64Gauge struct {
+	embedded.Float64Gauge
+	inst *forward[metric.Float64Gauge]
+}
+
+func (g *float64Gauge) Record(ctx context.Context, value float64, options ...metric.RecordOption) {
+	g.inst.get().Record(ctx, value, options...)
+}
+
+func (g *float64Gauge) Enabled(ctx context.Context) bool {
+	return g.inst.get().Enabled(ctx)
+}
@@ -0,0 +1,233 @@
+// Package patrontest runs a patron Service in-process for end to end tests.
+//
+// The Service is created with in-memory log, span and metric capture instead of the OTLP exporters and is
+// stopped by canceling its context, without OS signals. Each Service has its own meter provider, but logging,
+// tracing and the global meter provider forwarding to it are process-wide, so tests using this package should
+// not run in parallel.
+package patrontest
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/beatlabs/patron"
+	"github.com/beatlabs/patron/observability"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+const (
+	readyTimeout  = 10 * time.Second
+	readyInterval = 10 * time.Millisecond
+)
+
+// Service is a patron Service running in-process.
+type Service struct {
+	t      testing.TB
+	svc    *patron.Service
+	spans  *tracetest.InMemoryExporter
+	tp     *sdktrace.TracerProvider
+	mp     *sdkmetric.MeterProvider
+	reader *sdkmetric.ManualReader
+	logs   *logBuffer
+
+	cancel   context.CancelFunc
+	done     chan error
+	stopOnce sync.Once
+	err      error
+	// ended holds the spans once stopped, as shutting down the exporter drops them.
+	ended tracetest.SpanStubs
+}
+
+// Start creates a Service with the given options, runs the components and waits until they are all ready.
+// The test fails if the Service cannot be created, or if it stops or is not ready within 10s.
+// The Service is stopped when the test finishes, if Stop was not called before.
+func Start(t testing.TB, name string, components []patron.Component, oo ...patron.OptionFunc) *Service {
+	t.Helper()
+
+	// delta temporality makes every collection return the measurements since the previous one.
+	reader := sdkmetric.NewManualReader(sdkmetric.WithTemporalitySelector(
+		func(sdkmetric.InstrumentKind) metricdata.Temporality { return metricdata.DeltaTemporality }))
+	mp := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+	meters := installMeterProvider()
+	spans := tracetest.NewInMemoryExporter()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSyncer(spans))
+	logs := &logBuffer{}
+
+	// the capture options come last, so that they are not overridden.
+	oo = append(oo, patron.WithJSONLogger(), patron.WithLogOutput(logs),
+		patron.WithLogExporter(observability.ExporterNone), patron.WithMeterProvider(meters), patron.WithTracerProvider(tp))
+	svc, err := patron.New(name, "", oo...)
+	if err != nil {
+		t.Fatalf("failed to create the service: %v", err)
+	}
+
+	// measurements recorded before the start are not captured.
+	meters.current.Store(mp)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	s := &Service{
+		t:      t,
+		svc:    svc,
+		spans:  spans,
+		tp:     tp,
+		mp:     mp,
+		reader: reader,
+		logs:   logs,
+		cancel: cancel,
+		done:   make(chan error, 1),
+	}
+	go func() {
+		s.done <- svc.Run(ctx, components...)
+	}()
+
+	t.Cleanup(func() {
+		if first, err := s.stop(); first && err != nil {
+			t.Errorf("failed to stop the service: %v", err)
+		}
+	})
+
+	if err := s.waitReady(len(components)); err != nil {
+		t.Fatalf("failed to start the service: %v", err)
+	}
+	return s
+}
+
+func (s *Service) waitReady(components int) error {
+	timeout := time.NewTimer(readyTimeout)
+	defer timeout.Stop()
+	ticker := time.NewTicker(readyInterval)
+	defer ticker.Stop()
+
+	for {
+		// all the components are registered before any of them is started.
+		if len(s.svc.Readiness().Status()) >= components && s.svc.Readiness().Ready() {
+			return nil
+		}
+
+		select {
+		case err := <-s.done:
+			s.done <- err
+			return errors.Join(errors.New("service stopped before being ready"), err)
+		case <-timeout.C:
+			return errors.New("service not ready in time")
+		case <-ticker.C:
+		}
+	}
+}
+
+// Service returns the running patron Service.
+func (s *Service) Service() *patron.Service {
+	return s.svc
+}
+
+// Stop cancels the context of the Service and returns the error of its Run, once all the components are stopped.
+// Subsequent calls return the same error.
+func (s *Service) Stop() error {
+	_, err := s.stop()
+	return err
+}
+
+func (s *Service) stop() (bool, error) {
+	first := false
+	s.stopOnce.Do(func() {
+		first = true
+		s.cancel()
+		err := <-s.done
+		s.ended = s.spans.GetSpans()
+		// measurements recorded after the stop are not captured, the meter provider is kept for Metrics.
+		meters.current.CompareAndSwap(s.mp, nil)
+		s.err = errors.Join(err, s.tp.Shutdown(context.Background()))
+	})
+	return first, s.err
+}
+
+// Spans returns the ended spans.
+func (s *Service) Spans() tracetest.SpanStubs {
+	if s.ended != nil {
+		return s.ended
+	}
+	return s.spans.GetSpans()
+}
+
+// Metrics returns the measurements recorded between Start and Stop, since the previous call.
+func (s *Service) Metrics() []metricdata.Metrics {
+	s.t.Helper()
+
+	rm := metricdata.ResourceMetrics{}
+	if err := s.reader.Collect(context.Background(), &rm); err != nil {
+		s.t.Fatalf("failed to collect the metrics: %v", err)
+	}
+
+	var mm []metricdata.Metrics
+	for _, sm := range rm.ScopeMetrics {
+		mm = append(mm, sm.Metrics...)
+	}
+	return mm
+}
+
+// LogRecord is a captured log record.
+type LogRecord struct {
+	Time    time.Time
+	Level   slog.Level
+	Message string
+	// Attrs holds the attributes of the record, including the source and the attributes of the Service.
+	Attrs map[string]any
+}
+
+// Logs returns the records logged since Start.
+func (s *Service) Logs() []LogRecord {
+	s.t.Helper()
+
+	var records []LogRecord
+	dec := json.NewDecoder(bytes.NewReader(s.logs.Bytes()))
+	for dec.More() {
+		attrs := map[string]any{}
+		if err := dec.Decode(&attrs); err != nil {
+			s.t.Fatalf("failed to decode the logs: %v", err)
+		}
+
+		rec := LogRecord{Attrs: attrs}
+		if v, ok := attrs[slog.TimeKey].(string); ok {
+			rec.Time, _ = time.Parse(time.RFC3339Nano, v)
+		}
+		if v, ok := attrs[slog.LevelKey].(string); ok {
+			if err := rec.Level.UnmarshalText([]byte(v)); err != nil {
+				s.t.Fatalf("failed to decode the log level: %v", err)
+			}
+		}
+		rec.Message, _ = attrs[slog.MessageKey].(string)
+		delete(attrs, slog.TimeKey)
+		delete(attrs, slog.LevelKey)
+		delete(attrs, slog.MessageKey)
+
+		records = append(records, rec)
+	}
+	return records
+}
+
+// logBuffer is written to by the default logger from any goroutine.
+type logBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *logBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *logBuffer) Bytes() []byte {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return bytes.Clone(b.buf.Bytes())
+}
@@ -0,0 +1,81 @@
+package patrontest
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"testing"
+
+	"github.com/beatlabs/patron"
+	patronmetric "github.com/beatlabs/patron/observability/metric"
+	patrontrace "github.com/beatlabs/patron/observability/trace"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	nooptrace "go.opentelemetry.io/otel/trace/noop"
+)
+
+type component struct {
+	err error
+}
+
+func (c component) Run(ctx context.Context) error {
+	if c.err != nil {
+		return c.err
+	}
+	<-ctx.Done()
+	return nil
+}
+
+func TestStart(t *testing.T) {
+	counter := patronmetric.Int64Counter("patrontest", "patrontest.calls", "Calls.", "1")
+
+	// services started one after the other in the same process capture their own telemetry.
+	for _, name := range []string{"first", "second"} {
+		t.Run(name, func(t *testing.T) {
+			svc := Start(t, name, []patron.Component{component{}, component{}})
+			assert.True(t, svc.Service().Readiness().Ready())
+
+			ctx, sp := patrontrace.StartSpan(context.Background(), "work")
+			counter.Add(ctx, 1)
+			slog.InfoContext(ctx, "work done", slog.String("worker", name))
+			sp.End()
+
+			require.NoError(t, svc.Stop())
+			require.NoError(t, svc.Stop())
+
+			spans := svc.Spans()
+			require.Len(t, spans, 1)
+			assert.Equal(t, "work", spans[0].Name)
+
+			mm := svc.Metrics()
+			require.Len(t, mm, 1)
+			assert.Equal(t, "patrontest.calls", mm[0].Name)
+
+			var found bool
+			for _, rec := range svc.Logs() {
+				if rec.Message == "work done" {
+					found = true
+					assert.Equal(t, slog.LevelInfo, rec.Level)
+					assert.Equal(t, name, rec.Attrs["worker"])
+					assert.Equal(t, name, rec.Attrs["srv"])
+					assert.False(t, rec.Time.IsZero())
+				}
+			}
+			assert.True(t, found)
+		})
+	}
+}
+
+func TestWaitReady(t *testing.T) {
+	svc, err := patron.New("test", "", patron.WithMeterProvider(installMeterProvider()), patron.WithTracerProvider(nooptrace.NewTracerProvider()))
+	require.NoError(t, err)
+	svc.Readiness().Register("cmp", nil)
+
+	s := &Service{svc: svc, done: make(chan error, 1)}
+	s.done <- errors.New("component failed")
+
+	err = s.waitReady(1)
+	require.EqualError(t, err, "service stopped before being ready\ncomponent failed")
+	// the error of the run is kept for Stop.
+	require.EqualError(t, <-s.done, "component failed")
+}
@@ -0,0 +1,187 @@
+package patron
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/beatlabs/patron/health"
+	"github.com/beatlabs/patron/observability/log"
+)
+
+// runner drives the lifecycle of a single managed component.
+type runner struct {
+	mc     *ManagedComponent
+	deps   []*runner
+	health *health.Registry
+	ctx    context.Context
+	cancel context.CancelFunc
+	ready  chan struct{}
+	done   chan struct{}
+	// running is true while Run of the component has not returned.
+	running atomic.Bool
+
+	readyOnce sync.Once
+}
+
+func newRunner(ctx context.Context, mc *ManagedComponent, registry *health.Registry) *runner {
+	// the run context is detached from the service context so that components can be stopped one by one.
+	runCtx, cancel := context.WithCancel(context.WithoutCancel(ctx))
+	return &runner{
+		mc:     mc,
+		health: registry,
+		ctx:    runCtx,
+		cancel: cancel,
+		ready:  make(chan struct{}),
+		done:   make(chan struct{}),
+	}
+}
+
+// start waits for the dependencies to become ready, then initializes, runs and supervises the component.
+// Exactly one result is sent to chErr, unless the start context is done before the component is started.
+func (r *runner) start(startCtx context.Context, chErr chan<- error) {
+	defer close(r.done)
+
+	for _, dep := range r.deps {
+		select {
+		case <-dep.ready:
+		case <-startCtx.Done():
+			return
+		}
+	}
+
+	chErr <- r.supervise(startCtx)
+}
+
+// supervise starts the component and restarts it according to its restart policy, until it returns without
+// an error, the service stops or the failure is escalated.
+// Every start goes through Init, Run and Ready, so a failure to initialize or become ready is restarted as well.
+func (r *runner) supervise(startCtx context.Context) error {
+	var tracker *restartTracker
+	if r.mc.restartPolicy != nil {
+		tracker = &restartTracker{policy: *r.mc.restartPolicy}
+	}
+
+	for {
+		err := r.attempt(startCtx)
+		if err == nil || tracker == nil || startCtx.Err() != nil || r.ctx.Err() != nil {
+			return err
+		}
+
+		backoff, ok := tracker.next(time.Now())
+		if !ok {
+			componentRestartInc(r.ctx, r.mc.name, true)
+			slog.Error("component restarts exhausted, escalating", slog.String("component", r.mc.name),
+				slog.Uint64("max_restarts", uint64(r.mc.restartPolicy.MaxRestarts)),
+				slog.Duration("window", r.mc.restartPolicy.Window), log.ErrorAttr(err))
+			return fmt.Errorf("component %s exceeded its restart policy: %w", r.mc.name, err)
+		}
+
+		componentRestartInc(r.ctx, r.mc.name, false)
+		slog.Warn("component failed, restarting", slog.String("component", r.mc.name),
+			slog.Int("restarts", tracker.total), slog.Duration("backoff", backoff), log.ErrorAttr(err))
+
+		select {
+		case <-time.After(backoff):
+		case <-startCtx.Done():
+			return nil
+		case <-r.ctx.Done():
+			return nil
+		}
+	}
+}
+
+// attempt initializes and runs the component, waits for it to become ready and then for Run to return.
+// The component is reported as started only while it is ready.
+func (r *runner) attempt(startCtx context.Context) error {
+	if initializer, ok := r.mc.component.(Initializer); ok {
+		slog.Debug("initializing component", slog.String("component", r.mc.name))
+		if err := initializer.Init(startCtx); err != nil {
+			return fmt.Errorf("failed to initialize component %s: %w", r.mc.name, err)
+		}
+	}
+
+	readyCtx, readyCancel := context.WithCancel(startCtx)
+	defer readyCancel()
+	runCtx, runCancel := context.WithCancel(r.ctx)
+	defer runCancel()
+
+	chRun := make(chan error, 1)
+	r.running.Store(true)
+	go func() {
+		err := r.mc.component.Run(runCtx)
+		r.running.Store(false)
+		// the result is sent before the ready context is canceled, so that a failure of Run during startup
+		// is reported instead of the cancellation of Ready.
+		chRun <- err
+		readyCancel()
+	}()
+
+	if readier, ok := r.mc.component.(Readier); ok {
+		if err := readier.Ready(readyCtx); err != nil {
+			select {
+			case runErr := <-chRun:
+				return runErr
+			default:
+				// Run has to return before the component is started again.
+				runCancel()
+				<-chRun
+				return fmt.Errorf("component %s failed to become ready: %w", r.mc.name, err)
+			}
+		}
+	}
+
+	slog.Debug("component ready", slog.String("component", r.mc.name))
+	r.health.SetStarted(r.mc.name, true)
+	r.readyOnce.Do(func() { close(r.ready) })
+
+	err := <-chRun
+	r.health.SetStarted(r.mc.name, false)
+	return err
+}
+
+// reload calls the reload hook of a running component.
+func (r *runner) reload(ctx context.Context) error {
+	reloader, ok := r.mc.component.(Reloader)
+	if !ok || !r.running.Load() {
+		return nil
+	}
+
+	if err := reloader.Reload(ctx); err != nil {
+		return fmt.Errorf("failed to reload component %s: %w", r.mc.name, err)
+	}
+	return nil
+}
+
+// stop calls the shutdown hook of a running component, cancels its context and waits for it to return.
+// A component waiting to be restarted is not running, so its shutdown hook is not called.
+// The shutdown hook is waited for until the context is done, so that a stuck hook does not block the service.
+func (r *runner) stop(ctx context.Context) {
+	if shutdowner, ok := r.mc.component.(Shutdowner); ok && r.running.Load() {
+		r.shutdown(ctx, shutdowner)
+	}
+
+	r.cancel()
+	<-r.done
+}
+
+func (r *runner) shutdown(ctx context.Context, shutdowner Shutdowner) {
+	slog.Debug("shutting down component", slog.String("component", r.mc.name))
+
+	chErr := make(chan error, 1)
+	go func() {
+		chErr <- shutdowner.Shutdown(ctx)
+	}()
+
+	select {
+	case err := <-chErr:
+		if err != nil {
+			slog.Error("failed to shut down component", slog.String("component", r.mc.name), log.ErrorAttr(err))
+		}
+	case <-ctx.Done():
+		slog.Error("component shutdown timed out, stopping", slog.String("component", r.mc.name), log.ErrorAttr(ctx.Err()))
+	}
+}
@@ -3,23 +3,36 @@ package patron
 import (
 	"context"
 	"errors"
+	"fmt"
 	"log/slog"
 	"os"
 	"os/signal"
-	"sync"
 	"syscall"
 	"time"
 
+	"github.com/beatlabs/patron/config"
+	"github.com/beatlabs/patron/health"
 	"github.com/beatlabs/patron/observability"
 	"github.com/beatlabs/patron/observability/log"
+	"github.com/beatlabs/patron/observability/profiling"
 )
 
 const (
 	srv  = "srv"
 	ver  = "ver"
 	host = "host"
+
+	reloadTimeout          = 30 * time.Second
+	defaultShutdownTimeout = 30 * time.Second
+
+	profilerComponentName = "patron-profiler"
 )
 
+type reloadHandler struct {
+	name string
+	fn   func(ctx context.Context) error
+}
+
 // Component represents a long-running unit started by the Service.
 // Implementations should return when the context is canceled.
 type Component interface {
@@ -27,14 +40,21 @@ type Component interface {
 }
 
 // Service manages application lifecycle and observability setup.
-// It starts a default HTTP component for management endpoints.
+// It optionally starts an HTTP component for management endpoints, see WithManagementServer,
+// and a profiler, see WithProfiler.
 type Service struct {
 	name                  string
 	version               string
 	termSig               chan os.Signal
 	sighupHandler         func()
+	reloadHandlers        []reloadHandler
 	observabilityCfg      observability.Config
 	observabilityProvider *observability.Provider
+	readiness             *health.Registry
+	shutdownDelay         time.Duration
+	shutdownTimeout       time.Duration
+	management            *managementConfig
+	profiler              *profiling.Profiler
 }
 
 // New creates a new Service instance with sane defaults and optional configuration.
@@ -57,6 +77,7 @@ func New(name, version string, options ...OptionFunc) (*Service, error) {
 			slog.Debug("sighup received: nothing setup")
 		},
 		observabilityCfg: observabilityConfig(name, version),
+		readiness:        health.NewRegistry(),
 	}
 
 	optionErrors := make([]error, 0)
@@ -72,18 +93,33 @@ func New(name, version string, options ...OptionFunc) (*Service, error) {
 		return nil, errors.Join(optionErrors...)
 	}
 
+	logCfg := logSettings{}
+	err = config.Load("log", &logCfg)
+	if err != nil {
+		return nil, err
+	}
+	s.observabilityCfg.LogConfig.Level = logCfg.Level
+
 	observabilityProvider, err := observability.Setup(ctx, s.observabilityCfg)
 	if err != nil {
 		return nil, err
 	}
 	s.observabilityProvider = observabilityProvider
 
+	health.SetDefault(s.readiness)
+
 	s.setupOSSignal()
 
 	return s, nil
 }
 
+// Readiness returns the registry aggregating the readiness of the components run by the Service.
+func (s *Service) Readiness() *health.Registry {
+	return s.readiness
+}
+
 // Run starts the provided components and blocks until termination or a component error.
+// Components are started after their dependencies are ready and stopped in reverse order.
 func (s *Service) Run(ctx context.Context, components ...Component) error {
 	if len(components) == 0 {
 		return errors.New("components are empty or nil")
@@ -95,32 +131,87 @@ func (s *Service) Run(ctx context.Context, components ...Component) error {
 		}
 	}
 
+	if s.profiler != nil {
+		pc, err := NewManagedComponent(profilerComponentName, s.profiler)
+		if err != nil {
+			return err
+		}
+		components = append([]Component{pc}, components...)
+	}
+
+	if s.management != nil {
+		mc, err := s.managementComponent()
+		if err != nil {
+			return err
+		}
+		// the management component comes first, so it is stopped last.
+		components = append([]Component{mc}, components...)
+	}
+
+	mcs, err := managedComponents(components)
+	if err != nil {
+		return err
+	}
+
+	ordered, err := startupOrder(mcs)
+	if err != nil {
+		return err
+	}
+
 	defer func() {
 		ctx, cnl := context.WithTimeout(context.Background(), 5*time.Second)
 		defer cnl()
 
+		// the records held back by the logger are logged before the logs are flushed.
+		if err := log.Close(); err != nil {
+			slog.Error("failed to close logger", log.ErrorAttr(err))
+		}
+
 		err := s.observabilityProvider.Shutdown(ctx)
 		if err != nil {
 			slog.Error("failed to close observability provider", log.ErrorAttr(err))
 		}
 	}()
-	ctx, cnl := context.WithCancel(ctx)
-	chErr := make(chan error, len(components))
-	wg := sync.WaitGroup{}
-	wg.Add(len(components))
-	for _, cp := range components {
-		go func(c Component) {
-			defer wg.Done()
-			chErr <- c.Run(ctx)
-		}(cp)
+
+	runners := make(map[string]*runner, len(ordered))
+	orderedRunners := make([]*runner, 0, len(ordered))
+	for _, mc := range ordered {
+		checker, _ := mc.component.(health.Checker)
+		s.readiness.Register(mc.name, checker)
+		r := newRunner(ctx, mc, s.readiness)
+		for _, dep := range mc.deps {
+			r.deps = append(r.deps, runners[dep])
+		}
+		runners[mc.name] = r
+		orderedRunners = append(orderedRunners, r)
+	}
+
+	startCtx, startCnl := context.WithCancel(ctx)
+	chErr := make(chan error, len(ordered))
+	for _, r := range orderedRunners {
+		go r.start(startCtx, chErr)
 	}
 
 	log.FromContext(ctx).Info("service started", slog.String("name", s.name))
-	ee := make([]error, 0, len(components))
-	ee = append(ee, s.waitTermination(chErr))
-	cnl()
+	ee := make([]error, 0, len(ordered))
+	ee = append(ee, s.waitTermination(ctx, chErr, orderedRunners))
+	s.readiness.Shutdown()
+	startCnl()
+
+	if s.shutdownDelay > 0 {
+		slog.Info("service not ready, waiting before stopping components", slog.Duration("delay", s.shutdownDelay))
+		time.Sleep(s.shutdownDelay)
+	}
 
-	wg.Wait()
+	shutdownTimeout := s.shutdownTimeout
+	if shutdownTimeout == 0 {
+		shutdownTimeout = defaultShutdownTimeout
+	}
+	for i := len(orderedRunners) - 1; i >= 0; i-- {
+		stopCtx, stopCnl := context.WithTimeout(context.WithoutCancel(ctx), shutdownTimeout)
+		orderedRunners[i].stop(stopCtx)
+		stopCnl()
+	}
 	close(chErr)
 
 	for err := range chErr {
@@ -133,19 +224,20 @@ func (s *Service) setupOSSignal() {
 	signal.Notify(s.termSig, os.Interrupt, syscall.SIGTERM, syscall.SIGHUP)
 }
 
-func (s *Service) waitTermination(chErr <-chan error) error {
+func (s *Service) waitTermination(ctx context.Context, chErr <-chan error, runners []*runner) error {
 	for {
 		select {
+		case <-ctx.Done():
+			slog.Info("context done, stopping service")
+			return nil
 		case sig := <-s.termSig:
 			slog.Info("signal received", slog.Any("type", sig))
 
-			switch sig {
-			case syscall.SIGHUP:
-				s.sighupHandler()
-				return nil
-			default:
-				return nil
+			if sig == syscall.SIGHUP {
+				s.reload(ctx, runners)
+				continue
 			}
+			return nil
 		case err := <-chErr:
 			if err != nil {
 				slog.Info("component error received")
@@ -155,13 +247,72 @@ func (s *Service) waitTermination(chErr <-chan error) error {
 	}
 }
 
-func observabilityConfig(name, version string) observability.Config {
-	var lvl string
-	lvl, ok := os.LookupEnv("PATRON_LOG_LEVEL")
-	if !ok {
-		lvl = "info"
+// reload reads the configuration files again, applies the log level and notifies the reload handlers
+// and the running components, in startup order. The service keeps running whatever the outcome.
+func (s *Service) reload(ctx context.Context, runners []*runner) {
+	ctx, cnl := context.WithTimeout(ctx, reloadTimeout)
+	defer cnl()
+
+	err := s.reloadAll(ctx, runners)
+	reloadInc(ctx, err != nil)
+	if err != nil {
+		slog.Error("failed to reload service", log.ErrorAttr(err))
+		return
+	}
+	slog.Info("service reloaded")
+}
+
+func (s *Service) reloadAll(ctx context.Context, runners []*runner) error {
+	if err := config.Reload(); err != nil {
+		return fmt.Errorf("failed to reload config files: %w", err)
 	}
 
+	var errs []error
+
+	if err := s.reloadLogLevel(); err != nil {
+		errs = append(errs, err)
+	}
+
+	s.sighupHandler()
+
+	for _, handler := range s.reloadHandlers {
+		if err := handler.fn(ctx); err != nil {
+			errs = append(errs, fmt.Errorf("failed to reload %s: %w", handler.name, err))
+		}
+	}
+
+	for _, r := range runners {
+		errs = append(errs, r.reload(ctx))
+	}
+
+	return errors.Join(errs...)
+}
+
+// reloadLogLevel applies the log level of the configuration only if it changed, so that the level set at runtime,
+// e.g. with the /debug/log routes, is kept otherwise. The levels of the named loggers are kept in any case.
+func (s *Service) reloadLogLevel() error {
+	logCfg := logSettings{}
+	if err := config.Load("log", &logCfg); err != nil {
+		return err
+	}
+	if logCfg.Level == s.observabilityCfg.LogConfig.Level {
+		return nil
+	}
+	if err := log.SetLevel(logCfg.Level); err != nil {
+		return fmt.Errorf("failed to set log level %s: %w", logCfg.Level, err)
+	}
+	slog.Info("log level reloaded", slog.String("from", s.observabilityCfg.LogConfig.Level),
+		slog.String("to", logCfg.Level))
+	s.observabilityCfg.LogConfig.Level = logCfg.Level
+	return nil
+}
+
+// logSettings of the service loaded from the "log" configuration section and the environment.
+type logSettings struct {
+	Level string `yaml:"level" env:"PATRON_LOG_LEVEL" default:"info"`
+}
+
+func observabilityConfig(name, version string) observability.Config {
 	hostname, err := os.Hostname()
 	if err != nil {
 		hostname = host
@@ -179,7 +330,6 @@ func observabilityConfig(name, version string) observability.Config {
 		LogConfig: log.Config{
 			Attributes: attrs,
 			IsJSON:     false,
-			Level:      lvl,
 		},
 	}
 }
@@ -4,12 +4,24 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"log/slog"
+	"net"
+	"net/http"
 	"os"
+	"path/filepath"
+	"slices"
+	"sync"
 	"sync/atomic"
+	"syscall"
 	"testing"
+	"time"
 
+	"github.com/beatlabs/patron/config"
+	"github.com/beatlabs/patron/health"
 	"github.com/beatlabs/patron/observability"
+	"github.com/beatlabs/patron/observability/log"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/goleak"
@@ -31,6 +43,8 @@ func TestMain(m *testing.M) {
 		goleak.IgnoreTopFunction("google.golang.org/grpc/internal/grpcsync.(*CallbackSerializer).run"),
 		goleak.IgnoreTopFunction("go.opentelemetry.io/otel/sdk/metric.(*PeriodicReader).run"),
 		goleak.IgnoreTopFunction("go.opentelemetry.io/otel/sdk/trace.(*batchSpanProcessor).processQueue"),
+		goleak.IgnoreTopFunction("go.opentelemetry.io/otel/sdk/log.(*BatchProcessor).poll.func1"),
+		goleak.IgnoreTopFunction("go.opentelemetry.io/otel/sdk/log.exportSync.func1"),
 	)
 }
 
@@ -92,6 +106,7 @@ func TestRunRejectsNilComponentBeforeStartingAny(t *testing.T) {
 		name:                  "test-service",
 		termSig:               make(chan os.Signal, 1),
 		observabilityProvider: &observability.Provider{},
+		readiness:             health.NewRegistry(),
 	}
 	component := &testRunComponent{}
 
@@ -108,6 +123,7 @@ func TestRunRejectsEmptyComponents(t *testing.T) {
 		name:                  "test-service",
 		termSig:               make(chan os.Signal, 1),
 		observabilityProvider: &observability.Provider{},
+		readiness:             health.NewRegistry(),
 	}
 
 	err := service.Run(context.Background())
@@ -115,6 +131,618 @@ func TestRunRejectsEmptyComponents(t *testing.T) {
 	require.EqualError(t, err, "components are empty or nil")
 }
 
+type lifecycleRecorder struct {
+	mu     sync.Mutex
+	events []string
+}
+
+func (r *lifecycleRecorder) record(event string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.events = append(r.events, event)
+}
+
+func (r *lifecycleRecorder) get() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return slices.Clone(r.events)
+}
+
+type testLifecycleComponent struct {
+	name     string
+	recorder *lifecycleRecorder
+	initErr  error
+}
+
+func (c *testLifecycleComponent) Init(_ context.Context) error {
+	c.recorder.record(c.name + ":init")
+	return c.initErr
+}
+
+func (c *testLifecycleComponent) Run(ctx context.Context) error {
+	c.recorder.record(c.name + ":run")
+	<-ctx.Done()
+	c.recorder.record(c.name + ":stopped")
+	return nil
+}
+
+func (c *testLifecycleComponent) Ready(_ context.Context) error {
+	c.recorder.record(c.name + ":ready")
+	return nil
+}
+
+func (c *testLifecycleComponent) Shutdown(_ context.Context) error {
+	c.recorder.record(c.name + ":shutdown")
+	return nil
+}
+
+func TestRunStartsAndStopsComponentsInDependencyOrder(t *testing.T) {
+	t.Parallel()
+
+	service := &Service{
+		name:                  "test-service",
+		termSig:               make(chan os.Signal, 1),
+		observabilityProvider: &observability.Provider{},
+		readiness:             health.NewRegistry(),
+	}
+	recorder := &lifecycleRecorder{}
+	kafka, err := NewManagedComponent("kafka", &testLifecycleComponent{name: "kafka", recorder: recorder})
+	require.NoError(t, err)
+	http, err := NewManagedComponent("http", &testLifecycleComponent{name: "http", recorder: recorder}, DependsOn("kafka"))
+	require.NoError(t, err)
+
+	ctx, cnl := context.WithCancel(context.Background())
+	chErr := make(chan error)
+	go func() {
+		chErr <- service.Run(ctx, http, kafka)
+	}()
+
+	assert.Eventually(t, func() bool { return slices.Contains(recorder.get(), "http:ready") }, time.Second, time.Millisecond)
+	cnl()
+	require.NoError(t, <-chErr)
+
+	expected := []string{
+		"kafka:init", "kafka:run", "kafka:ready", "http:init", "http:run", "http:ready",
+		"http:shutdown", "http:stopped", "kafka:shutdown", "kafka:stopped",
+	}
+	events := recorder.get()
+	assert.ElementsMatch(t, expected, events)
+	assert.Less(t, slices.Index(events, "kafka:ready"), slices.Index(events, "http:init"))
+	assert.Less(t, slices.Index(events, "http:stopped"), slices.Index(events, "kafka:shutdown"))
+}
+
+func TestRunReturnsInitError(t *testing.T) {
+	t.Parallel()
+
+	service := &Service{
+		name:                  "test-service",
+		termSig:               make(chan os.Signal, 1),
+		observabilityProvider: &observability.Provider{},
+		readiness:             health.NewRegistry(),
+	}
+	recorder := &lifecycleRecorder{}
+	kafka, err := NewManagedComponent("kafka", &testLifecycleComponent{name: "kafka", recorder: recorder, initErr: errors.New("init failed")})
+	require.NoError(t, err)
+	http, err := NewManagedComponent("http", &testLifecycleComponent{name: "http", recorder: recorder}, DependsOn("kafka"))
+	require.NoError(t, err)
+
+	err = service.Run(context.Background(), http, kafka)
+
+	require.EqualError(t, err, "failed to initialize component kafka: init failed")
+	assert.Equal(t, []string{"kafka:init"}, recorder.get())
+}
+
+type testFailingReadyComponent struct{}
+
+func (c *testFailingReadyComponent) Run(_ context.Context) error {
+	return errors.New("run failed")
+}
+
+func (c *testFailingReadyComponent) Ready(ctx context.Context) error {
+	<-ctx.Done()
+	return ctx.Err()
+}
+
+func TestRunReportsRunErrorBeforeReady(t *testing.T) {
+	t.Parallel()
+
+	for range 20 {
+		service := &Service{
+			name:                  "test-service",
+			termSig:               make(chan os.Signal, 1),
+			observabilityProvider: &observability.Provider{},
+			readiness:             health.NewRegistry(),
+		}
+		cmp, err := NewManagedComponent("kafka", &testFailingReadyComponent{})
+		require.NoError(t, err)
+
+		err = service.Run(context.Background(), cmp)
+
+		require.EqualError(t, err, "run failed")
+	}
+}
+
+type testStuckShutdownComponent struct{}
+
+func (c *testStuckShutdownComponent) Run(ctx context.Context) error {
+	<-ctx.Done()
+	return nil
+}
+
+func (c *testStuckShutdownComponent) Shutdown(ctx context.Context) error {
+	<-ctx.Done()
+	return ctx.Err()
+}
+
+func TestRunBoundsShutdownHooks(t *testing.T) {
+	t.Parallel()
+
+	service := &Service{
+		name:                  "test-service",
+		termSig:               make(chan os.Signal, 1),
+		observabilityProvider: &observability.Provider{},
+		readiness:             health.NewRegistry(),
+		shutdownTimeout:       10 * time.Millisecond,
+	}
+	cmp, err := NewManagedComponent("amqp", &testStuckShutdownComponent{})
+	require.NoError(t, err)
+
+	ctx, cnl := context.WithCancel(context.Background())
+	chErr := make(chan error)
+	go func() {
+		chErr <- service.Run(ctx, cmp)
+	}()
+	time.Sleep(10 * time.Millisecond)
+	cnl()
+
+	select {
+	case err := <-chErr:
+		require.NoError(t, err)
+	case <-time.After(5 * time.Second):
+		require.Fail(t, "the shutdown hook blocked the service")
+	}
+}
+
+type testFlakyComponent struct {
+	failures atomic.Int32
+	runs     atomic.Int32
+}
+
+func (c *testFlakyComponent) Run(ctx context.Context) error {
+	if c.runs.Add(1) <= c.failures.Load() {
+		return errors.New("transient failure")
+	}
+	<-ctx.Done()
+	return nil
+}
+
+func TestRunRestartsFailingComponent(t *testing.T) {
+	t.Parallel()
+
+	service := &Service{
+		name:                  "test-service",
+		termSig:               make(chan os.Signal, 1),
+		observabilityProvider: &observability.Provider{},
+		readiness:             health.NewRegistry(),
+	}
+	flaky := &testFlakyComponent{}
+	flaky.failures.Store(2)
+	policy := RestartPolicy{MaxRestarts: 3, Window: time.Minute, InitialBackoff: time.Millisecond, MaxBackoff: time.Millisecond}
+	cmp, err := NewManagedComponent("amqp", flaky, WithRestartPolicy(policy))
+	require.NoError(t, err)
+
+	ctx, cnl := context.WithCancel(context.Background())
+	chErr := make(chan error)
+	go func() {
+		chErr <- service.Run(ctx, cmp)
+	}()
+
+	assert.Eventually(t, func() bool { return flaky.runs.Load() == 3 }, time.Second, time.Millisecond)
+	cnl()
+	require.NoError(t, <-chErr)
+}
+
+type testBackoffComponent struct {
+	testFlakyComponent
+	shutdowns atomic.Int32
+}
+
+func (c *testBackoffComponent) Shutdown(context.Context) error {
+	c.shutdowns.Add(1)
+	return nil
+}
+
+func TestRunSkipsShutdownOfComponentsInBackoff(t *testing.T) {
+	t.Parallel()
+
+	service := &Service{
+		name:                  "test-service",
+		termSig:               make(chan os.Signal, 1),
+		observabilityProvider: &observability.Provider{},
+		readiness:             health.NewRegistry(),
+	}
+	flaky := &testBackoffComponent{}
+	flaky.failures.Store(1)
+	policy := RestartPolicy{MaxRestarts: 3, Window: time.Minute, InitialBackoff: time.Minute, MaxBackoff: time.Minute}
+	cmp, err := NewManagedComponent("amqp", flaky, WithRestartPolicy(policy))
+	require.NoError(t, err)
+
+	ctx, cnl := context.WithCancel(context.Background())
+	chErr := make(chan error)
+	go func() {
+		chErr <- service.Run(ctx, cmp)
+	}()
+
+	assert.Eventually(t, func() bool { return flaky.runs.Load() == 1 }, time.Second, time.Millisecond)
+	time.Sleep(10 * time.Millisecond)
+	cnl()
+
+	select {
+	case err := <-chErr:
+		require.NoError(t, err)
+	case <-time.After(5 * time.Second):
+		require.Fail(t, "the backoff blocked the service")
+	}
+	assert.Equal(t, int32(1), flaky.runs.Load())
+	assert.Equal(t, int32(0), flaky.shutdowns.Load())
+}
+
+func TestRunEscalatesWhenRestartsExhausted(t *testing.T) {
+	t.Parallel()
+
+	service := &Service{
+		name:                  "test-service",
+		termSig:               make(chan os.Signal, 1),
+		observabilityProvider: &observability.Provider{},
+		readiness:             health.NewRegistry(),
+	}
+	flaky := &testFlakyComponent{}
+	flaky.failures.Store(10)
+	policy := RestartPolicy{MaxRestarts: 2, Window: time.Minute, InitialBackoff: time.Millisecond, MaxBackoff: time.Millisecond}
+	cmp, err := NewManagedComponent("sqs", flaky, WithRestartPolicy(policy))
+	require.NoError(t, err)
+
+	err = service.Run(context.Background(), cmp)
+
+	require.EqualError(t, err, "component sqs exceeded its restart policy: transient failure")
+	assert.Equal(t, int32(3), flaky.runs.Load())
+}
+
+type testRestartingComponent struct {
+	recorder     *lifecycleRecorder
+	readiness    *health.Registry
+	inits        atomic.Int32
+	runs         atomic.Int32
+	initFailures int32
+	runFailures  int32
+}
+
+func (c *testRestartingComponent) Init(_ context.Context) error {
+	c.recorder.record("init")
+	if c.inits.Add(1) <= c.initFailures {
+		return errors.New("init failure")
+	}
+	return nil
+}
+
+func (c *testRestartingComponent) Run(ctx context.Context) error {
+	c.recorder.record("run")
+	if c.readiness.Status()["kafka"] {
+		c.recorder.record("started before ready")
+	}
+	if c.runs.Add(1) <= c.runFailures {
+		return errors.New("run failure")
+	}
+	<-ctx.Done()
+	return nil
+}
+
+func (c *testRestartingComponent) Ready(ctx context.Context) error {
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-time.After(time.Millisecond):
+	}
+	c.recorder.record("ready")
+	return nil
+}
+
+func TestRunRestartsThroughTheLifecycle(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		initFailures int32
+		runFailures  int32
+		expected     []string
+	}{
+		"init failures": {
+			initFailures: 2,
+			expected:     []string{"init", "init", "init", "run", "ready"},
+		},
+		"run failures before ready": {
+			runFailures: 2,
+			expected:    []string{"init", "run", "init", "run", "init", "run", "ready"},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			registry := health.NewRegistry()
+			service := &Service{
+				name:                  "test-service",
+				termSig:               make(chan os.Signal, 1),
+				observabilityProvider: &observability.Provider{},
+				readiness:             registry,
+			}
+			recorder := &lifecycleRecorder{}
+			restarting := &testRestartingComponent{
+				recorder: recorder, readiness: registry, initFailures: tt.initFailures, runFailures: tt.runFailures,
+			}
+			policy := RestartPolicy{MaxRestarts: 3, Window: time.Minute, InitialBackoff: time.Millisecond, MaxBackoff: time.Millisecond}
+			cmp, err := NewManagedComponent("kafka", restarting, WithRestartPolicy(policy))
+			require.NoError(t, err)
+
+			ctx, cnl := context.WithCancel(context.Background())
+			chErr := make(chan error)
+			go func() {
+				chErr <- service.Run(ctx, cmp)
+			}()
+
+			assert.Eventually(t, func() bool { return registry.Status()["kafka"] }, time.Second, time.Millisecond)
+			cnl()
+			require.NoError(t, <-chErr)
+			assert.Equal(t, tt.expected, recorder.get())
+		})
+	}
+}
+
+func TestRunRestartsThroughTheLifecycleAfterReady(t *testing.T) {
+	t.Parallel()
+
+	registry := health.NewRegistry()
+	service := &Service{
+		name:                  "test-service",
+		termSig:               make(chan os.Signal, 1),
+		observabilityProvider: &observability.Provider{},
+		readiness:             registry,
+	}
+	recorder := &lifecycleRecorder{}
+	flaky := &testFlakyComponent{}
+	flaky.failures.Store(1)
+	restarting := &testRestartingComponent{recorder: recorder, readiness: registry}
+	policy := RestartPolicy{MaxRestarts: 3, Window: time.Minute, InitialBackoff: time.Millisecond, MaxBackoff: time.Millisecond}
+	cmp, err := NewManagedComponent("kafka", &testReadyFlakyComponent{testRestartingComponent: restarting, flaky: flaky},
+		WithRestartPolicy(policy))
+	require.NoError(t, err)
+
+	ctx, cnl := context.WithCancel(context.Background())
+	chErr := make(chan error)
+	go func() {
+		chErr <- service.Run(ctx, cmp)
+	}()
+
+	assert.Eventually(t, func() bool { return flaky.runs.Load() == 2 && registry.Status()["kafka"] },
+		time.Second, time.Millisecond)
+	cnl()
+	require.NoError(t, <-chErr)
+	assert.Equal(t, []string{"init", "run", "ready", "init", "run", "ready"}, recorder.get())
+}
+
+// testReadyFlakyComponent fails after becoming ready.
+type testReadyFlakyComponent struct {
+	*testRestartingComponent
+	flaky *testFlakyComponent
+}
+
+func (c *testReadyFlakyComponent) Run(ctx context.Context) error {
+	c.recorder.record("run")
+	if c.readiness.Status()["kafka"] {
+		c.recorder.record("started before ready")
+	}
+	select {
+	case <-ctx.Done():
+		return nil
+	case <-time.After(10 * time.Millisecond):
+	}
+	return c.flaky.Run(ctx)
+}
+
+type testCheckedComponent struct {
+	ready atomic.Bool
+}
+
+func (c *testCheckedComponent) Run(ctx context.Context) error {
+	<-ctx.Done()
+	return nil
+}
+
+func (c *testCheckedComponent) IsReady() bool {
+	return c.ready.Load()
+}
+
+func TestRunAggregatesReadiness(t *testing.T) {
+	t.Parallel()
+
+	service := &Service{
+		name:                  "test-service",
+		termSig:               make(chan os.Signal, 1),
+		observabilityProvider: &observability.Provider{},
+		readiness:             health.NewRegistry(),
+	}
+	checked := &testCheckedComponent{}
+
+	ctx, cnl := context.WithCancel(context.Background())
+	chErr := make(chan error)
+	go func() {
+		chErr <- service.Run(ctx, checked)
+	}()
+
+	assert.Eventually(t, func() bool { return len(service.Readiness().Status()) == 1 }, time.Second, time.Millisecond)
+	assert.False(t, service.Readiness().Ready())
+	checked.ready.Store(true)
+	assert.Eventually(t, service.Readiness().Ready, time.Second, time.Millisecond)
+
+	cnl()
+	require.NoError(t, <-chErr)
+	assert.False(t, service.Readiness().Ready())
+	assert.True(t, service.Readiness().ShuttingDown())
+}
+
+type testReloadComponent struct {
+	reloads atomic.Int32
+	err     error
+}
+
+func (c *testReloadComponent) Run(ctx context.Context) error {
+	<-ctx.Done()
+	return nil
+}
+
+func (c *testReloadComponent) Reload(_ context.Context) error {
+	c.reloads.Add(1)
+	return c.err
+}
+
+func TestRunReloadsOnSIGHUPAndKeepsRunning(t *testing.T) {
+	var sighups, handlerCalls atomic.Int32
+	service := &Service{
+		name:                  "test-service",
+		termSig:               make(chan os.Signal, 1),
+		sighupHandler:         func() { sighups.Add(1) },
+		observabilityProvider: &observability.Provider{},
+		readiness:             health.NewRegistry(),
+		observabilityCfg:      observability.Config{LogConfig: log.Config{Level: "info"}},
+	}
+	require.NoError(t, WithReloadHandler("limits", func(_ context.Context) error {
+		handlerCalls.Add(1)
+		return nil
+	})(service))
+
+	reloaded := &testReloadComponent{}
+	failing := &testReloadComponent{err: errors.New("bad config")}
+
+	chErr := make(chan error)
+	go func() {
+		chErr <- service.Run(context.Background(), reloaded, failing)
+	}()
+
+	assert.Eventually(t, func() bool { return len(service.Readiness().Status()) == 2 }, time.Second, time.Millisecond)
+	assert.Eventually(t, service.Readiness().Ready, time.Second, time.Millisecond)
+
+	service.termSig <- syscall.SIGHUP
+	assert.Eventually(t, func() bool { return failing.reloads.Load() == 1 }, time.Second, time.Millisecond)
+	service.termSig <- syscall.SIGHUP
+	assert.Eventually(t, func() bool { return failing.reloads.Load() == 2 }, time.Second, time.Millisecond)
+
+	assert.Equal(t, int32(2), reloaded.reloads.Load())
+	assert.Equal(t, int32(2), sighups.Load())
+	assert.Equal(t, int32(2), handlerCalls.Load())
+	assert.True(t, service.Readiness().Ready())
+
+	service.termSig <- syscall.SIGTERM
+	require.NoError(t, <-chErr)
+}
+
+func TestReloadLogLevelKeepsRuntimeLevels(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(path, []byte("log:\n  level: info\n"), 0o600))
+	t.Cleanup(func() { require.NoError(t, config.SetFiles()) })
+	service, err := New("test", "", WithConfigFiles(path))
+	require.NoError(t, err)
+	t.Cleanup(func() { log.ResetLoggerLevel("kafka") })
+
+	// levels set at runtime.
+	require.NoError(t, log.SetLevel("debug"))
+	require.NoError(t, log.SetLoggerLevel("kafka", "error", time.Minute))
+	assertLevels := func(expectedBase slog.Level) {
+		t.Helper()
+		base, loggers := log.Levels()
+		assert.Equal(t, expectedBase, base)
+		idx := slices.IndexFunc(loggers, func(l log.LoggerLevel) bool { return l.Name == "kafka" })
+		require.NotEqual(t, -1, idx)
+		assert.Equal(t, slog.LevelError, loggers[idx].Level)
+		assert.False(t, loggers[idx].ExpiresAt.IsZero())
+	}
+
+	// the configured level is unchanged.
+	require.NoError(t, config.Reload())
+	require.NoError(t, service.reloadLogLevel())
+	assertLevels(slog.LevelDebug)
+
+	// the configured level changed.
+	require.NoError(t, os.WriteFile(path, []byte("log:\n  level: warn\n"), 0o600))
+	require.NoError(t, config.Reload())
+	require.NoError(t, service.reloadLogLevel())
+	assertLevels(slog.LevelWarn)
+	require.NoError(t, log.SetLevel("info"))
+}
+
+func TestRunStartsManagementServer(t *testing.T) {
+	t.Parallel()
+
+	listenCfg := &net.ListenConfig{}
+	listener, err := listenCfg.Listen(context.Background(), "tcp", ":0") //nolint:gosec
+	require.NoError(t, err)
+	port, ok := listener.Addr().(*net.TCPAddr)
+	require.True(t, ok)
+	require.NoError(t, listener.Close())
+
+	service := &Service{
+		name:                  "test-service",
+		version:               "1.0.0",
+		termSig:               make(chan os.Signal, 1),
+		observabilityProvider: &observability.Provider{},
+		readiness:             health.NewRegistry(),
+	}
+	require.NoError(t, WithManagementServer(port.Port)(service))
+
+	ctx, cnl := context.WithCancel(context.Background())
+	chErr := make(chan error)
+	go func() {
+		chErr <- service.Run(ctx, &testCheckedComponent{})
+	}()
+
+	assert.Eventually(t, func() bool {
+		return service.Readiness().Status()[managementComponentName]
+	}, time.Second, time.Millisecond)
+
+	// the connections are closed before the shutdown, which otherwise waits for the ones not used yet.
+	transport := &http.Transport{}
+	defer transport.CloseIdleConnections()
+	client := &http.Client{Transport: transport}
+	for _, path := range []string{"/alive", "/ready", "/debug/info", "/debug/pprof/", "/debug/vars/"} {
+		req, err := http.NewRequestWithContext(context.Background(), http.MethodGet,
+			fmt.Sprintf("http://localhost:%d%s", port.Port, path), nil)
+		require.NoError(t, err)
+		rsp, err := client.Do(req)
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusOK, rsp.StatusCode, path)
+		require.NoError(t, rsp.Body.Close())
+	}
+	transport.CloseIdleConnections()
+
+	cnl()
+	require.NoError(t, <-chErr)
+}
+
+func TestRunRejectsUnknownDependency(t *testing.T) {
+	t.Parallel()
+
+	service := &Service{
+		name:                  "test-service",
+		termSig:               make(chan os.Signal, 1),
+		observabilityProvider: &observability.Provider{},
+		readiness:             health.NewRegistry(),
+	}
+	http, err := NewManagedComponent("http", &testRunComponent{}, DependsOn("kafka"))
+	require.NoError(t, err)
+
+	err = service.Run(context.Background(), http)
+
+	require.EqualError(t, err, "component http depends on unknown component kafka")
+}
+
 func TestNew_WithJSONLogger_ConfiguresDefaultLogger(t *testing.T) {
 	output := captureStderr(t, func() {
 		svc, err := New("name", "1.0", WithJSONLogger())
@@ -0,0 +1,89 @@
+package patron
+
+import (
+	"errors"
+	"time"
+)
+
+// RestartPolicy configures how a component that returns an error while the Service is running is supervised.
+// Components without a restart policy escalate any error to a full service stop.
+type RestartPolicy struct {
+	// MaxRestarts is the number of restarts allowed within Window before escalating to a service stop.
+	// Zero allows unlimited restarts.
+	MaxRestarts uint
+	// Window is the period in which restarts are counted. Zero counts all restarts.
+	Window time.Duration
+	// InitialBackoff is the delay before the first restart, doubled on every subsequent restart in the window.
+	InitialBackoff time.Duration
+	// MaxBackoff caps the delay between restarts.
+	MaxBackoff time.Duration
+}
+
+func (p RestartPolicy) validate() error {
+	if p.InitialBackoff <= 0 {
+		return errors.New("negative or zero initial backoff provided")
+	}
+	if p.MaxBackoff < p.InitialBackoff {
+		return errors.New("max backoff has to be greater or equal than the initial backoff")
+	}
+	if p.Window < 0 {
+		return errors.New("negative restart window provided")
+	}
+	return nil
+}
+
+// WithRestartPolicy restarts the component with exponential backoff when it returns an error,
+// until the maximum number of restarts within the window is exceeded.
+func WithRestartPolicy(policy RestartPolicy) ComponentOptionFunc {
+	return func(mc *ManagedComponent) error {
+		if err := policy.validate(); err != nil {
+			return err
+		}
+		mc.restartPolicy = &policy
+		return nil
+	}
+}
+
+// maxRestartHistory bounds the restart history of unlimited policies, enough to reach any max backoff.
+const maxRestartHistory = 64
+
+// restartTracker keeps the restart history of a component.
+type restartTracker struct {
+	policy   RestartPolicy
+	restarts []time.Time
+	total    int
+}
+
+// next records a restart at the given time and returns the backoff to wait for.
+// It returns false if the restart is not allowed and the failure should be escalated.
+func (t *restartTracker) next(now time.Time) (time.Duration, bool) {
+	if t.policy.Window > 0 {
+		start := 0
+		for start < len(t.restarts) && now.Sub(t.restarts[start]) > t.policy.Window {
+			start++
+		}
+		t.restarts = t.restarts[start:]
+	}
+
+	if t.policy.MaxRestarts > 0 && uint(len(t.restarts)) >= t.policy.MaxRestarts {
+		return 0, false
+	}
+
+	backoff := t.policy.InitialBackoff
+	for i := 0; i < len(t.restarts) && backoff < t.policy.MaxBackoff; i++ {
+		backoff *= 2
+	}
+	t.restarts = append(t.restarts, now)
+	t.total++
+
+	// only the latest restarts are needed to enforce the limit and compute the backoff.
+	limit := maxRestartHistory
+	if t.policy.MaxRestarts > 0 {
+		limit = int(t.policy.MaxRestarts) //nolint:gosec
+	}
+	if len(t.restarts) > limit {
+		t.restarts = t.restarts[len(t.restarts)-limit:]
+	}
+
+	return min(backoff, t.policy.MaxBackoff), true
+}
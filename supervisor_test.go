@@ -0,0 +1,84 @@
+package patron
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithRestartPolicy(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		policy RestartPolicy
+		expErr string
+	}{
+		"success":                 {policy: RestartPolicy{MaxRestarts: 3, Window: time.Minute, InitialBackoff: time.Second, MaxBackoff: time.Minute}},
+		"zero initial backoff":    {policy: RestartPolicy{MaxBackoff: time.Minute}, expErr: "negative or zero initial backoff provided"},
+		"max less than initial":   {policy: RestartPolicy{InitialBackoff: time.Minute, MaxBackoff: time.Second}, expErr: "max backoff has to be greater or equal than the initial backoff"},
+		"negative restart window": {policy: RestartPolicy{Window: -time.Second, InitialBackoff: time.Second, MaxBackoff: time.Second}, expErr: "negative restart window provided"},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			mc := &ManagedComponent{}
+			err := WithRestartPolicy(tt.policy)(mc)
+			if tt.expErr != "" {
+				require.EqualError(t, err, tt.expErr)
+				assert.Nil(t, mc.restartPolicy)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tt.policy, *mc.restartPolicy)
+			}
+		})
+	}
+}
+
+func TestRestartTracker_Next(t *testing.T) {
+	t.Parallel()
+
+	tracker := &restartTracker{policy: RestartPolicy{
+		MaxRestarts:    3,
+		Window:         time.Minute,
+		InitialBackoff: time.Second,
+		MaxBackoff:     3 * time.Second,
+	}}
+	now := time.Now()
+
+	backoff, ok := tracker.next(now)
+	assert.True(t, ok)
+	assert.Equal(t, time.Second, backoff)
+
+	backoff, ok = tracker.next(now.Add(time.Second))
+	assert.True(t, ok)
+	assert.Equal(t, 2*time.Second, backoff)
+
+	backoff, ok = tracker.next(now.Add(2 * time.Second))
+	assert.True(t, ok)
+	assert.Equal(t, 3*time.Second, backoff)
+
+	_, ok = tracker.next(now.Add(3 * time.Second))
+	assert.False(t, ok)
+
+	backoff, ok = tracker.next(now.Add(2 * time.Minute))
+	assert.True(t, ok)
+	assert.Equal(t, time.Second, backoff)
+}
+
+func TestRestartTracker_Next_Unlimited(t *testing.T) {
+	t.Parallel()
+
+	tracker := &restartTracker{policy: RestartPolicy{InitialBackoff: time.Second, MaxBackoff: time.Second}}
+	now := time.Now()
+
+	for i := range 100 {
+		backoff, ok := tracker.next(now.Add(time.Duration(i) * time.Second))
+		assert.True(t, ok)
+		assert.Equal(t, time.Second, backoff)
+	}
+	assert.Len(t, tracker.restarts, maxRestartHistory)
+	assert.Equal(t, 100, tracker.total)
+}